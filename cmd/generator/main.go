@@ -9,8 +9,20 @@ import (
 	"strings"
 
 	"atomic-generator/pkg/generators"
-	"atomic-generator/pkg/models"
 	"atomic-generator/pkg/parser"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+
+	// Output targets register themselves with the targets package
+	_ "atomic-generator/pkg/targets/compose"
+	_ "atomic-generator/pkg/targets/html"
+	_ "atomic-generator/pkg/targets/native"
+	_ "atomic-generator/pkg/targets/next"
+	_ "atomic-generator/pkg/targets/react"
+	_ "atomic-generator/pkg/targets/svelte"
+	_ "atomic-generator/pkg/targets/swiftui"
+	_ "atomic-generator/pkg/targets/vue"
+	_ "atomic-generator/pkg/targets/webcomponents"
 )
 
 const version = "1.0.0"
@@ -19,13 +31,19 @@ func main() {
 	// Parse command line flags
 	inputFile := flag.String("input", "", "Path to atomic structure JSON file")
 	outputDir := flag.String("output", "./output", "Output directory for generated project")
+	target := flag.String("target", "react", "Output target: "+strings.Join(targets.Names(), ", "))
+	styleMode := flag.String("style-mode", "inline", "How component styles are emitted: inline or css-modules")
+	langFlag := flag.String("lang", "js", "Language of the generated sources: js or ts")
+	compositionFlag := flag.String("composition", "", "How molecules and organisms render their children: import (their components, the default of the react and next targets) or inline (the only choice of the other targets)")
+	strict := flag.Bool("strict", false, "Fail on var() references to undefined design tokens instead of warning")
 	showVersion := flag.Bool("version", false, "Show version information")
 	flag.Parse()
 
 	// Show version
 	if *showVersion {
 		fmt.Printf("Atomic Generator v%s\n", version)
-		fmt.Println("Generate React applications from atomic design structures")
+		fmt.Println("Generate web and mobile applications from atomic design structures")
+		fmt.Printf("Targets: %s\n", strings.Join(targets.Names(), ", "))
 		os.Exit(0)
 	}
 
@@ -39,32 +57,52 @@ func main() {
 		os.Exit(1)
 	}
 
+	mode, err := renderers.ParseStyleMode(*styleMode)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	lang, err := renderers.ParseLang(*langFlag)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	composition, err := renderers.ParseComposition(*compositionFlag)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
 	// Check if input file exists
 	if _, err := os.Stat(*inputFile); os.IsNotExist(err) {
 		log.Fatalf("Error: input file does not exist: %s", *inputFile)
 	}
 
-	// Create output directory if it doesn't exist
-	if err := os.MkdirAll(*outputDir, 0755); err != nil {
-		log.Fatalf("Error creating output directory: %v", err)
-	}
-
 	// Print banner
 	printBanner()
 
 	// Parse atomic structure
 	fmt.Printf("📖 Reading atomic structure from: %s\n", *inputFile)
 	atomicParser := parser.NewAtomicParser(*inputFile)
+	atomicParser.Strict = *strict
 	structure, err := atomicParser.Parse()
 	if err != nil {
 		log.Fatalf("Error parsing atomic structure: %v", err)
 	}
+	if warnings := atomicParser.Warnings(); len(warnings) > 0 {
+		fmt.Printf("⚠️  Design tokens: %v\n   (pass -strict to fail on these)\n\n", warnings)
+	}
+
+	// Index every component once; renderers share this registry
+	registry, err := parser.NewRegistry(structure)
+	if err != nil {
+		log.Fatalf("Error indexing atomic structure: %v", err)
+	}
 
 	fmt.Printf("✅ Parsed structure: %s v%s\n", structure.Project.Name, structure.Project.Version)
-	fmt.Printf("   - Atoms: %d\n", countAtoms(structure))
+	fmt.Printf("   - Atoms: %d\n", len(registry.AllAtoms()))
 	fmt.Printf("   - Molecules: %d\n", len(structure.Molecules))
 	fmt.Printf("   - Organisms: %d\n", len(structure.Organisms))
-	fmt.Printf("   - Pages: 1\n\n")
+	fmt.Printf("   - Pages: %d\n\n", len(structure.Pages))
 
 	// Generate project
 	absOutputDir, err := filepath.Abs(*outputDir)
@@ -72,15 +110,29 @@ func main() {
 		log.Fatalf("Error resolving output directory: %v", err)
 	}
 
-	fmt.Printf("🚀 Generating React project in: %s\n\n", absOutputDir)
-	
-	projectGenerator := generators.NewProjectGenerator(structure, absOutputDir)
+	projectGenerator, err := generators.NewProjectGenerator(registry, absOutputDir, *target, renderers.Options{
+		StyleMode:   mode,
+		Lang:        lang,
+		Composition: composition,
+	})
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	// Create output directory only once the structure and options are known
+	// to be valid
+	if err := os.MkdirAll(absOutputDir, 0755); err != nil {
+		log.Fatalf("Error creating output directory: %v", err)
+	}
+
+	fmt.Printf("🚀 Generating %s project in: %s\n\n", *target, absOutputDir)
+
 	if err := projectGenerator.Generate(); err != nil {
 		log.Fatalf("Error generating project: %v", err)
 	}
 
 	// Print success message
-	printSuccess(absOutputDir, structure.Project.Name)
+	printSuccess(absOutputDir, structure.Project.Name, projectGenerator.NextSteps())
 }
 
 func printBanner() {
@@ -88,7 +140,7 @@ func printBanner() {
 ╔═══════════════════════════════════════════╗
 ║                                           ║
 ║         ATOMIC GENERATOR                  ║
-║         React from JSON                   ║
+║         Web and mobile apps from JSON     ║
 ║         v` + version + `                            ║
 ║                                           ║
 ╚═══════════════════════════════════════════╝
@@ -96,7 +148,7 @@ func printBanner() {
 	fmt.Println(banner)
 }
 
-func printSuccess(outputDir, projectName string) {
+func printSuccess(outputDir, projectName string, nextSteps []string) {
 	fmt.Println("\n" + strings.Repeat("=", 50))
 	fmt.Println("🎉 PROJECT GENERATED SUCCESSFULLY!")
 	fmt.Println(strings.Repeat("=", 50))
@@ -104,17 +156,11 @@ func printSuccess(outputDir, projectName string) {
 	fmt.Printf("Location: %s\n", outputDir)
 	fmt.Println("\nNext steps:")
 	fmt.Printf("\n  1. cd %s\n", outputDir)
-	fmt.Println("  2. npm install")
-	fmt.Println("  3. npm run dev")
-	fmt.Println("\nYour React application will be running at http://localhost:3000")
+	// The last step says what to expect once the project runs
+	last := len(nextSteps) - 1
+	for i, step := range nextSteps[:last] {
+		fmt.Printf("  %d. %s\n", i+2, step)
+	}
+	fmt.Printf("\n%s\n", nextSteps[last])
 	fmt.Println(strings.Repeat("=", 50) + "\n")
 }
-
-func countAtoms(structure *models.AtomicStructure) int {
-	return len(structure.Atoms.Images) +
-		len(structure.Atoms.Headings) +
-		len(structure.Atoms.Links) +
-		len(structure.Atoms.Buttons) +
-		len(structure.Atoms.Inputs) +
-		len(structure.Atoms.Text)
-}
@@ -0,0 +1,185 @@
+package format
+
+import "strings"
+
+// cssNode is a statement of a stylesheet: a rule or at-rule with a block,
+// a declaration (or an at-rule without block) or a comment
+type cssNode struct {
+	// text is the prelude of a block, the declaration or the comment
+	text     string
+	comment  bool
+	block    bool
+	children []*cssNode
+}
+
+// CSS formats a stylesheet: one selector and one declaration per line, a
+// blank line between rules, and "property: value;" declarations with
+// collapsed whitespace. Comments stay above what they comment.
+func CSS(src string) string {
+	p := &cssParser{src: src}
+	nodes := p.block()
+
+	var b strings.Builder
+	printCSS(&b, nodes, 0)
+	if b.Len() == 0 {
+		return ""
+	}
+	return b.String()
+}
+
+type cssParser struct {
+	src string
+	pos int
+}
+
+// block parses statements until the } closing the current block
+func (p *cssParser) block() []*cssNode {
+	var nodes []*cssNode
+	for {
+		for p.pos < len(p.src) && strings.ContainsRune(" \t\r\n", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		if p.pos >= len(p.src) {
+			return nodes
+		}
+
+		switch {
+		case p.src[p.pos] == '}':
+			p.pos++
+			return nodes
+		case strings.HasPrefix(p.src[p.pos:], "/*"):
+			end := strings.Index(p.src[p.pos+2:], "*/")
+			if end < 0 {
+				end = len(p.src) - p.pos - 4
+			}
+			nodes = append(nodes, &cssNode{text: p.src[p.pos : p.pos+end+4], comment: true})
+			p.pos += end + 4
+		default:
+			start := p.pos
+			p.pos = p.statementEnd()
+			text := p.src[start:p.pos]
+			if p.pos < len(p.src) && p.src[p.pos] == '{' {
+				p.pos++
+				nodes = append(nodes, &cssNode{text: text, block: true, children: p.block()})
+				continue
+			}
+			if p.pos < len(p.src) && p.src[p.pos] == ';' {
+				p.pos++
+			}
+			if strings.TrimSpace(text) != "" {
+				nodes = append(nodes, &cssNode{text: text})
+			}
+		}
+	}
+}
+
+// statementEnd returns the position of the {, ; or } ending the statement
+// at the current position, skipping strings and parentheses
+func (p *cssParser) statementEnd() int {
+	depth := 0
+	for i := p.pos; i < len(p.src); i++ {
+		switch c := p.src[i]; c {
+		case '\'', '"':
+			i = skipString(p.src, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '{', ';', '}':
+			if depth <= 0 {
+				return i
+			}
+		}
+	}
+	return len(p.src)
+}
+
+func printCSS(b *strings.Builder, nodes []*cssNode, level int) {
+	pad := indentation(level)
+	for i, node := range nodes {
+		if i > 0 && (node.block || node.comment) && !nodes[i-1].comment && (nodes[i-1].block || node.block) {
+			b.WriteString("\n")
+		}
+
+		switch {
+		case node.comment:
+			b.WriteString(pad + node.text + "\n")
+		case node.block:
+			prelude := collapse(node.text)
+			if !strings.HasPrefix(prelude, "@") {
+				prelude = strings.Join(splitTopLevel(prelude, ','), ",\n"+pad)
+			}
+			b.WriteString(pad + prelude + " {\n")
+			printCSS(b, node.children, level+1)
+			b.WriteString(pad + "}\n")
+		default:
+			b.WriteString(pad + declaration(node.text) + ";\n")
+		}
+	}
+}
+
+// declaration formats "property: value", keeping custom property values
+// as they are
+func declaration(text string) string {
+	text = strings.TrimSpace(text)
+	colon := strings.IndexByte(text, ':')
+	if colon < 0 || strings.HasPrefix(text, "@") {
+		return collapse(text)
+	}
+	property := strings.TrimSpace(text[:colon])
+	value := strings.TrimSpace(text[colon+1:])
+	if !strings.HasPrefix(property, "--") {
+		value = collapse(value)
+	}
+	return property + ": " + value
+}
+
+// collapse trims text and collapses its runs of whitespace outside strings
+// into single spaces
+func collapse(text string) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			space = b.Len() > 0
+		case c == '\'' || c == '"':
+			end := skipString(text, i)
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteString(text[i:end])
+			i = end - 1
+		default:
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// splitTopLevel splits a selector list at the separators outside
+// parentheses and strings, trimming every part
+func splitTopLevel(text string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\'' || c == '"':
+			i = skipString(text, i) - 1
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, strings.TrimSpace(text[start:i]))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.TrimSpace(text[start:]))
+}
@@ -0,0 +1,45 @@
+// Package format pretty prints the JavaScript, JSX, TypeScript and CSS the
+// generator emits, with Prettier-like rules: two-space indentation derived
+// from the code's own nesting, at most one blank line in a row, and lines
+// longer than 80 columns broken at their outermost group. Generated files
+// are formatted before they are written, so the output is consistent
+// whatever the templates and renderers produced, without Node.
+package format
+
+import (
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// printWidth is the column lines are broken at
+const printWidth = 80
+
+// indentUnit is one level of indentation
+const indentUnit = "  "
+
+// File formats a generated file according to its extension. Files that
+// aren't JavaScript, TypeScript or CSS are returned unchanged.
+func File(name, content string) string {
+	switch path.Ext(name) {
+	case ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx":
+		return JS(content)
+	case ".css":
+		return CSS(content)
+	default:
+		return content
+	}
+}
+
+// width returns the number of columns a line takes
+func width(line string) int {
+	return utf8.RuneCountInString(line)
+}
+
+// indentation returns the indentation of the given level
+func indentation(level int) string {
+	if level < 0 {
+		level = 0
+	}
+	return strings.Repeat(indentUnit, level)
+}
@@ -0,0 +1,726 @@
+package format
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind classifies the tokens of JavaScript and JSX source
+type tokenKind int
+
+const (
+	// tokWord is an identifier, keyword or number (member accesses such as
+	// styles.card stay one word)
+	tokWord tokenKind = iota
+	// tokPunct is an operator or separator
+	tokPunct
+	// tokOpen and tokClose are brackets: ( [ {
+	tokOpen
+	tokClose
+	// tokString is a string or template literal, which can span lines
+	tokString
+	// tokComment is a line or block comment
+	tokComment
+	// tokTagOpen starts an opening tag (<div, or the < of a fragment)
+	tokTagOpen
+	// tokTagEnd is the > ending an opening tag
+	tokTagEnd
+	// tokSelfClose is the /> ending a self-closing tag
+	tokSelfClose
+	// tokCloseTag is a closing tag (</div>, </>)
+	tokCloseTag
+	// tokText is JSX text
+	tokText
+	tokNewline
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// space reports whether whitespace separates the token from the
+	// previous one on its line
+	space bool
+	// container marks the braces of JSX expression containers
+	container bool
+}
+
+// delta returns how the token changes the nesting depth
+func (t token) delta() int {
+	switch t.kind {
+	case tokOpen, tokTagOpen:
+		return 1
+	case tokClose, tokSelfClose, tokCloseTag:
+		return -1
+	}
+	return 0
+}
+
+// closes reports whether the token closes a level, so a line starting with
+// it is indented like the line that opened the level
+func (t token) closes() bool {
+	switch t.kind {
+	case tokClose, tokTagEnd, tokSelfClose, tokCloseTag:
+		return true
+	}
+	return false
+}
+
+// multiline reports whether the token spans lines
+func (t token) multiline() bool {
+	return strings.Contains(t.text, "\n")
+}
+
+// JS formats JavaScript, JSX or TypeScript source. Lines are indented one
+// level deeper than the line opening the innermost unclosed bracket or
+// tag, so brackets opened together (a callback's "({") add a single level.
+func JS(src string) string {
+	var out []string
+	// open holds the level of the line each unclosed bracket or tag
+	// opened on
+	var open []int
+	pop := func() int {
+		if len(open) == 0 {
+			return 0
+		}
+		level := open[len(open)-1]
+		open = open[:len(open)-1]
+		return level
+	}
+
+	blank := false
+	for _, line := range splitLines(scanJS(src)) {
+		if len(line) == 0 {
+			blank = len(out) > 0
+			continue
+		}
+		// No blank lines at the start or the end of a block
+		if blank && !line[0].closes() && !opensBlock(out) {
+			out = append(out, "")
+		}
+		blank = false
+
+		level := 0
+		if len(open) > 0 {
+			level = open[len(open)-1] + 1
+		}
+		rest := line
+		for len(rest) > 0 && rest[0].closes() {
+			level = pop()
+			if rest[0].kind == tokTagEnd {
+				// The tag's children are nested in the element
+				open = append(open, level)
+			}
+			rest = rest[1:]
+		}
+		if continues(line[0]) {
+			level++
+		}
+		for _, t := range rest {
+			switch t.kind {
+			case tokOpen, tokTagOpen:
+				open = append(open, level)
+			case tokTagEnd:
+				open = append(open, pop())
+			case tokClose, tokSelfClose, tokCloseTag:
+				pop()
+			}
+		}
+
+		out = append(out, breakLine(line, level)...)
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// opensBlock reports whether the last printed line opens a block
+func opensBlock(out []string) bool {
+	if len(out) == 0 {
+		return true
+	}
+	last := strings.TrimSpace(out[len(out)-1])
+	return strings.HasSuffix(last, "{") || strings.HasSuffix(last, "(") || strings.HasSuffix(last, "[")
+}
+
+// continues reports whether a line starting with the token continues the
+// expression of the previous line (a ternary branch, a chained call)
+func continues(t token) bool {
+	if t.kind != tokPunct {
+		return false
+	}
+	switch t.text {
+	case "?", ":", ".", "?.", "&&", "||", "??":
+		return true
+	}
+	return false
+}
+
+// splitLines splits tokens at line breaks. Tokens spanning lines (template
+// literals, block comments) stay on the line they start on.
+func splitLines(tokens []token) [][]token {
+	lines := [][]token{nil}
+	for _, t := range tokens {
+		if t.kind == tokNewline {
+			lines = append(lines, nil)
+			continue
+		}
+		lines[len(lines)-1] = append(lines[len(lines)-1], t)
+	}
+	return lines
+}
+
+// render joins the tokens of a line
+func render(tokens []token) string {
+	var b strings.Builder
+	for i, t := range tokens {
+		if i > 0 && t.space {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.text)
+	}
+	return b.String()
+}
+
+// breakLine prints a line at the given level, breaking it at its outermost
+// group while it is too long
+func breakLine(tokens []token, level int) []string {
+	line := indentation(level) + render(tokens)
+	if width(line) <= printWidth {
+		return []string{line}
+	}
+	for _, t := range tokens {
+		if t.multiline() {
+			return []string{line}
+		}
+	}
+
+	g := findGroup(tokens, 0, len(tokens))
+	if g == nil {
+		return []string{line}
+	}
+
+	lines := breakLine(tokens[:g.head], level)
+	for _, item := range g.items {
+		lines = append(lines, breakLine(item, level+1)...)
+	}
+	return append(lines, breakLine(tokens[g.tail:], level)...)
+}
+
+// group is a part of a line that can be broken: the tokens before head stay
+// on the first line, every item gets a line of its own one level deeper,
+// and the tokens from tail on go on the last line
+type group struct {
+	head, tail int
+	items      [][]token
+}
+
+// findGroup returns the outermost breakable group between from and to,
+// leftmost first. Groups that can't be broken themselves (a call with a
+// single argument, a JSX expression container) are searched for groups
+// inside them.
+func findGroup(tokens []token, from, to int) *group {
+	for i := from; i < to; i++ {
+		switch tokens[i].kind {
+		case tokOpen:
+			end := matching(tokens, i, to)
+			if end < 0 {
+				continue
+			}
+			if !tokens[i].container {
+				if g := bracketGroup(tokens, i, end); g != nil {
+					return g
+				}
+			}
+			if g := findGroup(tokens, i+1, end); g != nil {
+				return g
+			}
+			i = end
+		case tokTagOpen:
+			end := tagEnd(tokens, i, to)
+			if end < 0 {
+				continue
+			}
+			if tokens[end].kind == tokTagEnd {
+				if closing := matching(tokens, i, to); closing >= 0 {
+					if g := childrenGroup(tokens, end, closing); g != nil {
+						return g
+					}
+				}
+			}
+			if g := attrGroup(tokens, i, end); g != nil {
+				return g
+			}
+			if g := findGroup(tokens, i+1, end); g != nil {
+				return g
+			}
+			i = end
+		}
+	}
+	return nil
+}
+
+// matching returns the index of the token closing the level opened at
+// open, or -1 when it isn't closed before to
+func matching(tokens []token, open, to int) int {
+	depth := 0
+	for i := open; i < to; i++ {
+		depth += tokens[i].delta()
+		if depth == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// tagEnd returns the index of the > or /> ending the opening tag started
+// at open, or -1
+func tagEnd(tokens []token, open, to int) int {
+	depth := 0
+	for i := open + 1; i < to; i++ {
+		switch {
+		case depth == 0 && (tokens[i].kind == tokTagEnd || tokens[i].kind == tokSelfClose):
+			return i
+		case tokens[i].kind == tokOpen:
+			depth++
+		case tokens[i].kind == tokClose:
+			depth--
+		}
+	}
+	return -1
+}
+
+// split splits tokens into items at the tokens sep accepts, at the top
+// level. Separators stay at the end of their item.
+func split(tokens []token, sep func(token) bool) [][]token {
+	var items [][]token
+	var item []token
+	depth := 0
+	for _, t := range tokens {
+		item = append(item, t)
+		depth += t.delta()
+		if depth == 0 && sep(t) {
+			items = append(items, item)
+			item = nil
+		}
+	}
+	if len(item) > 0 {
+		items = append(items, item)
+	}
+	return items
+}
+
+// bracketGroup breaks brackets into one entry per line: the elements of a
+// list, the arguments of a call, the properties of an object or the
+// statements of a block. Lists get a trailing comma.
+func bracketGroup(tokens []token, open, close int) *group {
+	inner := tokens[open+1 : close]
+	if len(inner) == 0 {
+		return nil
+	}
+
+	separators := 0
+	items := split(inner, func(t token) bool {
+		if t.kind == tokPunct && (t.text == "," || t.text == ";") {
+			separators++
+			return true
+		}
+		return false
+	})
+	if separators == 0 && tokens[open].text != "{" {
+		// A single argument or element: break inside it instead
+		return nil
+	}
+
+	block := open > 0 && (tokens[open-1].text == "=>" || tokens[open-1].kind == tokClose)
+	last := items[len(items)-1]
+	lastToken := last[len(last)-1]
+	if !block && lastToken.text != "," && lastToken.text != ";" && !(tokens[open].text == "(" && last[0].text == "...") {
+		items[len(items)-1] = append(last[:len(last):len(last)], token{kind: tokPunct, text: ","})
+	}
+	return &group{head: open + 1, tail: close, items: items}
+}
+
+// attrGroup breaks an opening tag into one attribute per line
+func attrGroup(tokens []token, open, end int) *group {
+	attrs := tokens[open+1 : end]
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	var items [][]token
+	depth := 0
+	for _, t := range attrs {
+		if depth == 0 && (t.space || len(items) == 0) {
+			items = append(items, nil)
+		}
+		items[len(items)-1] = append(items[len(items)-1], t)
+		depth += t.delta()
+	}
+	return &group{head: open + 1, tail: end, items: items}
+}
+
+// childrenGroup moves the children of an element to lines of their own.
+// Text next to an element keeps its line, where line breaks would change
+// the whitespace between them.
+func childrenGroup(tokens []token, end, closing int) *group {
+	children := tokens[end+1 : closing]
+	if len(children) == 0 {
+		return nil
+	}
+
+	var items [][]token
+	for i := 0; i < len(children); i++ {
+		last := i
+		if children[i].kind == tokOpen || children[i].kind == tokTagOpen {
+			last = matching(children, i, len(children))
+			if last < 0 {
+				return nil
+			}
+		}
+		items = append(items, children[i:last+1])
+		i = last
+	}
+	if len(items) > 1 {
+		for _, item := range items {
+			if item[0].kind == tokText {
+				return nil
+			}
+		}
+	}
+	return &group{head: end + 1, tail: closing, items: items}
+}
+
+// mode is what the JS scanner is reading
+type mode int
+
+const (
+	modeJS mode = iota
+	// modeTag is inside an opening tag, reading attributes
+	modeTag
+	// modeChildren is between an opening and a closing tag
+	modeChildren
+)
+
+type frame struct {
+	mode mode
+	// container marks the frame of a JSX expression container
+	container bool
+}
+
+// jsScanner splits JavaScript and JSX into tokens
+type jsScanner struct {
+	src    string
+	pos    int
+	tokens []token
+	space  bool
+	stack  []frame
+}
+
+func scanJS(src string) []token {
+	s := &jsScanner{src: src, stack: []frame{{mode: modeJS}}}
+	for s.pos < len(s.src) {
+		switch s.stack[len(s.stack)-1].mode {
+		case modeTag:
+			s.tag()
+		case modeChildren:
+			s.children()
+		default:
+			s.js()
+		}
+	}
+	return s.tokens
+}
+
+func (s *jsScanner) emit(kind tokenKind, text string) {
+	s.tokens = append(s.tokens, token{kind: kind, text: text, space: s.space})
+	s.space = false
+}
+
+func (s *jsScanner) push(f frame) {
+	s.stack = append(s.stack, f)
+}
+
+// pop leaves the current frame and returns it; the base frame stays
+func (s *jsScanner) pop() frame {
+	f := s.stack[len(s.stack)-1]
+	if len(s.stack) > 1 {
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+	return f
+}
+
+func (s *jsScanner) peek(offset int) byte {
+	if s.pos+offset < len(s.src) {
+		return s.src[s.pos+offset]
+	}
+	return 0
+}
+
+// whitespace consumes a blank or a line break, reporting whether it did
+func (s *jsScanner) whitespace() bool {
+	switch s.src[s.pos] {
+	case ' ', '\t', '\r':
+		s.pos++
+		s.space = true
+		return true
+	case '\n':
+		s.pos++
+		s.emit(tokNewline, "")
+		return true
+	}
+	return false
+}
+
+// punctuators lists the operators of more than one character, longest
+// first
+var punctuators = []string{
+	"===", "!==", "...", "**=", "&&=", "||=", "??=",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "??", "?.", "++", "--", "+=", "-=", "*=", "/=", "%=", "**",
+}
+
+func (s *jsScanner) js() {
+	if s.whitespace() {
+		return
+	}
+
+	c := s.src[s.pos]
+	switch {
+	case c == '/' && s.peek(1) == '/':
+		end := strings.IndexByte(s.src[s.pos:], '\n')
+		if end < 0 {
+			end = len(s.src) - s.pos
+		}
+		s.emit(tokComment, strings.TrimRight(s.src[s.pos:s.pos+end], " \t\r"))
+		s.pos += end
+	case c == '/' && s.peek(1) == '*':
+		end := strings.Index(s.src[s.pos+2:], "*/")
+		if end < 0 {
+			end = len(s.src) - s.pos - 4
+		}
+		s.emit(tokComment, s.src[s.pos:s.pos+end+4])
+		s.pos += end + 4
+	case c == '\'' || c == '"':
+		start := s.pos
+		s.pos = skipString(s.src, s.pos)
+		s.emit(tokString, s.src[start:s.pos])
+	case c == '`':
+		start := s.pos
+		s.pos = skipTemplate(s.src, s.pos)
+		s.emit(tokString, s.src[start:s.pos])
+	case c == '(' || c == '[' || c == '{':
+		s.emit(tokOpen, string(c))
+		s.pos++
+		s.push(frame{mode: modeJS})
+	case c == ')' || c == ']' || c == '}':
+		s.emit(tokClose, string(c))
+		s.pos++
+		if s.pop().container {
+			s.tokens[len(s.tokens)-1].container = true
+		}
+	case c == '<' && s.jsxStarts():
+		s.openTag()
+	case isWordByte(c):
+		start := s.pos
+		for s.pos < len(s.src) && (isWordByte(s.src[s.pos]) || s.src[s.pos] == '.' && s.pos+1 < len(s.src) && isWordByte(s.src[s.pos+1]) && s.pos > start) {
+			s.pos++
+		}
+		s.emit(tokWord, s.src[start:s.pos])
+	default:
+		for _, p := range punctuators {
+			if strings.HasPrefix(s.src[s.pos:], p) {
+				s.emit(tokPunct, p)
+				s.pos += len(p)
+				return
+			}
+		}
+		_, size := utf8.DecodeRuneInString(s.src[s.pos:])
+		s.emit(tokPunct, s.src[s.pos:s.pos+size])
+		s.pos += size
+	}
+}
+
+// jsxStarts reports whether the < at the current position opens a JSX
+// element rather than comparing: it must be followed by a tag name or >
+// (a fragment) and come where an expression starts
+func (s *jsScanner) jsxStarts() bool {
+	next := s.peek(1)
+	if next != '>' && !(next >= 'a' && next <= 'z' || next >= 'A' && next <= 'Z') {
+		return false
+	}
+	for i := len(s.tokens) - 1; i >= 0; i-- {
+		t := s.tokens[i]
+		switch t.kind {
+		case tokNewline, tokComment:
+			continue
+		case tokOpen, tokPunct:
+			return true
+		case tokWord:
+			return t.text == "return" || t.text == "default" || t.text == "yield" || t.text == "await"
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// openTag reads < and the tag name, and starts reading attributes
+func (s *jsScanner) openTag() {
+	start := s.pos
+	s.pos++
+	for s.pos < len(s.src) && isTagByte(s.src[s.pos]) {
+		s.pos++
+	}
+	s.emit(tokTagOpen, s.src[start:s.pos])
+	s.push(frame{mode: modeTag})
+}
+
+func (s *jsScanner) tag() {
+	if s.whitespace() {
+		return
+	}
+
+	c := s.src[s.pos]
+	switch {
+	case c == '/' && s.peek(1) == '>':
+		s.emit(tokSelfClose, "/>")
+		s.pos += 2
+		s.pop()
+	case c == '>':
+		s.emit(tokTagEnd, ">")
+		s.pos++
+		s.stack[len(s.stack)-1].mode = modeChildren
+	case c == '{':
+		s.openContainer()
+	case c == '"' || c == '\'':
+		// JSX attribute strings have no escapes
+		end := strings.IndexByte(s.src[s.pos+1:], c)
+		if end < 0 {
+			end = len(s.src) - s.pos - 2
+		}
+		s.emit(tokString, s.src[s.pos:s.pos+end+2])
+		s.pos += end + 2
+	case c == '=':
+		s.emit(tokPunct, "=")
+		s.pos++
+	default:
+		start := s.pos
+		for s.pos < len(s.src) && !strings.ContainsRune(" \t\r\n=>/{", rune(s.src[s.pos])) {
+			s.pos++
+		}
+		if s.pos == start {
+			s.pos++
+		}
+		s.emit(tokWord, s.src[start:s.pos])
+	}
+}
+
+// openContainer reads the { of a JSX expression container
+func (s *jsScanner) openContainer() {
+	s.emit(tokOpen, "{")
+	s.tokens[len(s.tokens)-1].container = true
+	s.pos++
+	s.push(frame{mode: modeJS, container: true})
+}
+
+func (s *jsScanner) children() {
+	c := s.src[s.pos]
+	switch {
+	case c == '\n':
+		s.whitespace()
+	case c == '{':
+		s.openContainer()
+	case c == '<' && s.peek(1) == '/':
+		end := strings.IndexByte(s.src[s.pos:], '>')
+		if end < 0 {
+			end = len(s.src) - s.pos - 1
+		}
+		s.emit(tokCloseTag, strings.Join(strings.Fields(s.src[s.pos:s.pos+end+1]), ""))
+		s.pos += end + 1
+		s.pop()
+	case c == '<':
+		s.openTag()
+	default:
+		start := s.pos
+		for s.pos < len(s.src) && !strings.ContainsRune("<{\n", rune(s.src[s.pos])) {
+			s.pos++
+		}
+		text := s.src[start:s.pos]
+		lineStart := len(s.tokens) == 0 || s.tokens[len(s.tokens)-1].kind == tokNewline
+		if lineStart {
+			text = strings.TrimLeft(text, " \t\r")
+		}
+		if s.pos == len(s.src) || s.src[s.pos] == '\n' {
+			// JSX drops the whitespace around line breaks
+			text = strings.TrimRight(text, " \t\r")
+		}
+		if text != "" {
+			s.emit(tokText, text)
+		}
+	}
+}
+
+// skipString returns the position after the string literal starting at
+// pos
+func skipString(src string, pos int) int {
+	quote := src[pos]
+	for i := pos + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case quote, '\n':
+			return i + 1
+		}
+	}
+	return len(src)
+}
+
+// skipTemplate returns the position after the template literal starting
+// at pos, including its ${} substitutions
+func skipTemplate(src string, pos int) int {
+	for i := pos + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '`':
+			return i + 1
+		case '$':
+			if i+1 < len(src) && src[i+1] == '{' {
+				i = skipExpression(src, i+2) - 1
+			}
+		}
+	}
+	return len(src)
+}
+
+// skipExpression returns the position after the } closing the substitution
+// whose expression starts at pos
+func skipExpression(src string, pos int) int {
+	depth := 1
+	for i := pos; i < len(src); i++ {
+		switch src[i] {
+		case '\'', '"':
+			i = skipString(src, i) - 1
+		case '`':
+			i = skipTemplate(src, i) - 1
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(src)
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+func isTagByte(c byte) bool {
+	return isWordByte(c) || c == '-' || c == '.' || c == ':'
+}
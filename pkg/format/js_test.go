@@ -0,0 +1,88 @@
+package format
+
+import "testing"
+
+func TestJS(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "indents blocks",
+			src:  "function a() {\nreturn 1;\n}\n",
+			want: "function a() {\n  return 1;\n}\n",
+		},
+		{
+			name: "collapses blank lines",
+			src:  "const a = 1;\n\n\n\nconst b = 2;\n",
+			want: "const a = 1;\n\nconst b = 2;\n",
+		},
+		{
+			name: "drops blank lines at block edges",
+			src:  "function a() {\n\nreturn 1;\n\n}\n",
+			want: "function a() {\n  return 1;\n}\n",
+		},
+		{
+			name: "ignores brackets in strings and template literals",
+			src:  "const s = '{ not a block';\nconst t = `line\n  {keep}\nend`;\nif (x) {\ny();\n}\n",
+			want: "const s = '{ not a block';\nconst t = `line\n  {keep}\nend`;\nif (x) {\n  y();\n}\n",
+		},
+		{
+			name: "ignores brackets in comments",
+			src:  "// comment {\nconst a = 1;\n/* block\n { */\nconst b = 2;\n",
+			want: "// comment {\nconst a = 1;\n/* block\n { */\nconst b = 2;\n",
+		},
+		{
+			name: "nests JSX children",
+			src:  "const El = () => (\n<div className=\"a\">\n<span>{text}</span>\n<img src=\"x\" />\n</div>\n);\n",
+			want: "const El = () => (\n  <div className=\"a\">\n    <span>{text}</span>\n    <img src=\"x\" />\n  </div>\n);\n",
+		},
+		{
+			name: "breaks long objects at the outermost group",
+			src:  "const styles = { container: { display: 'flex', flexDirection: 'column', alignItems: 'center' }, x: 1 };\n",
+			want: "const styles = {\n  container: { display: 'flex', flexDirection: 'column', alignItems: 'center' },\n  x: 1,\n};\n",
+		},
+		{
+			name: "breaks long calls one argument per line",
+			src:  "callSomething(argumentNumberOne, argumentNumberTwo, argumentNumberThree, argumentFour);\n",
+			want: "callSomething(\n  argumentNumberOne,\n  argumentNumberTwo,\n  argumentNumberThree,\n  argumentFour,\n);\n",
+		},
+		{
+			name: "indents ternary branches",
+			src:  "const a = cond\n? one\n: two;\n",
+			want: "const a = cond\n  ? one\n  : two;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JS(tt.src)
+			if got != tt.want {
+				t.Errorf("JS() =\n%s\nwant\n%s", got, tt.want)
+			}
+			// Formatting is idempotent
+			if again := JS(got); again != got {
+				t.Errorf("JS(JS()) =\n%s\nwant\n%s", again, got)
+			}
+		})
+	}
+}
+
+func TestJSEmpty(t *testing.T) {
+	if got := JS("\n\n"); got != "" {
+		t.Errorf("JS() = %q, want empty", got)
+	}
+}
+
+func TestFile(t *testing.T) {
+	src := "if (x) {\ny();\n}\n"
+	for name, want := range map[string]string{
+		"App.jsx":   "if (x) {\n  y();\n}\n",
+		"main.ts":   "if (x) {\n  y();\n}\n",
+		"README.md": src,
+	} {
+		if got := File(name, src); got != want {
+			t.Errorf("File(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
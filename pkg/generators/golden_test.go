@@ -0,0 +1,206 @@
+package generators
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"atomic-generator/pkg/parser"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+
+	_ "atomic-generator/pkg/targets/compose"
+	_ "atomic-generator/pkg/targets/html"
+	_ "atomic-generator/pkg/targets/native"
+	_ "atomic-generator/pkg/targets/next"
+	_ "atomic-generator/pkg/targets/react"
+	_ "atomic-generator/pkg/targets/svelte"
+	_ "atomic-generator/pkg/targets/swiftui"
+	_ "atomic-generator/pkg/targets/vue"
+	_ "atomic-generator/pkg/targets/webcomponents"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenCases are the projects generated from testdata/structure.json and
+// compared file by file with testdata/golden/<name>. Golden files carry a
+// .golden suffix so generated dotfiles like .gitignore stay inert.
+var goldenCases = []struct {
+	name    string
+	target  string
+	options renderers.Options
+}{
+	{"compose", "compose", renderers.Options{}},
+	{"html", "html", renderers.Options{}},
+	{"next-ts", "next", renderers.Options{Lang: renderers.LangTS}},
+	{"react-native", "react-native", renderers.Options{}},
+	{"react-ts", "react", renderers.Options{Lang: renderers.LangTS}},
+	{"svelte", "svelte", renderers.Options{}},
+	{"swiftui", "swiftui", renderers.Options{}},
+	{"vue", "vue", renderers.Options{}},
+	{"webcomponents", "webcomponents", renderers.Options{}},
+}
+
+func TestGolden(t *testing.T) {
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := generate(t, tc.target, tc.options)
+			dir := filepath.Join("testdata", "golden", tc.name)
+
+			if *update {
+				if err := os.RemoveAll(dir); err != nil {
+					t.Fatal(err)
+				}
+				for path, content := range got {
+					golden := filepath.Join(dir, path+".golden")
+					if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(golden, []byte(content), 0644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return
+			}
+
+			want := readTree(t, dir)
+			for _, path := range sortedPaths(want) {
+				content, exists := got[path]
+				switch {
+				case !exists:
+					t.Errorf("%s: not generated", path)
+				case content != want[path]:
+					t.Errorf("%s: differs from its golden file (run go test ./pkg/generators -update to accept)\n--- got ---\n%s", path, content)
+				}
+			}
+			for _, path := range sortedPaths(got) {
+				if _, exists := want[path]; !exists {
+					t.Errorf("%s: generated but has no golden file", path)
+				}
+			}
+		})
+	}
+}
+
+// TestDeterministicOutput checks that generating the fixture twice gives
+// byte-identical projects on every target
+func TestDeterministicOutput(t *testing.T) {
+	for _, target := range targets.Names() {
+		t.Run(target, func(t *testing.T) {
+			first := generate(t, target, renderers.Options{})
+			second := generate(t, target, renderers.Options{})
+			if !reflect.DeepEqual(sortedPaths(first), sortedPaths(second)) {
+				t.Fatalf("generated files differ between runs: %v and %v", sortedPaths(first), sortedPaths(second))
+			}
+			for path, content := range first {
+				if second[path] != content {
+					t.Errorf("%s differs between runs", path)
+				}
+			}
+		})
+	}
+}
+
+// TestImportComposition checks that only the React targets accept
+// -composition=import, the other targets always inlining children
+func TestImportComposition(t *testing.T) {
+	importing := map[string]bool{"next": true, "react": true}
+	structure, err := parser.NewAtomicParser(filepath.Join("testdata", "structure.json")).Parse()
+	if err != nil {
+		t.Fatalf("parsing fixture: %v", err)
+	}
+	registry, err := parser.NewRegistry(structure)
+	if err != nil {
+		t.Fatalf("indexing fixture: %v", err)
+	}
+
+	for _, target := range targets.Names() {
+		_, err := NewProjectGenerator(registry, t.TempDir(), target, renderers.Options{Composition: renderers.CompositionImport})
+		switch {
+		case importing[target] && err != nil:
+			t.Errorf("%s: import composition rejected: %v", target, err)
+		case !importing[target] && err == nil:
+			t.Errorf("%s: import composition accepted, but the target inlines children", target)
+		}
+	}
+}
+
+// TestSectionProps checks that every target passes the prop values of
+// the fixture's layout sections to their organisms
+func TestSectionProps(t *testing.T) {
+	for _, target := range targets.Names() {
+		t.Run(target, func(t *testing.T) {
+			for _, content := range generate(t, target, renderers.Options{}) {
+				if strings.Contains(content, "Hello from the layout") {
+					return
+				}
+			}
+			t.Error("the headline the hero section passes is not generated")
+		})
+	}
+}
+
+// generate builds the fixture project for a target and returns its files
+// by slash-separated path
+func generate(t *testing.T, target string, options renderers.Options) map[string]string {
+	t.Helper()
+	structure, err := parser.NewAtomicParser(filepath.Join("testdata", "structure.json")).Parse()
+	if err != nil {
+		t.Fatalf("parsing fixture: %v", err)
+	}
+	registry, err := parser.NewRegistry(structure)
+	if err != nil {
+		t.Fatalf("indexing fixture: %v", err)
+	}
+
+	out := t.TempDir()
+	generator, err := NewProjectGenerator(registry, out, target, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := generator.Generate(); err != nil {
+		t.Fatalf("generating %s: %v", target, err)
+	}
+
+	return readTree(t, out)
+}
+
+// readTree returns the contents of the files under dir by slash-separated
+// path, with the .golden suffix removed
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[strings.TrimSuffix(filepath.ToSlash(rel), ".golden")] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func sortedPaths(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,49 @@
+//go:build tsc
+
+package generators
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"atomic-generator/pkg/renderers"
+)
+
+// TestTypeScriptCompiles installs the dependencies of the TypeScript
+// projects generated from the fixture and type-checks them with tsc. It
+// needs npm and network access, hence the build tag:
+//
+//	go test -tags tsc ./pkg/generators -run TestTypeScriptCompiles
+func TestTypeScriptCompiles(t *testing.T) {
+	if _, err := exec.LookPath("npm"); err != nil {
+		t.Skip("npm not found")
+	}
+	for _, target := range []string{"react", "next"} {
+		t.Run(target, func(t *testing.T) {
+			out := t.TempDir()
+			for path, content := range generate(t, target, renderers.Options{Lang: renderers.LangTS}) {
+				file := filepath.Join(out, filepath.FromSlash(path))
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			run(t, out, "npm", "install", "--no-audit", "--no-fund")
+			run(t, out, "npx", "tsc", "--noEmit")
+		})
+	}
+}
+
+func run(t *testing.T, dir, name string, args ...string) {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%s %v: %v\n%s", name, args, err, output)
+	}
+}
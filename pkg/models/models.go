@@ -79,11 +79,14 @@ type LayoutSection struct {
 	Organism  string   `json:"organism,omitempty"`
 	Organisms []string `json:"organisms,omitempty"`
 	Position  string   `json:"position,omitempty"`
+	// Props are the prop values passed to Organism
+	Props map[string]string `json:"props,omitempty"`
 }
 
 // Atom represents the smallest UI element
 type Atom struct {
 	ID       string                 `json:"id"`
+	Extends  string                 `json:"extends,omitempty"` // ID of the base atom
 	Subatom  string                 `json:"subatom"`
 	Config   map[string]interface{} `json:"config,omitempty"`
 	Styles   map[string]interface{} `json:"styles,omitempty"`
@@ -99,20 +102,59 @@ type Atoms struct {
 	Text     []Atom `json:"text,omitempty"`
 }
 
-// Molecule represents a combination of atoms
+// Molecule represents a combination of atoms and nested molecules
 type Molecule struct {
 	ID         string                 `json:"id"`
+	Extends    string                 `json:"extends,omitempty"` // ID of the base molecule
 	Type       string                 `json:"type"`
-	Atoms      map[string]string      `json:"atoms,omitempty"`
+	Atoms      RefMap                 `json:"atoms,omitempty"`
+	Molecules  RefMap                 `json:"molecules,omitempty"` // rendered after the atoms
 	Responsive []ResponsiveConfig     `json:"responsive,omitempty"`
 	Styles     map[string]interface{} `json:"styles,omitempty"`
 	States     map[string]map[string]interface{} `json:"states,omitempty"`
 	Events     map[string]Event       `json:"events,omitempty"`
+	Props      map[string]Prop        `json:"props,omitempty"`
+}
+
+// Prop types
+const (
+	PropText    = "text"
+	PropHref    = "href"
+	PropSrc     = "src"
+	PropVariant = "variant"
+)
+
+// Prop is a prop of a molecule or organism component, set by the instances
+// referencing it
+type Prop struct {
+	// Type is text, href or src (the content of the atom in Slot), or
+	// variant (a set of style overrides)
+	Type string `json:"type"`
+	// Slot is the atom or molecule slot the prop sets. A variant without
+	// slot restyles the component itself.
+	Slot string `json:"slot,omitempty"`
+	// Prop is the prop of the molecule in Slot the value is passed to,
+	// the prop's own name by default
+	Prop string `json:"prop,omitempty"`
+	// Default is the value when an instance passes none. Content props
+	// default to the atom's own content.
+	Default string `json:"default,omitempty"`
+	// Variants maps the values of a variant prop to the styles they apply
+	Variants map[string]map[string]interface{} `json:"variants,omitempty"`
+}
+
+// ChildProp returns the name of the prop of the molecule in Slot the value
+// is passed to
+func (p Prop) ChildProp(name string) string {
+	if p.Prop != "" {
+		return p.Prop
+	}
+	return name
 }
 
 type ResponsiveConfig struct {
-	Breakpoint string            `json:"breakpoint"`
-	Atoms      map[string]string `json:"atoms"`
+	Breakpoint string `json:"breakpoint"`
+	Atoms      RefMap `json:"atoms"`
 }
 
 type Event struct {
@@ -127,9 +169,10 @@ type Event struct {
 // Organism represents a complex UI section
 type Organism struct {
 	ID             string                 `json:"id"`
+	Extends        string                 `json:"extends,omitempty"` // ID of the base organism
 	Type           string                 `json:"type"`
-	Atoms          map[string]string      `json:"atoms,omitempty"`
-	Molecules      interface{}            `json:"molecules,omitempty"` // can be map or array
+	Atoms          RefMap                 `json:"atoms,omitempty"`
+	Molecules      MoleculeRefs           `json:"molecules,omitempty"` // can be map or array
 	Sections       []OrganismSection      `json:"sections,omitempty"`
 	Config         map[string]interface{} `json:"config,omitempty"`
 	Styles         map[string]interface{} `json:"styles,omitempty"`
@@ -139,6 +182,8 @@ type Organism struct {
 	Behavior       *Behavior              `json:"behavior,omitempty"`
 	ControlStyles  map[string]map[string]interface{} `json:"controlStyles,omitempty"`
 	IndicatorStyles *IndicatorStyles      `json:"indicatorStyles,omitempty"`
+	Props          map[string]Prop        `json:"props,omitempty"`
+	Repeat         *Repeat                `json:"repeat,omitempty"`
 }
 
 type OrganismSection struct {
@@ -163,12 +208,17 @@ type IndicatorStyles struct {
 	DotActive map[string]interface{} `json:"dotActive"`
 }
 
-// AtomicStructure is the root structure containing all elements
+// AtomicStructure is the root structure containing all elements.
+// Single-page files may still use the singular clump/page/layout keys; the
+// parser folds them into Clumps, Pages and Layouts.
 type AtomicStructure struct {
 	Project   Project     `json:"project"`
 	Clump     Clump       `json:"clump"`
 	Page      Page        `json:"page"`
 	Layout    Layout      `json:"layout"`
+	Clumps    []Clump     `json:"clumps,omitempty"`
+	Pages     []Page      `json:"pages,omitempty"`
+	Layouts   []Layout    `json:"layouts,omitempty"`
 	Atoms     Atoms       `json:"atoms"`
 	Molecules []Molecule  `json:"molecules"`
 	Organisms []Organism  `json:"organisms"`
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Ref is a named reference from a molecule or organism slot to another
+// component's ID. In JSON it is the ID, or an instance passing prop values:
+// {"id": "value_card", "props": {"title": "Our mission"}}.
+type Ref struct {
+	Key   string
+	ID    string
+	Props map[string]string
+}
+
+// refInstance is the JSON object form of a Ref
+type refInstance struct {
+	ID    string            `json:"id"`
+	Props map[string]string `json:"props,omitempty"`
+}
+
+func (r *Ref) unmarshal(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var instance refInstance
+		if err := json.Unmarshal(trimmed, &instance); err != nil {
+			return err
+		}
+		r.ID, r.Props = instance.ID, instance.Props
+		return nil
+	}
+	return json.Unmarshal(trimmed, &r.ID)
+}
+
+func (r Ref) marshal() []byte {
+	var data []byte
+	if len(r.Props) > 0 {
+		data, _ = json.Marshal(refInstance{ID: r.ID, Props: r.Props})
+	} else {
+		data, _ = json.Marshal(r.ID)
+	}
+	return data
+}
+
+// RefMap is a JSON object of slot name → component ID. Unlike a Go map it
+// keeps the order the keys were declared in, so children always render in
+// the order they were written.
+type RefMap []Ref
+
+// Get returns the ID referenced by a slot name
+func (m RefMap) Get(key string) (string, bool) {
+	for _, ref := range m {
+		if ref.Key == key {
+			return ref.ID, true
+		}
+	}
+	return "", false
+}
+
+// Keys returns the slot names in declaration order
+func (m RefMap) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for _, ref := range m {
+		keys = append(keys, ref.Key)
+	}
+	return keys
+}
+
+// IDs returns the referenced IDs in declaration order
+func (m RefMap) IDs() []string {
+	ids := make([]string, 0, len(m))
+	for _, ref := range m {
+		ids = append(ids, ref.ID)
+	}
+	return ids
+}
+
+func (m *RefMap) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*m = nil
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	refs := RefMap{}
+	for decoder.More() {
+		keyToken, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := keyToken.(string)
+
+		var raw json.RawMessage
+		ref := Ref{Key: key}
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		if err := ref.unmarshal(raw); err != nil {
+			return fmt.Errorf("reference %q must be an ID or an {id, props} object: %w", key, err)
+		}
+		refs = append(refs, ref)
+	}
+
+	*m = refs
+	return expectDelim(decoder, '}')
+}
+
+func (m RefMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, ref := range m {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(ref.Key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(ref.marshal())
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MoleculeRefs lists the molecules of an organism. In JSON it is either an
+// array of references (List is true and every Key is empty) or an object of
+// slot name → reference. Both forms keep declaration order.
+type MoleculeRefs struct {
+	Refs RefMap
+	List bool
+}
+
+func (m *MoleculeRefs) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return fmt.Errorf("molecules must be an array of references: %w", err)
+		}
+		m.List = true
+		m.Refs = make(RefMap, 0, len(items))
+		for i, item := range items {
+			var ref Ref
+			if err := ref.unmarshal(item); err != nil {
+				return fmt.Errorf("molecules[%d] must be an ID or an {id, props} object: %w", i, err)
+			}
+			m.Refs = append(m.Refs, ref)
+		}
+		return nil
+	}
+
+	m.List = false
+	return m.Refs.UnmarshalJSON(trimmed)
+}
+
+func (m MoleculeRefs) MarshalJSON() ([]byte, error) {
+	if m.List {
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, ref := range m.Refs {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(ref.marshal())
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	}
+	return m.Refs.MarshalJSON()
+}
+
+func expectDelim(decoder *json.Decoder, want json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, token)
+	}
+	return nil
+}
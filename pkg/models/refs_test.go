@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRefMapKeepsDeclarationOrder(t *testing.T) {
+	source := `{"title":"heading","body":"text","action":{"id":"cta","props":{"label":"Go"}},"aside":"note"}`
+
+	var refs RefMap
+	if err := json.Unmarshal([]byte(source), &refs); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"title", "body", "action", "aside"}; !reflect.DeepEqual(refs.Keys(), want) {
+		t.Errorf("Keys() = %v, want %v", refs.Keys(), want)
+	}
+	if want := []string{"heading", "text", "cta", "note"}; !reflect.DeepEqual(refs.IDs(), want) {
+		t.Errorf("IDs() = %v, want %v", refs.IDs(), want)
+	}
+	if id, _ := refs.Get("action"); id != "cta" || refs[2].Props["label"] != "Go" {
+		t.Errorf("action = %q %v, want cta with its label", id, refs[2].Props)
+	}
+
+	data, err := json.Marshal(refs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != source {
+		t.Errorf("MarshalJSON() = %s, want %s", data, source)
+	}
+}
+
+func TestMoleculeRefsForms(t *testing.T) {
+	tests := []struct {
+		source string
+		list   bool
+		keys   []string
+	}{
+		{`["card",{"id":"card","props":{"title":"Two"}},"footer"]`, true, []string{"", "", ""}},
+		{`{"main":"card","side":"footer"}`, false, []string{"main", "side"}},
+	}
+	for _, tt := range tests {
+		var refs MoleculeRefs
+		if err := json.Unmarshal([]byte(tt.source), &refs); err != nil {
+			t.Fatalf("%s: %v", tt.source, err)
+		}
+		if refs.List != tt.list || !reflect.DeepEqual(refs.Refs.Keys(), tt.keys) {
+			t.Errorf("%s: List = %v, keys %q, want %v, %q", tt.source, refs.List, refs.Refs.Keys(), tt.list, tt.keys)
+		}
+		data, err := json.Marshal(refs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.source {
+			t.Errorf("MarshalJSON() = %s, want %s", data, tt.source)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Repeat renders a molecule once per item of a data list, e.g. the cards of
+// a grid or the slides of a carousel
+type Repeat struct {
+	Molecule string      `json:"molecule"`
+	Items    RepeatItems `json:"items"`
+	// Key is the item field identifying each item. Without it items are
+	// keyed by position.
+	Key string `json:"key,omitempty"`
+}
+
+// RepeatItems are the prop values of each repeated molecule. In JSON they
+// are an array of objects, or the path of a JSON file holding that array,
+// relative to the atomic structure; the parser loads the file into Values.
+type RepeatItems struct {
+	File   string
+	Values []map[string]string
+}
+
+func (r *RepeatItems) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '"' {
+		return json.Unmarshal(trimmed, &r.File)
+	}
+	return r.Decode(trimmed)
+}
+
+// Decode parses an array of items
+func (r *RepeatItems) Decode(data []byte) error {
+	if err := json.Unmarshal(data, &r.Values); err != nil {
+		return fmt.Errorf("items must be a file path or an array of objects of string prop values: %w", err)
+	}
+	return nil
+}
+
+func (r RepeatItems) MarshalJSON() ([]byte, error) {
+	if r.File != "" {
+		return json.Marshal(r.File)
+	}
+	return json.Marshal(r.Values)
+}
@@ -2,14 +2,21 @@ package parser
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"atomic-generator/pkg/models"
 )
 
 type AtomicParser struct {
 	filePath string
+
+	// Strict fails parsing on var() references to undefined design
+	// tokens instead of reporting them as warnings
+	Strict   bool
+	warnings ValidationErrors
 }
 
 func NewAtomicParser(filePath string) *AtomicParser {
@@ -32,70 +39,87 @@ func (p *AtomicParser) Parse() (*models.AtomicStructure, error) {
 		return nil, fmt.Errorf("error parsing JSON: %w", err)
 	}
 
-	// Validate
-	if err := p.validate(&structure); err != nil {
-		return nil, fmt.Errorf("validation error: %w", err)
+	// Load repeated items kept in their own files
+	if err := p.loadRepeatItems(&structure); err != nil {
+		return nil, err
 	}
 
-	return &structure, nil
-}
+	// Check design token names, and references where they are written,
+	// before inheritance copies them
+	tokenErrors, warnings := CheckTokens(&structure)
+	p.warnings = warnings
 
-// validate performs basic validation on the structure
-func (p *AtomicParser) validate(s *models.AtomicStructure) error {
-	if s.Project.ID == "" {
-		return fmt.Errorf("project.id is required")
-	}
-	if s.Project.Name == "" {
-		return fmt.Errorf("project.name is required")
+	// Flatten the components extending a base, then validate. Broken
+	// extends are reported with the validator's problems, so they don't
+	// hide the rest of the file's
+	problems := append(tokenErrors, resolveExtends(&structure)...)
+	if err := p.validate(&structure); err != nil {
+		var validation ValidationErrors
+		if !errors.As(err, &validation) {
+			return nil, fmt.Errorf("validation error: %w", err)
+		}
+		problems = append(problems, validation...)
 	}
-	if s.Page.ID == "" {
-		return fmt.Errorf("page.id is required")
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("validation error: %w", problems)
 	}
-	if s.Layout.ID == "" {
-		return fmt.Errorf("layout.id is required")
+
+	// Design token references fail parsing only once the structure is
+	// known to be valid
+	if p.Strict && len(p.warnings) > 0 {
+		return nil, fmt.Errorf("design token error: %w", p.warnings)
 	}
-	
-	return nil
+
+	// Fold the single-page form into the page/layout/clump lists
+	p.normalize(&structure)
+
+	return &structure, nil
 }
 
-// GetAtomByID finds an atom by its ID across all atom categories
-func (p *AtomicParser) GetAtomByID(structure *models.AtomicStructure, atomID string) *models.Atom {
-	// Search in all atom categories
-	allAtoms := [][]models.Atom{
-		structure.Atoms.Images,
-		structure.Atoms.Headings,
-		structure.Atoms.Links,
-		structure.Atoms.Buttons,
-		structure.Atoms.Inputs,
-		structure.Atoms.Text,
-	}
+// Warnings returns the problems found by the last Parse that don't fail it
+// outside strict mode
+func (p *AtomicParser) Warnings() ValidationErrors {
+	return p.warnings
+}
 
-	for _, atomList := range allAtoms {
-		for i := range atomList {
-			if atomList[i].ID == atomID {
-				return &atomList[i]
-			}
-		}
+// normalize prepends the singular clump, page and layout (if present) to
+// their list counterparts so the rest of the generator only deals with lists
+func (p *AtomicParser) normalize(s *models.AtomicStructure) {
+	if s.Clump.ID != "" {
+		s.Clumps = append([]models.Clump{s.Clump}, s.Clumps...)
+	}
+	if s.Page.ID != "" {
+		s.Pages = append([]models.Page{s.Page}, s.Pages...)
+	}
+	if s.Layout.ID != "" {
+		s.Layouts = append([]models.Layout{s.Layout}, s.Layouts...)
 	}
-	return nil
 }
 
-// GetMoleculeByID finds a molecule by its ID
-func (p *AtomicParser) GetMoleculeByID(structure *models.AtomicStructure, moleculeID string) *models.Molecule {
-	for i := range structure.Molecules {
-		if structure.Molecules[i].ID == moleculeID {
-			return &structure.Molecules[i]
+// loadRepeatItems reads the items of every repeat given as a file path,
+// relative to the atomic structure's directory
+func (p *AtomicParser) loadRepeatItems(s *models.AtomicStructure) error {
+	for i := range s.Organisms {
+		repeat := s.Organisms[i].Repeat
+		if repeat == nil || repeat.Items.File == "" {
+			continue
+		}
+		path := repeat.Items.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(p.filePath), path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading items of organism %s: %w", s.Organisms[i].ID, err)
+		}
+		if err := repeat.Items.Decode(data); err != nil {
+			return fmt.Errorf("error parsing %s: %w", path, err)
 		}
 	}
 	return nil
 }
 
-// GetOrganismByID finds an organism by its ID
-func (p *AtomicParser) GetOrganismByID(structure *models.AtomicStructure, organismID string) *models.Organism {
-	for i := range structure.Organisms {
-		if structure.Organisms[i].ID == organismID {
-			return &structure.Organisms[i]
-		}
-	}
-	return nil
+// validate checks the whole structure and reports every problem at once
+func (p *AtomicParser) validate(s *models.AtomicStructure) error {
+	return NewValidator(s).Validate()
 }
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLoadsRepeatItemsFile(t *testing.T) {
+	path := writeStructure(t, `{
+		"project": {"id": "p", "name": "P"},
+		"atoms": {"text": [{"id": "body", "subatom": "Text"}]},
+		"molecules": [{"id": "card", "atoms": {"body": "body"}, "props": {"body": {"type": "text", "slot": "body"}}}],
+		"organisms": [{"id": "grid", "repeat": {"molecule": "card", "items": "data/cards.json"}}],
+		"layouts": [{"id": "main", "structure": [{"organism": "grid"}]}],
+		"pages": [{"id": "home", "route": "/", "layout": "main"}]
+	}`)
+	data := filepath.Join(filepath.Dir(path), "data")
+	if err := os.MkdirAll(data, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(data, "cards.json"), []byte(`[{"body": "One"}, {"body": "Two"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	structure, err := NewAtomicParser(path).Parse()
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	items := structure.Organisms[0].Repeat.Items
+	want := []map[string]string{{"body": "One"}, {"body": "Two"}}
+	if items.File != "data/cards.json" || !reflect.DeepEqual(items.Values, want) {
+		t.Errorf("items = %q %v, want data/cards.json %v", items.File, items.Values, want)
+	}
+}
+
+func TestParseReportsMissingRepeatItemsFile(t *testing.T) {
+	_, err := parseFile(t, `{
+		"project": {"id": "p", "name": "P"},
+		"organisms": [{"id": "grid", "repeat": {"molecule": "card", "items": "missing.json"}}]
+	}`)
+	if err == nil || !strings.Contains(err.Error(), "organism grid") {
+		t.Errorf("Parse() = %v, want an error reading the items of organism grid", err)
+	}
+}
@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// breakpointUnits lists the units a brand breakpoint can be written in
+var breakpointUnits = []string{"px", "rem", "em"}
+
+// Breakpoint is a named viewport range. It starts at MinWidth and ends just
+// before the next larger breakpoint.
+type Breakpoint struct {
+	Name     string
+	MinWidth string
+
+	value float64
+	unit  string
+}
+
+// Breakpoints are the brand breakpoints sorted from the narrowest up
+type Breakpoints []Breakpoint
+
+// ParseBreakpoints parses Brand.Breakpoints ("tablet": "768px") and sorts
+// them by width
+func ParseBreakpoints(raw map[string]string) (Breakpoints, error) {
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var breakpoints Breakpoints
+	for _, name := range names {
+		width := raw[name]
+		value, unit, err := parseLength(width)
+		if err != nil {
+			return nil, fmt.Errorf("breakpoint %q: %w", name, err)
+		}
+		breakpoints = append(breakpoints, Breakpoint{
+			Name:     name,
+			MinWidth: width,
+			value:    value,
+			unit:     unit,
+		})
+	}
+
+	sort.Slice(breakpoints, func(i, j int) bool {
+		a, b := breakpoints[i], breakpoints[j]
+		if a.pixels() != b.pixels() {
+			return a.pixels() < b.pixels()
+		}
+		return a.Name < b.Name
+	})
+	return breakpoints, nil
+}
+
+func parseLength(width string) (float64, string, error) {
+	width = strings.TrimSpace(width)
+	for _, unit := range breakpointUnits {
+		if strings.HasSuffix(width, unit) {
+			value, err := strconv.ParseFloat(strings.TrimSuffix(width, unit), 64)
+			if err != nil || value < 0 {
+				break
+			}
+			return value, unit, nil
+		}
+	}
+	return 0, "", fmt.Errorf("%q is not a width in px, rem or em", width)
+}
+
+// pixels approximates the width in px so mixed units still sort
+func (b Breakpoint) pixels() float64 {
+	if b.unit == "px" {
+		return b.value
+	}
+	return b.value * 16
+}
+
+// Names returns the breakpoint names from the narrowest up
+func (b Breakpoints) Names() []string {
+	names := make([]string, 0, len(b))
+	for _, bp := range b {
+		names = append(names, bp.Name)
+	}
+	return names
+}
+
+// Has reports whether a breakpoint is declared
+func (b Breakpoints) Has(name string) bool {
+	_, ok := b.index(name)
+	return ok
+}
+
+func (b Breakpoints) index(name string) (int, bool) {
+	for i, bp := range b {
+		if bp.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// MediaQuery returns the CSS media query matching exactly the range of a
+// breakpoint. The narrowest breakpoint also covers anything narrower and the
+// widest anything wider, so every viewport falls in exactly one range.
+func (b Breakpoints) MediaQuery(name string) (string, bool) {
+	i, ok := b.index(name)
+	if !ok {
+		return "", false
+	}
+	return b.rangeQuery(i, i), true
+}
+
+// MediaQueries returns the media queries covering a set of breakpoints,
+// merging adjacent breakpoints into a single range
+func (b Breakpoints) MediaQueries(names []string) []string {
+	included := make([]bool, len(b))
+	for _, name := range names {
+		if i, ok := b.index(name); ok {
+			included[i] = true
+		}
+	}
+
+	var queries []string
+	for i := 0; i < len(b); i++ {
+		if !included[i] {
+			continue
+		}
+		last := i
+		for last+1 < len(b) && included[last+1] {
+			last++
+		}
+		queries = append(queries, b.rangeQuery(i, last))
+		i = last
+	}
+	return queries
+}
+
+// rangeQuery returns the media query from breakpoint first up to the end of
+// breakpoint last
+func (b Breakpoints) rangeQuery(first, last int) string {
+	var conditions []string
+	if first > 0 {
+		conditions = append(conditions, fmt.Sprintf("(min-width: %s)", b[first].MinWidth))
+	}
+	if last < len(b)-1 {
+		next := b[last+1]
+		// Stop just short of the next breakpoint so ranges don't overlap
+		max := strconv.FormatFloat(next.value-0.02, 'f', -1, 64)
+		conditions = append(conditions, fmt.Sprintf("(max-width: %s%s)", max, next.unit))
+	}
+
+	if len(conditions) == 0 {
+		return "all"
+	}
+	return strings.Join(conditions, " and ")
+}
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBreakpointsSortsByWidth(t *testing.T) {
+	breakpoints, err := ParseBreakpoints(map[string]string{
+		"desktop": "1024px",
+		"mobile":  "0px",
+		"tablet":  "48rem",
+		"wide":    "90em",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"mobile", "tablet", "desktop", "wide"}; !reflect.DeepEqual(breakpoints.Names(), want) {
+		t.Errorf("Names() = %v, want %v", breakpoints.Names(), want)
+	}
+
+	if _, err := ParseBreakpoints(map[string]string{"tablet": "768"}); err == nil {
+		t.Error("ParseBreakpoints() accepted a width without a unit")
+	}
+}
+
+func TestBreakpointMediaQueries(t *testing.T) {
+	breakpoints, err := ParseBreakpoints(map[string]string{
+		"mobile":  "0px",
+		"tablet":  "768px",
+		"desktop": "1024px",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"mobile":  "(max-width: 767.98px)",
+		"tablet":  "(min-width: 768px) and (max-width: 1023.98px)",
+		"desktop": "(min-width: 1024px)",
+	}
+	for name, want := range tests {
+		if got, _ := breakpoints.MediaQuery(name); got != want {
+			t.Errorf("MediaQuery(%s) = %q, want %q", name, got, want)
+		}
+	}
+	if _, ok := breakpoints.MediaQuery("watch"); ok {
+		t.Error("MediaQuery() found an undeclared breakpoint")
+	}
+
+	queries := breakpoints.MediaQueries([]string{"desktop", "mobile"})
+	if want := []string{"(max-width: 767.98px)", "(min-width: 1024px)"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("MediaQueries(desktop, mobile) = %v, want %v", queries, want)
+	}
+	queries = breakpoints.MediaQueries([]string{"tablet", "desktop"})
+	if want := []string{"(min-width: 768px)"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("MediaQueries(tablet, desktop) = %v, want %v", queries, want)
+	}
+	if queries = breakpoints.MediaQueries(breakpoints.Names()); !reflect.DeepEqual(queries, []string{"all"}) {
+		t.Errorf("MediaQueries(every breakpoint) = %v, want [all]", queries)
+	}
+}
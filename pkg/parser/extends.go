@@ -0,0 +1,257 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"atomic-generator/pkg/models"
+)
+
+// resolveExtends flattens the atoms, molecules and organisms extending a
+// base: each takes the base's fields it leaves empty, and its config, styles
+// and states are deep-merged over the base's, its own values winning. Bases
+// are resolved first, so chains flatten all the way down. Unknown bases and
+// cycles are returned as validation errors.
+func resolveExtends(s *models.AtomicStructure) ValidationErrors {
+	var errors ValidationErrors
+
+	atoms := newExtension("atom", func(a *models.Atom) (string, string) { return a.ID, a.Extends }, mergeAtom)
+	categories := []struct {
+		name  string
+		atoms []models.Atom
+	}{
+		{"images", s.Atoms.Images},
+		{"headings", s.Atoms.Headings},
+		{"links", s.Atoms.Links},
+		{"buttons", s.Atoms.Buttons},
+		{"inputs", s.Atoms.Inputs},
+		{"text", s.Atoms.Text},
+	}
+	for _, category := range categories {
+		for i := range category.atoms {
+			atoms.add(&category.atoms[i], fmt.Sprintf("atoms.%s[%d]", category.name, i))
+		}
+	}
+	errors = append(errors, atoms.resolve()...)
+
+	molecules := newExtension("molecule", func(m *models.Molecule) (string, string) { return m.ID, m.Extends }, mergeMolecule)
+	for i := range s.Molecules {
+		molecules.add(&s.Molecules[i], fmt.Sprintf("molecules[%d]", i))
+	}
+	errors = append(errors, molecules.resolve()...)
+
+	organisms := newExtension("organism", func(o *models.Organism) (string, string) { return o.ID, o.Extends }, mergeOrganism)
+	for i := range s.Organisms {
+		organisms.add(&s.Organisms[i], fmt.Sprintf("organisms[%d]", i))
+	}
+	errors = append(errors, organisms.resolve()...)
+	return errors
+}
+
+// extension resolves the extends chains of one kind of component
+type extension[T any] struct {
+	kind  string
+	ids   func(*T) (id, base string)
+	merge func(child, base *T)
+
+	entries []*T
+	paths   map[*T]string
+	// byID holds the first component declared with each ID; duplicates
+	// are reported by the validator
+	byID map[string]*T
+
+	resolved map[*T]bool
+	// chain is the components being resolved, each extending the next
+	chain  []string
+	errors ValidationErrors
+}
+
+func newExtension[T any](kind string, ids func(*T) (string, string), merge func(child, base *T)) *extension[T] {
+	return &extension[T]{
+		kind:     kind,
+		ids:      ids,
+		merge:    merge,
+		paths:    make(map[*T]string),
+		byID:     make(map[string]*T),
+		resolved: make(map[*T]bool),
+	}
+}
+
+func (e *extension[T]) add(item *T, path string) {
+	e.entries = append(e.entries, item)
+	e.paths[item] = path
+	if id, _ := e.ids(item); id != "" && e.byID[id] == nil {
+		e.byID[id] = item
+	}
+}
+
+func (e *extension[T]) resolve() ValidationErrors {
+	for _, item := range e.entries {
+		e.resolveItem(item)
+	}
+	return e.errors
+}
+
+// resolveItem merges the resolved base of item into it
+func (e *extension[T]) resolveItem(item *T) {
+	if e.resolved[item] {
+		return
+	}
+	id, baseID := e.ids(item)
+	if baseID == "" {
+		e.resolved[item] = true
+		return
+	}
+
+	path := e.paths[item] + ".extends"
+	base := e.byID[baseID]
+	switch {
+	case base == nil:
+		e.errors = append(e.errors, ValidationError{Path: path, Message: fmt.Sprintf("unknown base %s %q", e.kind, baseID)})
+	case baseID == id || slices.Contains(e.chain, baseID):
+		cycle := []string{id, baseID}
+		if start := slices.Index(e.chain, baseID); start >= 0 {
+			cycle = append(slices.Clone(e.chain[start:]), id, baseID)
+		}
+		e.errors = append(e.errors, ValidationError{Path: path, Message: fmt.Sprintf("%s extends cycle: %s", e.kind, strings.Join(cycle, " → "))})
+	default:
+		e.chain = append(e.chain, id)
+		e.resolveItem(base)
+		e.chain = e.chain[:len(e.chain)-1]
+		e.merge(item, base)
+	}
+	e.resolved[item] = true
+}
+
+func mergeAtom(child, base *models.Atom) {
+	if child.Subatom == "" {
+		child.Subatom = base.Subatom
+	}
+	child.Config = mergeValues(base.Config, child.Config)
+	child.Styles = mergeValues(base.Styles, child.Styles)
+	child.States = mergeStates(base.States, child.States)
+}
+
+func mergeMolecule(child, base *models.Molecule) {
+	if child.Type == "" {
+		child.Type = base.Type
+	}
+	child.Atoms = mergeRefs(base.Atoms, child.Atoms)
+	child.Molecules = mergeRefs(base.Molecules, child.Molecules)
+	if len(child.Responsive) == 0 {
+		child.Responsive = base.Responsive
+	}
+	child.Styles = mergeValues(base.Styles, child.Styles)
+	child.States = mergeStates(base.States, child.States)
+	child.Events = mergeMap(base.Events, child.Events)
+	child.Props = mergeMap(base.Props, child.Props)
+}
+
+func mergeOrganism(child, base *models.Organism) {
+	if child.Type == "" {
+		child.Type = base.Type
+	}
+	child.Atoms = mergeRefs(base.Atoms, child.Atoms)
+	// Keyed molecules merge by slot; a list replaces the base's
+	switch {
+	case len(child.Molecules.Refs) == 0:
+		child.Molecules = base.Molecules
+	case !child.Molecules.List && !base.Molecules.List:
+		child.Molecules.Refs = mergeRefs(base.Molecules.Refs, child.Molecules.Refs)
+	}
+	if len(child.Sections) == 0 {
+		child.Sections = base.Sections
+	}
+	child.Config = mergeValues(base.Config, child.Config)
+	child.Styles = mergeValues(base.Styles, child.Styles)
+	child.Layout = mergeValues(base.Layout, child.Layout)
+	child.States = mergeStates(base.States, child.States)
+	child.Events = mergeMap(base.Events, child.Events)
+	if child.Behavior == nil {
+		child.Behavior = base.Behavior
+	}
+	child.ControlStyles = mergeStates(base.ControlStyles, child.ControlStyles)
+	if child.IndicatorStyles == nil {
+		child.IndicatorStyles = base.IndicatorStyles
+	}
+	child.Props = mergeMap(base.Props, child.Props)
+	if child.Repeat == nil {
+		child.Repeat = base.Repeat
+	}
+}
+
+// mergeValues deep-merges child over base: nested objects merge key by key,
+// any other child value replaces the base's. Neither map is modified.
+func mergeValues(base, child map[string]interface{}) map[string]interface{} {
+	if len(base) == 0 {
+		return child
+	}
+	merged := make(map[string]interface{}, len(base)+len(child))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range child {
+		baseMap, baseIsMap := merged[key].(map[string]interface{})
+		childMap, childIsMap := value.(map[string]interface{})
+		if baseIsMap && childIsMap {
+			merged[key] = mergeValues(baseMap, childMap)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// mergeStates deep-merges the styles of each state
+func mergeStates(base, child map[string]map[string]interface{}) map[string]map[string]interface{} {
+	if len(base) == 0 {
+		return child
+	}
+	merged := make(map[string]map[string]interface{}, len(base)+len(child))
+	for state, styles := range base {
+		merged[state] = styles
+	}
+	for state, styles := range child {
+		merged[state] = mergeValues(merged[state], styles)
+	}
+	return merged
+}
+
+// mergeMap merges child entries over base ones, without merging the entries
+func mergeMap[V any](base, child map[string]V) map[string]V {
+	if len(base) == 0 {
+		return child
+	}
+	merged := make(map[string]V, len(base)+len(child))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range child {
+		merged[key] = value
+	}
+	return merged
+}
+
+// mergeRefs keeps the base's slots in order, replacing those the child
+// redefines, followed by the child's new slots
+func mergeRefs(base, child models.RefMap) models.RefMap {
+	if len(base) == 0 {
+		return child
+	}
+	merged := make(models.RefMap, 0, len(base)+len(child))
+	for _, ref := range base {
+		for _, own := range child {
+			if own.Key == ref.Key {
+				ref = own
+			}
+		}
+		merged = append(merged, ref)
+	}
+	for _, ref := range child {
+		if _, inherited := base.Get(ref.Key); !inherited {
+			merged = append(merged, ref)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeStructure writes source to a structure file and returns its path
+func writeStructure(t *testing.T, source string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "structure.json")
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// parseFile parses source as a structure file
+func parseFile(t *testing.T, source string) (*AtomicParser, error) {
+	t.Helper()
+	p := NewAtomicParser(writeStructure(t, source))
+	_, err := p.Parse()
+	return p, err
+}
+
+func TestResolveExtendsMerges(t *testing.T) {
+	structure := parseStructure(t, `{
+		"atoms": {"buttons": [
+			{"id": "base", "subatom": "Button", "config": {"content": "Go", "type": "button"},
+			 "styles": {"color": "red", "padding": "8px", "responsive": {"mobile": {"padding": "4px"}}},
+			 "states": {"hover": {"opacity": "0.8"}}},
+			{"id": "large", "extends": "base", "config": {"content": "Go now"},
+			 "styles": {"padding": "16px", "responsive": {"mobile": {"color": "blue"}}}},
+			{"id": "huge", "extends": "large", "states": {"hover": {"opacity": "0.5"}}}
+		]},
+		"molecules": [
+			{"id": "card", "type": "card", "atoms": {"title": "base", "action": "base"}},
+			{"id": "promo", "extends": "card", "atoms": {"action": "large", "extra": "huge"}}
+		]
+	}`)
+
+	if problems := resolveExtends(structure); len(problems) > 0 {
+		t.Fatalf("resolveExtends() = %v", problems)
+	}
+
+	huge := structure.Atoms.Buttons[2]
+	if huge.Subatom != "Button" {
+		t.Errorf("huge.Subatom = %q, want Button", huge.Subatom)
+	}
+	wantConfig := map[string]interface{}{"content": "Go now", "type": "button"}
+	if !reflect.DeepEqual(huge.Config, wantConfig) {
+		t.Errorf("huge.Config = %v, want %v", huge.Config, wantConfig)
+	}
+	wantStyles := map[string]interface{}{
+		"color":      "red",
+		"padding":    "16px",
+		"responsive": map[string]interface{}{"mobile": map[string]interface{}{"padding": "4px", "color": "blue"}},
+	}
+	if !reflect.DeepEqual(huge.Styles, wantStyles) {
+		t.Errorf("huge.Styles = %v, want %v", huge.Styles, wantStyles)
+	}
+	if got := huge.States["hover"]["opacity"]; got != "0.5" {
+		t.Errorf("huge hover opacity = %v, want 0.5", got)
+	}
+
+	promo := structure.Molecules[1]
+	var slots []string
+	for _, ref := range promo.Atoms {
+		slots = append(slots, ref.Key+"="+ref.ID)
+	}
+	wantSlots := []string{"title=base", "action=large", "extra=huge"}
+	if !reflect.DeepEqual(slots, wantSlots) {
+		t.Errorf("promo atoms = %v, want %v", slots, wantSlots)
+	}
+	if promo.Type != "card" {
+		t.Errorf("promo.Type = %q, want card", promo.Type)
+	}
+}
+
+func TestResolveExtendsReportsUnknownBasesAndCycles(t *testing.T) {
+	structure := parseStructure(t, `{
+		"atoms": {"text": [
+			{"id": "a", "extends": "b"},
+			{"id": "b", "extends": "a"},
+			{"id": "c", "extends": "missing"}
+		]}
+	}`)
+
+	want := ValidationErrors{
+		{Path: "atoms.text[1].extends", Message: "atom extends cycle: a → b → a"},
+		{Path: "atoms.text[2].extends", Message: `unknown base atom "missing"`},
+	}
+	if got := resolveExtends(structure); !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveExtends() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestParseReportsExtendsWithValidationErrors(t *testing.T) {
+	_, err := parseFile(t, `{
+		"project": {"id": "p", "name": "P"},
+		"atoms": {"buttons": [
+			{"id": "cta", "subatom": "Button"},
+			{"id": "big", "extends": "missing"}
+		]},
+		"molecules": [{"id": "card", "atoms": {"icon": "ghost"}}],
+		"layouts": [{"id": "main", "structure": []}],
+		"pages": [{"id": "home", "route": "/", "layout": "main"}]
+	}`)
+
+	var problems ValidationErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("Parse() = %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Path: "atoms.buttons[1].extends", Message: `unknown base atom "missing"`},
+		{Path: "molecules[0].atoms.icon", Message: `atom "ghost" not found`},
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("Parse() problems =\n%v\nwant\n%v", problems, want)
+	}
+}
@@ -0,0 +1,281 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"atomic-generator/pkg/models"
+)
+
+// Kind identifies what an ID in the registry refers to
+type Kind string
+
+const (
+	KindAtom     Kind = "atom"
+	KindMolecule Kind = "molecule"
+	KindOrganism Kind = "organism"
+	KindLayout   Kind = "layout"
+	KindPage     Kind = "page"
+	KindClump    Kind = "clump"
+)
+
+// Registry indexes every element of an atomic structure by ID. It is built
+// once after parsing and shared by the generator and all renderers, so every
+// lookup is O(1) instead of a scan over the structure.
+type Registry struct {
+	structure *models.AtomicStructure
+
+	atoms     map[string]*models.Atom
+	molecules map[string]*models.Molecule
+	organisms map[string]*models.Organism
+	layouts   map[string]*models.Layout
+	pages     map[string]*models.Page
+	clumps    map[string]*models.Clump
+
+	// allAtoms keeps atoms in category/declaration order
+	allAtoms []*models.Atom
+	// components maps atom, molecule and organism IDs to their kind. They
+	// share one namespace because they all become React components.
+	components map[string]Kind
+	// pageClumps maps page IDs to the clump they belong to
+	pageClumps map[string]*models.Clump
+	// breakpoints are the brand breakpoints, narrowest first
+	breakpoints Breakpoints
+}
+
+// NewRegistry indexes the structure and reports ID collisions, both inside a
+// kind (e.g. the same atom ID in images and links) and across component kinds
+func NewRegistry(structure *models.AtomicStructure) (*Registry, error) {
+	r := &Registry{
+		structure:  structure,
+		atoms:      make(map[string]*models.Atom),
+		molecules:  make(map[string]*models.Molecule),
+		organisms:  make(map[string]*models.Organism),
+		layouts:    make(map[string]*models.Layout),
+		pages:      make(map[string]*models.Page),
+		clumps:     make(map[string]*models.Clump),
+		components: make(map[string]Kind),
+		pageClumps: make(map[string]*models.Clump),
+	}
+
+	var collisions ValidationErrors
+
+	breakpoints, err := ParseBreakpoints(structure.Project.Brand.Breakpoints)
+	if err != nil {
+		collisions = append(collisions, ValidationError{
+			Path:    "project.brand.breakpoints",
+			Message: err.Error(),
+		})
+	}
+	r.breakpoints = breakpoints
+
+	// componentIDs maps the generated component names to the IDs taking
+	// them, so hero-banner and hero_banner don't both become HeroBanner
+	componentIDs := make(map[string]string)
+	addComponent := func(kind Kind, id, path string) bool {
+		if existing, exists := r.components[id]; exists {
+			collisions = append(collisions, ValidationError{
+				Path:    path + ".id",
+				Message: fmt.Sprintf("%s id %q collides with an existing %s", kind, id, existing),
+			})
+			return false
+		}
+		name := ComponentName(id)
+		if other, exists := componentIDs[name]; exists {
+			collisions = append(collisions, ValidationError{
+				Path:    path + ".id",
+				Message: fmt.Sprintf("%s id %q collides with the %s %q: both become the component %s", kind, id, r.components[other], other, name),
+			})
+			return false
+		}
+		r.components[id] = kind
+		componentIDs[name] = id
+		return true
+	}
+
+	categories := []struct {
+		name  string
+		atoms []models.Atom
+	}{
+		{"images", structure.Atoms.Images},
+		{"headings", structure.Atoms.Headings},
+		{"links", structure.Atoms.Links},
+		{"buttons", structure.Atoms.Buttons},
+		{"inputs", structure.Atoms.Inputs},
+		{"text", structure.Atoms.Text},
+	}
+	for _, category := range categories {
+		for i := range category.atoms {
+			atom := &category.atoms[i]
+			if addComponent(KindAtom, atom.ID, fmt.Sprintf("atoms.%s[%d]", category.name, i)) {
+				r.atoms[atom.ID] = atom
+				r.allAtoms = append(r.allAtoms, atom)
+			}
+		}
+	}
+
+	for i := range structure.Molecules {
+		molecule := &structure.Molecules[i]
+		if addComponent(KindMolecule, molecule.ID, fmt.Sprintf("molecules[%d]", i)) {
+			r.molecules[molecule.ID] = molecule
+		}
+	}
+
+	for i := range structure.Organisms {
+		organism := &structure.Organisms[i]
+		if addComponent(KindOrganism, organism.ID, fmt.Sprintf("organisms[%d]", i)) {
+			r.organisms[organism.ID] = organism
+		}
+	}
+
+	for i := range structure.Layouts {
+		layout := &structure.Layouts[i]
+		if _, exists := r.layouts[layout.ID]; exists {
+			collisions = append(collisions, ValidationError{
+				Path:    fmt.Sprintf("layouts[%d].id", i),
+				Message: fmt.Sprintf("duplicate layout id %q", layout.ID),
+			})
+			continue
+		}
+		r.layouts[layout.ID] = layout
+	}
+
+	pageIDs := make(map[string]string)
+	for i := range structure.Pages {
+		page := &structure.Pages[i]
+		if _, exists := r.pages[page.ID]; exists {
+			collisions = append(collisions, ValidationError{
+				Path:    fmt.Sprintf("pages[%d].id", i),
+				Message: fmt.Sprintf("duplicate page id %q", page.ID),
+			})
+			continue
+		}
+		name := ComponentName(page.ID)
+		if other, exists := pageIDs[name]; exists {
+			collisions = append(collisions, ValidationError{
+				Path:    fmt.Sprintf("pages[%d].id", i),
+				Message: fmt.Sprintf("page id %q collides with the page %q: both become the component %s", page.ID, other, name),
+			})
+			continue
+		}
+		r.pages[page.ID] = page
+		pageIDs[name] = page.ID
+	}
+
+	for i := range structure.Clumps {
+		clump := &structure.Clumps[i]
+		if _, exists := r.clumps[clump.ID]; exists {
+			collisions = append(collisions, ValidationError{
+				Path:    fmt.Sprintf("clumps[%d].id", i),
+				Message: fmt.Sprintf("duplicate clump id %q", clump.ID),
+			})
+			continue
+		}
+		r.clumps[clump.ID] = clump
+		for _, pageID := range clump.Pages {
+			if _, exists := r.pageClumps[pageID]; !exists {
+				r.pageClumps[pageID] = clump
+			}
+		}
+	}
+
+	// A page's own clump field wins over clump page lists
+	for _, page := range r.pages {
+		if clump, exists := r.clumps[page.Clump]; exists {
+			r.pageClumps[page.ID] = clump
+		}
+	}
+
+	if len(collisions) > 0 {
+		return nil, collisions
+	}
+	return r, nil
+}
+
+// ComponentName returns the PascalCase component name generated for an ID:
+// snake_case and kebab-case parts are capitalized and joined
+func ComponentName(id string) string {
+	var name string
+	for _, part := range strings.Split(strings.ReplaceAll(id, "_", "-"), "-") {
+		if len(part) > 0 {
+			name += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return name
+}
+
+// Structure returns the indexed atomic structure
+func (r *Registry) Structure() *models.AtomicStructure {
+	return r.structure
+}
+
+// Atom finds an atom by its ID across all atom categories
+func (r *Registry) Atom(id string) *models.Atom {
+	return r.atoms[id]
+}
+
+// Molecule finds a molecule by its ID
+func (r *Registry) Molecule(id string) *models.Molecule {
+	return r.molecules[id]
+}
+
+// Organism finds an organism by its ID
+func (r *Registry) Organism(id string) *models.Organism {
+	return r.organisms[id]
+}
+
+// Layout finds a layout by its ID
+func (r *Registry) Layout(id string) *models.Layout {
+	return r.layouts[id]
+}
+
+// Page finds a page by its ID
+func (r *Registry) Page(id string) *models.Page {
+	return r.pages[id]
+}
+
+// Clump finds a clump by its ID
+func (r *Registry) Clump(id string) *models.Clump {
+	return r.clumps[id]
+}
+
+// ClumpForPage returns the clump a page belongs to, if any
+func (r *Registry) ClumpForPage(pageID string) *models.Clump {
+	return r.pageClumps[pageID]
+}
+
+// AllAtoms returns every atom in category and declaration order
+func (r *Registry) AllAtoms() []*models.Atom {
+	return r.allAtoms
+}
+
+// Breakpoints returns the brand breakpoints, narrowest first
+func (r *Registry) Breakpoints() Breakpoints {
+	return r.breakpoints
+}
+
+// KindOf reports whether an ID is an atom, molecule or organism
+func (r *Registry) KindOf(id string) (Kind, bool) {
+	kind, exists := r.components[id]
+	return kind, exists
+}
+
+// Has reports whether an element of the given kind is registered under id
+func (r *Registry) Has(kind Kind, id string) bool {
+	switch kind {
+	case KindAtom:
+		return r.atoms[id] != nil
+	case KindMolecule:
+		return r.molecules[id] != nil
+	case KindOrganism:
+		return r.organisms[id] != nil
+	case KindLayout:
+		return r.layouts[id] != nil
+	case KindPage:
+		return r.pages[id] != nil
+	case KindClump:
+		return r.clumps[id] != nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegistryLookups(t *testing.T) {
+	registry, err := NewRegistry(parseStructure(t, `{
+		"atoms": {
+			"images": [{"id": "logo", "subatom": "Image"}],
+			"text": [{"id": "body", "subatom": "Text"}]
+		},
+		"molecules": [{"id": "card", "atoms": {"body": "body"}}],
+		"organisms": [{"id": "hero", "molecules": {"main": "card"}}],
+		"layouts": [{"id": "main", "structure": []}],
+		"pages": [{"id": "home", "route": "/", "layout": "main"}]
+	}`))
+	if err != nil {
+		t.Fatalf("NewRegistry() = %v", err)
+	}
+
+	if atom := registry.Atom("body"); atom == nil || atom.Subatom != "Text" {
+		t.Errorf("Atom(body) = %v, want the text atom", atom)
+	}
+	if kind, exists := registry.KindOf("card"); !exists || kind != KindMolecule {
+		t.Errorf("KindOf(card) = %q, %v, want molecule", kind, exists)
+	}
+	if registry.Has(KindAtom, "card") || !registry.Has(KindOrganism, "hero") {
+		t.Error("Has() looks up the wrong kind")
+	}
+	if registry.Layout("main") == nil || registry.Page("home") == nil {
+		t.Error("layout or page not indexed")
+	}
+
+	var ids []string
+	for _, atom := range registry.AllAtoms() {
+		ids = append(ids, atom.ID)
+	}
+	if want := []string{"logo", "body"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("AllAtoms() = %v, want %v", ids, want)
+	}
+}
+
+func TestRegistryReportsCollisions(t *testing.T) {
+	_, err := NewRegistry(parseStructure(t, `{
+		"atoms": {
+			"images": [{"id": "logo", "subatom": "Image"}],
+			"links": [{"id": "logo", "subatom": "Link"}],
+			"text": [{"id": "hero-title", "subatom": "Text"}]
+		},
+		"molecules": [
+			{"id": "hero_title", "atoms": {}},
+			{"id": "logo", "atoms": {}}
+		],
+		"organisms": [{"id": "card"}, {"id": "card"}],
+		"layouts": [{"id": "main"}, {"id": "main"}],
+		"pages": [
+			{"id": "about-us", "route": "/about", "layout": "main"},
+			{"id": "about_us", "route": "/about-us", "layout": "main"}
+		]
+	}`))
+
+	var problems ValidationErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("NewRegistry() = %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Path: "atoms.links[0].id", Message: `atom id "logo" collides with an existing atom`},
+		{Path: "molecules[0].id", Message: `molecule id "hero_title" collides with the atom "hero-title": both become the component HeroTitle`},
+		{Path: "molecules[1].id", Message: `molecule id "logo" collides with an existing atom`},
+		{Path: "organisms[1].id", Message: `organism id "card" collides with an existing organism`},
+		{Path: "layouts[1].id", Message: `duplicate layout id "main"`},
+		{Path: "pages[1].id", Message: `page id "about_us" collides with the page "about-us": both become the component AboutUs`},
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("NewRegistry() problems =\n%v\nwant\n%v", problems, want)
+	}
+}
+
+func TestComponentName(t *testing.T) {
+	tests := map[string]string{
+		"hero":         "Hero",
+		"hero-banner":  "HeroBanner",
+		"hero_banner":  "HeroBanner",
+		"value--card_": "ValueCard",
+	}
+	for id, want := range tests {
+		if got := ComponentName(id); got != want {
+			t.Errorf("ComponentName(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
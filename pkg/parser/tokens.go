@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"atomic-generator/pkg/models"
+)
+
+// TokenName returns the CSS variable name of a brand token key: camelCase
+// and snake_case keys become kebab-case, e.g. textLight and text_light both
+// become text-light. Acronyms stay together (primaryRGB → primary-rgb).
+func TokenName(key string) string {
+	runes := []rune(key)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == ' ':
+			b.WriteRune('-')
+		case unicode.IsUpper(r):
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' &&
+				(!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteRune('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// TokenVariable returns the CSS variable of a brand token, e.g.
+// --color-text-light for the color textLight
+func TokenVariable(category, key string) string {
+	return "--" + category + "-" + TokenName(key)
+}
+
+// TokenVariables returns every CSS variable the brand defines
+func TokenVariables(brand models.Brand) map[string]bool {
+	vars := make(map[string]bool)
+	for key := range brand.Colors {
+		vars[TokenVariable("color", key)] = true
+	}
+	for key := range brand.Typography.FontFamily {
+		vars[TokenVariable("font-family", key)] = true
+	}
+	for key := range brand.Typography.FontSizes {
+		vars[TokenVariable("font-size", key)] = true
+	}
+	for key := range brand.Typography.FontWeights {
+		vars[TokenVariable("font-weight", key)] = true
+	}
+	for key := range brand.Spacing {
+		vars[TokenVariable("spacing", key)] = true
+	}
+	for key := range brand.Breakpoints {
+		vars[TokenVariable("breakpoint", key)] = true
+	}
+	return vars
+}
+
+// tokenReference matches the variable of a var() reference
+var tokenReference = regexp.MustCompile(`var\(\s*(--[\w-]+)`)
+
+// CheckTokens returns as errors the brand tokens whose keys become the same
+// CSS variable (textLight and text_light), which would overwrite each
+// other. As warnings, it reports the var() references of atom, molecule and
+// organism styles (including states, responsive overrides and organism
+// layout zones) to CSS variables neither the brand nor the styles
+// themselves define, suggesting the closest defined variable.
+func CheckTokens(s *models.AtomicStructure) (errors, warnings ValidationErrors) {
+	brand := s.Project.Brand
+	errors = append(errors, tokenCollisions("project.brand.colors", "color", brand.Colors)...)
+	errors = append(errors, tokenCollisions("project.brand.typography.fontFamily", "font-family", brand.Typography.FontFamily)...)
+	errors = append(errors, tokenCollisions("project.brand.typography.fontSizes", "font-size", brand.Typography.FontSizes)...)
+	errors = append(errors, tokenCollisions("project.brand.typography.fontWeights", "font-weight", brand.Typography.FontWeights)...)
+	errors = append(errors, tokenCollisions("project.brand.spacing", "spacing", brand.Spacing)...)
+	errors = append(errors, tokenCollisions("project.brand.breakpoints", "breakpoint", brand.Breakpoints)...)
+
+	checker := &tokenChecker{defined: TokenVariables(brand)}
+
+	type styled struct {
+		path   string
+		values map[string]interface{}
+	}
+	var blocks []styled
+	add := func(path, field string, values map[string]interface{}) {
+		if len(values) > 0 {
+			blocks = append(blocks, styled{path + "." + field, values})
+		}
+	}
+	addStates := func(path, field string, states map[string]map[string]interface{}) {
+		for _, state := range sortedKeys(states) {
+			add(path, field+"."+state, states[state])
+		}
+	}
+
+	categories := []struct {
+		name  string
+		atoms []models.Atom
+	}{
+		{"images", s.Atoms.Images},
+		{"headings", s.Atoms.Headings},
+		{"links", s.Atoms.Links},
+		{"buttons", s.Atoms.Buttons},
+		{"inputs", s.Atoms.Inputs},
+		{"text", s.Atoms.Text},
+	}
+	for _, category := range categories {
+		for i, atom := range category.atoms {
+			path := fmt.Sprintf("atoms.%s[%d]", category.name, i)
+			add(path, "styles", atom.Styles)
+			addStates(path, "states", atom.States)
+		}
+	}
+	for i, molecule := range s.Molecules {
+		path := fmt.Sprintf("molecules[%d]", i)
+		add(path, "styles", molecule.Styles)
+		addStates(path, "states", molecule.States)
+		for _, name := range sortedKeys(molecule.Props) {
+			addStates(path, "props."+name+".variants", molecule.Props[name].Variants)
+		}
+	}
+	for i, organism := range s.Organisms {
+		path := fmt.Sprintf("organisms[%d]", i)
+		add(path, "styles", organism.Styles)
+		add(path, "layout", organism.Layout)
+		addStates(path, "states", organism.States)
+		addStates(path, "controlStyles", organism.ControlStyles)
+		for _, name := range sortedKeys(organism.Props) {
+			addStates(path, "props."+name+".variants", organism.Props[name].Variants)
+		}
+		if indicators := organism.IndicatorStyles; indicators != nil {
+			add(path, "indicatorStyles.container", indicators.Container)
+			add(path, "indicatorStyles.dot", indicators.Dot)
+			add(path, "indicatorStyles.dotActive", indicators.DotActive)
+		}
+	}
+
+	// Styles can define custom properties of their own
+	for _, block := range blocks {
+		checker.declare(block.values)
+	}
+	for _, block := range blocks {
+		checker.check(block.path, block.values)
+	}
+	return errors, checker.problems
+}
+
+// tokenCollisions reports the keys of one brand category that become the
+// same CSS variable as an earlier key
+func tokenCollisions[V any](path, category string, tokens map[string]V) ValidationErrors {
+	var problems ValidationErrors
+	keys := make(map[string]string)
+	for _, key := range sortedKeys(tokens) {
+		variable := TokenVariable(category, key)
+		if other, exists := keys[variable]; exists {
+			problems = append(problems, ValidationError{
+				Path:    path + "." + key,
+				Message: fmt.Sprintf("token %q collides with %q: both become %s", key, other, variable),
+			})
+			continue
+		}
+		keys[variable] = key
+	}
+	return problems
+}
+
+type tokenChecker struct {
+	defined  map[string]bool
+	problems ValidationErrors
+}
+
+// declare adds the custom properties set in styles to the defined ones
+func (c *tokenChecker) declare(styles map[string]interface{}) {
+	for key, value := range styles {
+		if strings.HasPrefix(key, "--") {
+			c.defined[key] = true
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			c.declare(nested)
+		}
+	}
+}
+
+func (c *tokenChecker) check(path string, styles map[string]interface{}) {
+	for _, key := range sortedKeys(styles) {
+		switch value := styles[key].(type) {
+		case map[string]interface{}:
+			c.check(path+"."+key, value)
+		case string:
+			for _, match := range tokenReference.FindAllStringSubmatch(value, -1) {
+				name := match[1]
+				if c.defined[name] {
+					continue
+				}
+				message := fmt.Sprintf("unknown design token %s", name)
+				if suggestion := c.suggest(name); suggestion != "" {
+					message += fmt.Sprintf(" (did you mean %s?)", suggestion)
+				}
+				c.problems = append(c.problems, ValidationError{Path: path + "." + key, Message: message})
+			}
+		}
+	}
+}
+
+// suggest returns the defined variable closest to an unknown one: the same
+// name in kebab-case, or the nearest name within a few edits
+func (c *tokenChecker) suggest(name string) string {
+	if normalized := "--" + TokenName(strings.TrimPrefix(name, "--")); c.defined[normalized] {
+		return normalized
+	}
+
+	best, bestDistance := "", len(name)/3+1
+	for _, candidate := range sortedKeys(c.defined) {
+		if distance := editDistance(name, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
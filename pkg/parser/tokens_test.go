@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenName(t *testing.T) {
+	tests := map[string]string{
+		"primary":    "primary",
+		"textLight":  "text-light",
+		"text_light": "text-light",
+		"text-light": "text-light",
+		"primaryRGB": "primary-rgb",
+		"h1":         "h1",
+		"XMLHeading": "xml-heading",
+		"font size":  "font-size",
+	}
+	for key, want := range tests {
+		if got := TokenName(key); got != want {
+			t.Errorf("TokenName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestCheckTokens(t *testing.T) {
+	structure := parseStructure(t, `{
+		"project": {"brand": {
+			"colors": {"primary": "#f00", "textLight": "#fff", "text_light": "#eee"},
+			"spacing": {"sm": "4px"}
+		}},
+		"atoms": {"text": [{"id": "body", "styles": {
+			"color": "var(--color-textLight)",
+			"padding": "var(--spacing-sm)",
+			"--accent": "var(--color-primry)",
+			"background": "var(--accent)",
+			"states": {"hover": {"margin": "var(--spacing-xl)"}}
+		}}]}
+	}`)
+
+	errs, warnings := CheckTokens(structure)
+	wantErrors := ValidationErrors{{
+		Path:    "project.brand.colors.text_light",
+		Message: `token "text_light" collides with "textLight": both become --color-text-light`,
+	}}
+	if !reflect.DeepEqual(errs, wantErrors) {
+		t.Errorf("errors =\n%v\nwant\n%v", errs, wantErrors)
+	}
+
+	wantWarnings := ValidationErrors{
+		{Path: "atoms.text[0].styles.--accent", Message: "unknown design token --color-primry (did you mean --color-primary?)"},
+		{Path: "atoms.text[0].styles.color", Message: "unknown design token --color-textLight (did you mean --color-text-light?)"},
+		{Path: "atoms.text[0].styles.states.hover.margin", Message: "unknown design token --spacing-xl (did you mean --spacing-sm?)"},
+	}
+	if !reflect.DeepEqual(warnings, wantWarnings) {
+		t.Errorf("warnings =\n%v\nwant\n%v", warnings, wantWarnings)
+	}
+}
+
+const strictStructure = `{
+	"project": {"id": "p", "name": "P", "brand": {"colors": {"primary": "#f00"}}},
+	"atoms": {"text": [{"id": "body", "subatom": "Text", "styles": {"color": "var(--color-primry)"}}]},
+	"layouts": [{"id": "main", "structure": []}],
+	"pages": [{"id": "home", "route": "/", "layout": "main"}]
+}`
+
+func TestParseTokenWarnings(t *testing.T) {
+	p, err := parseFile(t, strictStructure)
+	if err != nil {
+		t.Fatalf("Parse() = %v, want warnings only", err)
+	}
+	if len(p.Warnings()) != 1 {
+		t.Errorf("Warnings() = %v, want one unknown token", p.Warnings())
+	}
+}
+
+func TestParseStrictTokens(t *testing.T) {
+	path := writeStructure(t, strictStructure)
+	p := NewAtomicParser(path)
+	p.Strict = true
+	_, err := p.Parse()
+
+	var problems ValidationErrors
+	if !errors.As(err, &problems) || !strings.Contains(err.Error(), "design token error") {
+		t.Fatalf("Parse() = %v, want a design token error", err)
+	}
+}
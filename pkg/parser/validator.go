@@ -0,0 +1,724 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"atomic-generator/pkg/models"
+)
+
+// knownSubatoms lists the subatom types the renderers know how to emit
+var knownSubatoms = map[string]bool{
+	"Image":   true,
+	"Heading": true,
+	"Link":    true,
+	"Button":  true,
+	"Input":   true,
+	"Text":    true,
+}
+
+// TextTags are the elements a Text atom can render as, through its tag
+// config
+var TextTags = map[string]bool{
+	"abbr":       true,
+	"address":    true,
+	"b":          true,
+	"blockquote": true,
+	"caption":    true,
+	"cite":       true,
+	"code":       true,
+	"dd":         true,
+	"div":        true,
+	"dt":         true,
+	"em":         true,
+	"figcaption": true,
+	"i":          true,
+	"label":      true,
+	"legend":     true,
+	"li":         true,
+	"mark":       true,
+	"p":          true,
+	"pre":        true,
+	"q":          true,
+	"small":      true,
+	"span":       true,
+	"strong":     true,
+	"sub":        true,
+	"sup":        true,
+	"time":       true,
+}
+
+// contentSubatoms lists, for each content prop type, the subatoms having
+// that content
+var contentSubatoms = map[string]map[string]bool{
+	models.PropText: {"Heading": true, "Link": true, "Button": true, "Text": true},
+	models.PropHref: {"Link": true},
+	models.PropSrc:  {"Image": true},
+}
+
+// identifierPattern matches the names usable as JavaScript properties
+// without quotes
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// ValidationError is a single problem found in an atomic structure,
+// located by its JSON path (e.g. molecules[3].atoms.icon)
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors holds every problem found in a single validation pass
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	lines := []string{fmt.Sprintf("%d problem(s) found:", len(e))}
+	for _, ve := range e {
+		lines = append(lines, "  - "+ve.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validator checks the referential integrity of an atomic structure and
+// collects every problem instead of stopping at the first one
+type Validator struct {
+	structure *models.AtomicStructure
+	errors    ValidationErrors
+
+	// Declared IDs mapped to the path where they were first declared
+	atoms     map[string]string
+	molecules map[string]string
+	organisms map[string]string
+	layouts   map[string]string
+	pages     map[string]string
+	clumps    map[string]string
+
+	// Declared atoms, molecules and organisms by ID, to check props
+	atomDefs     map[string]*models.Atom
+	moleculeDefs map[string]*models.Molecule
+	organismDefs map[string]*models.Organism
+
+	breakpoints Breakpoints
+	// breakpointsValid is false when the brand breakpoints failed to parse,
+	// so references to them aren't reported a second time
+	breakpointsValid bool
+}
+
+func NewValidator(structure *models.AtomicStructure) *Validator {
+	return &Validator{
+		structure: structure,
+		atoms:     make(map[string]string),
+		molecules: make(map[string]string),
+		organisms: make(map[string]string),
+		layouts:   make(map[string]string),
+		pages:     make(map[string]string),
+		clumps:    make(map[string]string),
+
+		atomDefs:     make(map[string]*models.Atom),
+		moleculeDefs: make(map[string]*models.Molecule),
+		organismDefs: make(map[string]*models.Organism),
+	}
+}
+
+// Validate runs every check and returns ValidationErrors if anything failed
+func (v *Validator) Validate() error {
+	v.validateProject()
+
+	// First pass: collect declarations so references can be resolved
+	v.declareAtoms()
+	v.declareMolecules()
+	v.declareOrganisms()
+	v.declareLayouts()
+	v.declarePages()
+	v.declareClumps()
+
+	// Second pass: check references
+	v.validateMolecules()
+	v.validateMoleculeCycles()
+	v.validateOrganisms()
+	v.validateLayouts()
+	v.validatePages()
+	v.validateClumps()
+
+	if len(v.errors) > 0 {
+		return v.errors
+	}
+	return nil
+}
+
+func (v *Validator) addError(path, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// declare registers an ID in the given set, reporting missing and duplicate IDs
+func (v *Validator) declare(set map[string]string, kind, id, path string) {
+	if id == "" {
+		v.addError(path+".id", "%s id is required", kind)
+		return
+	}
+	if first, exists := set[id]; exists {
+		v.addError(path+".id", "duplicate %s id %q (first declared at %s)", kind, id, first)
+		return
+	}
+	set[id] = path
+}
+
+// requireRef reports a reference to an ID that was never declared
+func (v *Validator) requireRef(set map[string]string, kind, id, path string) {
+	if id == "" {
+		v.addError(path, "empty %s reference", kind)
+		return
+	}
+	if _, exists := set[id]; !exists {
+		v.addError(path, "%s %q not found", kind, id)
+	}
+}
+
+// requireRefMap checks every entry of a slot name → ID map, in declaration order
+func (v *Validator) requireRefMap(set map[string]string, kind string, refs models.RefMap, path string) {
+	for _, ref := range refs {
+		v.requireRef(set, kind, ref.ID, fmt.Sprintf("%s.%s", path, ref.Key))
+	}
+}
+
+// requireBreakpoint reports a reference to a breakpoint the brand doesn't
+// declare
+func (v *Validator) requireBreakpoint(name, path string) bool {
+	if v.breakpoints.Has(name) {
+		return true
+	}
+	if !v.breakpointsValid {
+		// Already reported as an invalid brand breakpoint
+		return false
+	}
+	if len(v.breakpoints) == 0 {
+		v.addError(path, "unknown breakpoint %q (project.brand.breakpoints is empty)", name)
+	} else {
+		v.addError(path, "unknown breakpoint %q (brand declares: %s)", name, strings.Join(v.breakpoints.Names(), ", "))
+	}
+	return false
+}
+
+// sortedKeys returns map keys in lexical order so errors are reported
+// deterministically
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *Validator) validateProject() {
+	if v.structure.Project.ID == "" {
+		v.addError("project.id", "project id is required")
+	}
+	if v.structure.Project.Name == "" {
+		v.addError("project.name", "project name is required")
+	}
+
+	breakpoints, err := ParseBreakpoints(v.structure.Project.Brand.Breakpoints)
+	if err != nil {
+		v.addError("project.brand.breakpoints", "%v", err)
+	}
+	v.breakpoints = breakpoints
+	v.breakpointsValid = err == nil
+}
+
+// validateResponsiveStyles checks the per-breakpoint overrides declared
+// under styles.responsive
+func (v *Validator) validateResponsiveStyles(styles map[string]interface{}, path string) {
+	raw, exists := styles["responsive"]
+	if !exists {
+		return
+	}
+	path += ".styles.responsive"
+
+	overrides, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addError(path, "must be an object of breakpoint name to styles")
+		return
+	}
+	for _, name := range sortedKeys(overrides) {
+		if !v.requireBreakpoint(name, path+"."+name) {
+			continue
+		}
+		if _, ok := overrides[name].(map[string]interface{}); !ok {
+			v.addError(path+"."+name, "breakpoint styles must be an object")
+		}
+	}
+}
+
+func (v *Validator) declareAtoms() {
+	categories := []struct {
+		name  string
+		atoms []models.Atom
+	}{
+		{"images", v.structure.Atoms.Images},
+		{"headings", v.structure.Atoms.Headings},
+		{"links", v.structure.Atoms.Links},
+		{"buttons", v.structure.Atoms.Buttons},
+		{"inputs", v.structure.Atoms.Inputs},
+		{"text", v.structure.Atoms.Text},
+	}
+
+	for _, category := range categories {
+		for i := range category.atoms {
+			atom := &category.atoms[i]
+			path := fmt.Sprintf("atoms.%s[%d]", category.name, i)
+			v.declare(v.atoms, "atom", atom.ID, path)
+			if v.atomDefs[atom.ID] == nil {
+				v.atomDefs[atom.ID] = atom
+			}
+
+			switch {
+			case atom.Subatom != "":
+				if !knownSubatoms[atom.Subatom] {
+					v.addError(path+".subatom", "unknown subatom %q", atom.Subatom)
+				}
+			case atom.Extends == "":
+				// An atom extending a base it can't find is reported by
+				// resolveExtends instead
+				v.addError(path+".subatom", "subatom is required")
+			}
+			v.validateAtomConfig(atom, path)
+			v.validateResponsiveStyles(atom.Styles, path)
+		}
+	}
+}
+
+// validateAtomConfig checks the config values naming elements: the level
+// of headings and the tag of Text atoms
+func (v *Validator) validateAtomConfig(atom *models.Atom, path string) {
+	switch atom.Subatom {
+	case "Heading":
+		if level, exists := atom.Config["level"]; exists {
+			if n, ok := level.(float64); !ok || n != math.Trunc(n) || n < 1 || n > 6 {
+				v.addError(path+".config.level", "heading level must be a whole number from 1 to 6, got %v", level)
+			}
+		}
+	case "Text":
+		if tag, exists := atom.Config["tag"]; exists {
+			if name, ok := tag.(string); !ok || !TextTags[name] {
+				v.addError(path+".config.tag", "unsupported text tag %v (use one of %s)", tag, strings.Join(sortedKeys(TextTags), ", "))
+			}
+		}
+	}
+}
+
+func (v *Validator) declareMolecules() {
+	for i := range v.structure.Molecules {
+		molecule := &v.structure.Molecules[i]
+		v.declare(v.molecules, "molecule", molecule.ID, fmt.Sprintf("molecules[%d]", i))
+		if v.moleculeDefs[molecule.ID] == nil {
+			v.moleculeDefs[molecule.ID] = molecule
+		}
+	}
+}
+
+func (v *Validator) declareOrganisms() {
+	for i := range v.structure.Organisms {
+		organism := &v.structure.Organisms[i]
+		v.declare(v.organisms, "organism", organism.ID, fmt.Sprintf("organisms[%d]", i))
+		if v.organismDefs[organism.ID] == nil {
+			v.organismDefs[organism.ID] = organism
+		}
+	}
+}
+
+// layoutEntries returns every layout with its JSON path, including the
+// singular "layout" key
+func (v *Validator) layoutEntries() ([]*models.Layout, []string) {
+	var layouts []*models.Layout
+	var paths []string
+
+	s := v.structure
+	if s.Layout.ID != "" || len(s.Layout.Structure) > 0 {
+		layouts = append(layouts, &s.Layout)
+		paths = append(paths, "layout")
+	}
+	for i := range s.Layouts {
+		layouts = append(layouts, &s.Layouts[i])
+		paths = append(paths, fmt.Sprintf("layouts[%d]", i))
+	}
+	return layouts, paths
+}
+
+// pageEntries returns every page with its JSON path, including the
+// singular "page" key
+func (v *Validator) pageEntries() ([]*models.Page, []string) {
+	var pages []*models.Page
+	var paths []string
+
+	s := v.structure
+	if s.Page.ID != "" || s.Page.Route != "" || s.Page.Layout != "" {
+		pages = append(pages, &s.Page)
+		paths = append(paths, "page")
+	}
+	for i := range s.Pages {
+		pages = append(pages, &s.Pages[i])
+		paths = append(paths, fmt.Sprintf("pages[%d]", i))
+	}
+	return pages, paths
+}
+
+// clumpEntries returns every clump with its JSON path, including the
+// singular "clump" key
+func (v *Validator) clumpEntries() ([]*models.Clump, []string) {
+	var clumps []*models.Clump
+	var paths []string
+
+	s := v.structure
+	if s.Clump.ID != "" || len(s.Clump.Pages) > 0 {
+		clumps = append(clumps, &s.Clump)
+		paths = append(paths, "clump")
+	}
+	for i := range s.Clumps {
+		clumps = append(clumps, &s.Clumps[i])
+		paths = append(paths, fmt.Sprintf("clumps[%d]", i))
+	}
+	return clumps, paths
+}
+
+func (v *Validator) declareLayouts() {
+	layouts, paths := v.layoutEntries()
+	if len(layouts) == 0 {
+		v.addError("layouts", "at least one layout is required (layout or layouts)")
+	}
+	for i, layout := range layouts {
+		v.declare(v.layouts, "layout", layout.ID, paths[i])
+	}
+}
+
+func (v *Validator) declarePages() {
+	pages, paths := v.pageEntries()
+	if len(pages) == 0 {
+		v.addError("pages", "at least one page is required (page or pages)")
+	}
+	for i, page := range pages {
+		v.declare(v.pages, "page", page.ID, paths[i])
+	}
+}
+
+func (v *Validator) declareClumps() {
+	clumps, paths := v.clumpEntries()
+	for i, clump := range clumps {
+		v.declare(v.clumps, "clump", clump.ID, paths[i])
+	}
+}
+
+func (v *Validator) validateMolecules() {
+	for i, molecule := range v.structure.Molecules {
+		path := fmt.Sprintf("molecules[%d]", i)
+		v.requireRefMap(v.atoms, "atom", molecule.Atoms, path+".atoms")
+		v.validateResponsiveStyles(molecule.Styles, path)
+
+		declared := make(map[string]int)
+		for j, responsive := range molecule.Responsive {
+			responsivePath := fmt.Sprintf("%s.responsive[%d]", path, j)
+			v.requireRefMap(v.atoms, "atom", responsive.Atoms, responsivePath+".atoms")
+
+			if first, exists := declared[responsive.Breakpoint]; exists {
+				v.addError(responsivePath+".breakpoint", "breakpoint %q already declared at %s.responsive[%d]", responsive.Breakpoint, path, first)
+				continue
+			}
+			declared[responsive.Breakpoint] = j
+			v.requireBreakpoint(responsive.Breakpoint, responsivePath+".breakpoint")
+		}
+
+		// Content props set the atoms of a slot, whichever breakpoint shows
+		slots := make(map[string][]string)
+		for _, ref := range molecule.Atoms {
+			slots[ref.Key] = append(slots[ref.Key], ref.ID)
+		}
+		for _, responsive := range molecule.Responsive {
+			for _, ref := range responsive.Atoms {
+				slots[ref.Key] = append(slots[ref.Key], ref.ID)
+			}
+		}
+
+		// Nested molecules take their own slots, and the prop values passed
+		// to them
+		v.requireRefMap(v.molecules, "molecule", molecule.Molecules, path+".molecules")
+		moleculeSlots := make(map[string]string)
+		for _, ref := range molecule.Molecules {
+			refPath := fmt.Sprintf("%s.molecules.%s", path, ref.Key)
+			if _, taken := slots[ref.Key]; taken {
+				v.addError(refPath, "slot %q is already used by an atom", ref.Key)
+			}
+			moleculeSlots[ref.Key] = ref.ID
+			if child := v.moleculeDefs[ref.ID]; child != nil {
+				v.validatePropValues(child.Props, ref.Props, "molecule", ref.ID, refPath+".props")
+			}
+		}
+		v.validateProps(molecule.Props, path, slots, moleculeSlots)
+	}
+}
+
+// validateMoleculeCycles reports molecules nesting themselves, directly or
+// through other molecules, with the path of the cycle
+func (v *Validator) validateMoleculeCycles() {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, ref := range v.moleculeDefs[id].Molecules {
+			if v.moleculeDefs[ref.ID] == nil {
+				continue
+			}
+			switch state[ref.ID] {
+			case unvisited:
+				visit(ref.ID)
+			case visiting:
+				start := slices.Index(stack, ref.ID)
+				cycle := append(slices.Clone(stack[start:]), ref.ID)
+				v.addError(fmt.Sprintf("%s.molecules.%s", v.molecules[id], ref.Key), "molecule cycle: %s", strings.Join(cycle, " → "))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+
+	for _, molecule := range v.structure.Molecules {
+		if state[molecule.ID] == unvisited && v.moleculeDefs[molecule.ID] != nil {
+			visit(molecule.ID)
+		}
+	}
+}
+
+func (v *Validator) validateOrganisms() {
+	for i, organism := range v.structure.Organisms {
+		path := fmt.Sprintf("organisms[%d]", i)
+		v.requireRefMap(v.atoms, "atom", organism.Atoms, path+".atoms")
+		v.validateResponsiveStyles(organism.Styles, path)
+
+		// Molecules can be a map or an array
+		if organism.Molecules.List {
+			for j, molID := range organism.Molecules.Refs.IDs() {
+				v.requireRef(v.molecules, "molecule", molID, fmt.Sprintf("%s.molecules[%d]", path, j))
+			}
+		} else {
+			v.requireRefMap(v.molecules, "molecule", organism.Molecules.Refs, path+".molecules")
+		}
+		for j, ref := range organism.Molecules.Refs {
+			refPath := fmt.Sprintf("%s.molecules.%s", path, ref.Key)
+			if organism.Molecules.List {
+				refPath = fmt.Sprintf("%s.molecules[%d]", path, j)
+			}
+			if molecule := v.moleculeDefs[ref.ID]; molecule != nil {
+				v.validatePropValues(molecule.Props, ref.Props, "molecule", ref.ID, refPath+".props")
+			}
+		}
+
+		atomSlots := make(map[string][]string)
+		for _, ref := range organism.Atoms {
+			atomSlots[ref.Key] = []string{ref.ID}
+		}
+		moleculeSlots := make(map[string]string)
+		if !organism.Molecules.List {
+			for _, ref := range organism.Molecules.Refs {
+				moleculeSlots[ref.Key] = ref.ID
+			}
+		}
+		v.validateProps(organism.Props, path, atomSlots, moleculeSlots)
+
+		for j, section := range organism.Sections {
+			for k, molID := range section.Molecules {
+				v.requireRef(v.molecules, "molecule", molID, fmt.Sprintf("%s.sections[%d].molecules[%d]", path, j, k))
+			}
+		}
+
+		if organism.Repeat != nil {
+			v.validateRepeat(organism.Repeat, path+".repeat")
+		}
+	}
+}
+
+// validateRepeat checks the molecule a repeat renders and the prop values
+// and keys of its items
+func (v *Validator) validateRepeat(repeat *models.Repeat, path string) {
+	v.requireRef(v.molecules, "molecule", repeat.Molecule, path+".molecule")
+	if len(repeat.Items.Values) == 0 {
+		v.addError(path+".items", "a repeat needs items")
+	}
+	if repeat.Key != "" && !identifierPattern.MatchString(repeat.Key) {
+		v.addError(path+".key", "key %q must be a JavaScript identifier", repeat.Key)
+	}
+
+	molecule := v.moleculeDefs[repeat.Molecule]
+	keys := make(map[string]int)
+	for i, item := range repeat.Items.Values {
+		itemPath := fmt.Sprintf("%s.items[%d]", path, i)
+		values := item
+		if repeat.Key != "" {
+			key, exists := item[repeat.Key]
+			if !exists {
+				v.addError(itemPath, "missing key %q", repeat.Key)
+			} else if first, duplicate := keys[key]; duplicate {
+				v.addError(itemPath+"."+repeat.Key, "duplicate key %q (first used by items[%d])", key, first)
+			} else {
+				keys[key] = i
+			}
+
+			// The key field identifies the item; it is only a prop value
+			// when the molecule has a prop of that name
+			if molecule != nil {
+				if _, isProp := molecule.Props[repeat.Key]; !isProp {
+					values = make(map[string]string, len(item))
+					for name, value := range item {
+						if name != repeat.Key {
+							values[name] = value
+						}
+					}
+				}
+			}
+		}
+		if molecule != nil {
+			v.validatePropValues(molecule.Props, values, "molecule", molecule.ID, itemPath)
+		}
+	}
+}
+
+// validateProps checks the props a molecule or organism declares against
+// its slots: atomSlots maps slot names to the atoms they show,
+// moleculeSlots to the molecule they hold
+func (v *Validator) validateProps(props map[string]models.Prop, path string, atomSlots map[string][]string, moleculeSlots map[string]string) {
+	for _, name := range sortedPropNames(props) {
+		prop := props[name]
+		propPath := fmt.Sprintf("%s.props.%s", path, name)
+
+		switch {
+		case prop.Type == models.PropVariant && prop.Slot == "":
+			// A variant of the component itself
+			if len(prop.Variants) == 0 {
+				v.addError(propPath+".variants", "a variant prop needs variants")
+			}
+			if _, exists := prop.Variants[prop.Default]; prop.Default != "" && !exists {
+				v.addError(propPath+".default", "unknown variant %q", prop.Default)
+			}
+			continue
+		case prop.Type != models.PropVariant && contentSubatoms[prop.Type] == nil:
+			v.addError(propPath+".type", "unknown prop type %q (expected %s, %s, %s or %s)", prop.Type, models.PropText, models.PropHref, models.PropSrc, models.PropVariant)
+			continue
+		case prop.Slot == "":
+			v.addError(propPath+".slot", "a %s prop needs the slot it sets", prop.Type)
+			continue
+		}
+
+		if atomIDs, isAtom := atomSlots[prop.Slot]; isAtom {
+			for _, atomID := range atomIDs {
+				if atom := v.atomDefs[atomID]; atom != nil && !contentSubatoms[prop.Type][atom.Subatom] {
+					v.addError(propPath+".slot", "atom %q is a %s, which has no %s", atomID, atom.Subatom, prop.Type)
+				}
+			}
+			continue
+		}
+
+		moleculeID, isMolecule := moleculeSlots[prop.Slot]
+		if !isMolecule {
+			v.addError(propPath+".slot", "unknown slot %q", prop.Slot)
+			continue
+		}
+		molecule := v.moleculeDefs[moleculeID]
+		if molecule == nil {
+			continue
+		}
+		childProp, exists := molecule.Props[prop.ChildProp(name)]
+		if !exists {
+			v.addError(propPath, "molecule %q has no prop %q", moleculeID, prop.ChildProp(name))
+		} else if childProp.Type != prop.Type {
+			v.addError(propPath+".type", "molecule %q prop %q is a %s prop", moleculeID, prop.ChildProp(name), childProp.Type)
+		}
+	}
+}
+
+// validatePropValues checks the prop values an instance passes to a
+// molecule or organism
+func (v *Validator) validatePropValues(props map[string]models.Prop, values map[string]string, kind, id, path string) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		prop, exists := props[name]
+		if !exists {
+			v.addError(path+"."+name, "%s %q has no prop %q", kind, id, name)
+			continue
+		}
+		if prop.Type == models.PropVariant && prop.Slot == "" {
+			if _, known := prop.Variants[values[name]]; !known {
+				v.addError(path+"."+name, "unknown variant %q", values[name])
+			}
+		}
+	}
+}
+
+// sortedPropNames returns prop names in lexical order
+func sortedPropNames(props map[string]models.Prop) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (v *Validator) validateLayouts() {
+	layouts, paths := v.layoutEntries()
+	for i, layout := range layouts {
+		for j, section := range layout.Structure {
+			sectionPath := fmt.Sprintf("%s.structure[%d]", paths[i], j)
+			if section.Organism != "" {
+				v.requireRef(v.organisms, "organism", section.Organism, sectionPath+".organism")
+				if organism := v.organismDefs[section.Organism]; organism != nil {
+					v.validatePropValues(organism.Props, section.Props, "organism", organism.ID, sectionPath+".props")
+				}
+			} else if len(section.Props) > 0 {
+				v.addError(sectionPath+".props", "props are passed to a single organism")
+			}
+			for k, orgID := range section.Organisms {
+				v.requireRef(v.organisms, "organism", orgID, fmt.Sprintf("%s.organisms[%d]", sectionPath, k))
+			}
+		}
+	}
+}
+
+func (v *Validator) validatePages() {
+	pages, paths := v.pageEntries()
+	for i, page := range pages {
+		v.requireRef(v.layouts, "layout", page.Layout, paths[i]+".layout")
+		if page.Clump != "" {
+			v.requireRef(v.clumps, "clump", page.Clump, paths[i]+".clump")
+		}
+	}
+}
+
+func (v *Validator) validateClumps() {
+	clumps, paths := v.clumpEntries()
+	for i, clump := range clumps {
+		for j, pageID := range clump.Pages {
+			v.requireRef(v.pages, "page", pageID, fmt.Sprintf("%s.pages[%d]", paths[i], j))
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"atomic-generator/pkg/models"
+)
+
+func parseStructure(t *testing.T, source string) *models.AtomicStructure {
+	t.Helper()
+	var structure models.AtomicStructure
+	if err := json.Unmarshal([]byte(source), &structure); err != nil {
+		t.Fatalf("parsing structure: %v", err)
+	}
+	return &structure
+}
+
+func TestValidateValidStructure(t *testing.T) {
+	structure := parseStructure(t, `{
+		"project": {"id": "p", "name": "P"},
+		"atoms": {"text": [{"id": "body", "subatom": "Text", "config": {"content": "Hi"}}]},
+		"molecules": [{"id": "card", "atoms": {"body": "body"}}],
+		"organisms": [{"id": "hero", "molecules": {"main": "card"}}],
+		"layouts": [{"id": "main", "structure": [{"organism": "hero"}]}],
+		"pages": [{"id": "home", "route": "/", "layout": "main"}]
+	}`)
+
+	if err := NewValidator(structure).Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidateCollectsEveryError(t *testing.T) {
+	structure := parseStructure(t, `{
+		"project": {"id": "p", "name": "P"},
+		"atoms": {"text": [
+			{"id": "body", "subatom": "Text"},
+			{"id": "body", "subatom": "Text"}
+		]},
+		"molecules": [
+			{"id": "card", "atoms": {"body": "body", "icon": "missing-icon"}},
+			{"id": "a", "molecules": {"inner": "b"}},
+			{"id": "b", "molecules": {"inner": "a"}}
+		],
+		"organisms": [{"id": "hero", "molecules": {"main": "missing-card"}}],
+		"layouts": [{"id": "main", "structure": [{"organism": "missing-organism"}]}],
+		"pages": [{"id": "home", "route": "/", "layout": "missing-layout"}]
+	}`)
+
+	err := NewValidator(structure).Validate()
+	var problems ValidationErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("Validate() = %v, want ValidationErrors", err)
+	}
+
+	want := ValidationErrors{
+		{Path: "atoms.text[1].id", Message: `duplicate atom id "body" (first declared at atoms.text[0])`},
+		{Path: "molecules[0].atoms.icon", Message: `atom "missing-icon" not found`},
+		{Path: "molecules[2].molecules.inner", Message: "molecule cycle: a → b → a"},
+		{Path: "organisms[0].molecules.main", Message: `molecule "missing-card" not found`},
+		{Path: "layouts[0].structure[0].organism", Message: `organism "missing-organism" not found`},
+		{Path: "pages[0].layout", Message: `layout "missing-layout" not found`},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n%v", len(problems), len(want), err)
+	}
+	for i := range want {
+		if problems[i] != want[i] {
+			t.Errorf("problem %d = %q, want %q", i, problems[i].Error(), want[i].Error())
+		}
+	}
+}
+
+func TestValidateAtomConfig(t *testing.T) {
+	structure := parseStructure(t, `{
+		"project": {"id": "p", "name": "P"},
+		"atoms": {
+			"headings": [
+				{"id": "title", "subatom": "Heading", "config": {"level": 2}},
+				{"id": "deep", "subatom": "Heading", "config": {"level": 7}},
+				{"id": "half", "subatom": "Heading", "config": {"level": 1.5}}
+			],
+			"text": [
+				{"id": "quote", "subatom": "Text", "config": {"tag": "blockquote"}},
+				{"id": "evil", "subatom": "Text", "config": {"tag": "script"}}
+			]
+		},
+		"layouts": [{"id": "main", "structure": []}],
+		"pages": [{"id": "home", "route": "/", "layout": "main"}]
+	}`)
+
+	err := NewValidator(structure).Validate()
+	var problems ValidationErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("Validate() = %v, want ValidationErrors", err)
+	}
+	want := []string{"atoms.headings[1].config.level", "atoms.headings[2].config.level", "atoms.text[1].config.tag"}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n%v", len(problems), len(want), err)
+	}
+	for i, path := range want {
+		if problems[i].Path != path {
+			t.Errorf("problem %d at %s, want %s", i, problems[i].Path, path)
+		}
+	}
+}
+
+func TestValidationErrorsMessage(t *testing.T) {
+	err := ValidationErrors{
+		{Path: "atoms.text[0].id", Message: "duplicate atom id \"body\""},
+		{Path: "pages[0].layout", Message: "unknown layout \"main\""},
+	}
+
+	want := "2 problem(s) found:\n" +
+		"  - atoms.text[0].id: duplicate atom id \"body\"\n" +
+		"  - pages[0].layout: unknown layout \"main\""
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateRepeat(t *testing.T) {
+	structure := parseStructure(t, `{
+		"project": {"id": "p", "name": "P"},
+		"atoms": {"text": [{"id": "body", "subatom": "Text"}]},
+		"molecules": [{"id": "card", "atoms": {"body": "body"}, "props": {"body": {"type": "text", "slot": "body"}}}],
+		"organisms": [
+			{"id": "grid", "repeat": {"molecule": "card", "key": "slug", "items": [
+				{"slug": "a", "body": "A"},
+				{"slug": "a", "body": "B"},
+				{"body": "C"},
+				{"slug": "d", "title": "D"}
+			]}},
+			{"id": "empty", "repeat": {"molecule": "ghost", "key": "not-an-id", "items": []}}
+		],
+		"layouts": [{"id": "main", "structure": []}],
+		"pages": [{"id": "home", "route": "/", "layout": "main"}]
+	}`)
+
+	err := NewValidator(structure).Validate()
+	var problems ValidationErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("Validate() = %v, want ValidationErrors", err)
+	}
+	want := ValidationErrors{
+		{Path: "organisms[0].repeat.items[1].slug", Message: `duplicate key "a" (first used by items[0])`},
+		{Path: "organisms[0].repeat.items[2]", Message: `missing key "slug"`},
+		{Path: "organisms[0].repeat.items[3].title", Message: `molecule "card" has no prop "title"`},
+		{Path: "organisms[1].repeat.molecule", Message: `molecule "ghost" not found`},
+		{Path: "organisms[1].repeat.items", Message: "a repeat needs items"},
+		{Path: "organisms[1].repeat.key", Message: `key "not-an-id" must be a JavaScript identifier`},
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("Validate() problems =\n%v\nwant\n%v", problems, want)
+	}
+}
+
+func TestValidateMoleculeCycles(t *testing.T) {
+	structure := parseStructure(t, `{
+		"project": {"id": "p", "name": "P"},
+		"atoms": {"text": [{"id": "body", "subatom": "Text"}]},
+		"molecules": [
+			{"id": "card", "atoms": {"body": "body"}, "molecules": {"actions": "button_group"}},
+			{"id": "button_group", "molecules": {"menu": "menu"}},
+			{"id": "menu", "molecules": {"back": "card"}},
+			{"id": "loop", "molecules": {"self": "loop"}},
+			{"id": "shared", "molecules": {"a": "leaf", "b": "leaf"}},
+			{"id": "leaf", "atoms": {"body": "body"}}
+		],
+		"layouts": [{"id": "main", "structure": []}],
+		"pages": [{"id": "home", "route": "/", "layout": "main"}]
+	}`)
+
+	err := NewValidator(structure).Validate()
+	var problems ValidationErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("Validate() = %v, want ValidationErrors", err)
+	}
+	// Each cycle is reported once, and a molecule nested twice is no cycle
+	want := ValidationErrors{
+		{Path: "molecules[2].molecules.back", Message: "molecule cycle: card → button_group → menu → card"},
+		{Path: "molecules[3].molecules.self", Message: "molecule cycle: loop → loop"},
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("Validate() problems =\n%v\nwant\n%v", problems, want)
+	}
+}
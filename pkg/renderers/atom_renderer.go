@@ -9,95 +9,121 @@ import (
 // AtomRenderer generates React components from Atoms
 type AtomRenderer struct {
 	atom      *models.Atom
-	structure *models.AtomicStructure
+	ctx       *Context
+	styles    *StyleEmitter
+	inherited []InheritedState
+	hiddenAt  []string
+	// forward is set while rendering the component of an atom its parents
+	// restyle
+	forward bool
+	// content replaces the atom's text, href or src
+	content map[string]propValue
 }
 
-func NewAtomRenderer(atom *models.Atom, structure *models.AtomicStructure) *AtomRenderer {
+func NewAtomRenderer(atom *models.Atom, ctx *Context) *AtomRenderer {
 	return &AtomRenderer{
-		atom:      atom,
-		structure: structure,
+		atom:   atom,
+		ctx:    ctx,
+		styles: NewStyleEmitter(ctx),
 	}
 }
 
+// withStyles makes the atom emit its styles into a parent's stylesheet
+func (ar *AtomRenderer) withStyles(styles *StyleEmitter) *AtomRenderer {
+	ar.styles = styles
+	return ar
+}
+
+// withInherited applies states inherited from the atom's parent
+func (ar *AtomRenderer) withInherited(inherited []InheritedState) *AtomRenderer {
+	ar.inherited = inherited
+	return ar
+}
+
+// withContent replaces the atom's content (text, href or src) by static
+// values or expressions
+func (ar *AtomRenderer) withContent(content map[string]propValue) *AtomRenderer {
+	ar.content = content
+	return ar
+}
+
+// withHiddenAt hides the atom at the given breakpoints (responsive swaps)
+func (ar *AtomRenderer) withHiddenAt(breakpoints []string) *AtomRenderer {
+	ar.hiddenAt = breakpoints
+	return ar
+}
+
 // Render generates the JSX for an atom
 func (ar *AtomRenderer) Render() (string, error) {
+	el, err := ar.element()
+	if err != nil {
+		return "", err
+	}
+	return PrintJSX(el, 0), nil
+}
+
+// element builds the JSX element of the atom
+func (ar *AtomRenderer) element() (*JSXElement, error) {
 	// Use SubatomRenderer to render the base component
-	subatomRenderer := NewSubatomRenderer(ar.atom)
-	return subatomRenderer.Render()
+	subatomRenderer := NewSubatomRenderer(atomWithContent(ar.atom, ar.content), ar.styles).
+		withInherited(ar.inherited).
+		withHiddenAt(ar.hiddenAt).
+		withForward(ar.forward)
+	el, err := subatomRenderer.element()
+	if err != nil {
+		return nil, err
+	}
+	applyContent(el, ar.content)
+	return el, nil
+}
+
+// StyleSheet returns the CSS Module of the last rendered component
+func (ar *AtomRenderer) StyleSheet() string {
+	return ar.styles.StyleSheet()
+}
+
+// Interactive reports whether the last rendered component tracks
+// interaction states with hooks
+func (ar *AtomRenderer) Interactive() bool {
+	return ar.styles.HasHooks()
 }
 
 // RenderAsComponent generates a full React component for the atom
 func (ar *AtomRenderer) RenderAsComponent() (string, error) {
 	componentName := ToPascalCase(ar.atom.ID)
-	
-	// Generate JSX
-	jsx, err := ar.Render()
-	if err != nil {
-		return "", err
+	ar.styles = NewStyleEmitter(ar.ctx)
+	ar.forward = ar.ctx.restyled(ar.atom.ID)
+	kinds := ar.ctx.contentProps(ar.atom.ID)
+	ar.content = make(map[string]propValue)
+	for _, kind := range kinds {
+		ar.content[kind] = propValue{expr: kind}
 	}
-
-	// Generate state management if needed (for hover, focus, etc.)
-	var stateCode string
-	var eventHandlers string
-	
-	if len(ar.atom.States) > 0 {
-		stateCode = ar.generateStateManagement()
-		eventHandlers = ar.generateEventHandlers()
+	props := atomContentProps(ar.atom, kinds)
+	if ar.forward {
+		props = append(props, restyleProps...)
 	}
 
-	component := fmt.Sprintf(`import React, { useState } from 'react';
+	// Build the JSX (state hooks for hover, focus, etc. are collected by
+	// the style emitter while building)
+	el, err := ar.element()
+	if err != nil {
+		return "", err
+	}
 
-const %s = () => {
+	component := fmt.Sprintf(`%s
+%s
+%s
+%s  return (
 %s
-  return (
-    %s%s
   );
 };
 
 export default %s;
-`, componentName, stateCode, IndentCode(jsx, 2), eventHandlers, componentName)
+`, reactImport(ar.styles.hookImports()...), ar.styles.ImportStatement(componentName), ar.ctx.componentDeclaration(componentName, props...), ar.styles.HookDeclarations(), PrintJSX(el, 2), componentName)
 
 	return component, nil
 }
 
-// generateStateManagement creates state hooks for interactive atoms
-func (ar *AtomRenderer) generateStateManagement() string {
-	if len(ar.atom.States) == 0 {
-		return ""
-	}
-
-	var stateVars []string
-	for stateName := range ar.atom.States {
-		stateVars = append(stateVars, fmt.Sprintf("  const [is%s, setIs%s] = useState(false);", 
-			Capitalize(stateName), Capitalize(stateName)))
-	}
-
-	return "\n" + fmt.Sprintf("%s\n", stateVars[0]) // For now, just return first state
-}
-
-// generateEventHandlers creates event handlers for state changes
-func (ar *AtomRenderer) generateEventHandlers() string {
-	if len(ar.atom.States) == 0 {
-		return ""
-	}
-
-	// For hover state
-	if _, hasHover := ar.atom.States["hover"]; hasHover {
-		return `
-      onMouseEnter={() => setIsHover(true)}
-      onMouseLeave={() => setIsHover(false)}`
-	}
-
-	// For focus state
-	if _, hasFocus := ar.atom.States["focus"]; hasFocus {
-		return `
-      onFocus={() => setIsFocus(true)}
-      onBlur={() => setIsFocus(false)}`
-	}
-
-	return ""
-}
-
 // RenderInline generates inline JSX without wrapping component
 func (ar *AtomRenderer) RenderInline() (string, error) {
 	return ar.Render()
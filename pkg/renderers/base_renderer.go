@@ -2,7 +2,10 @@ package renderers
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"atomic-generator/pkg/parser"
 )
 
 // Renderer is the base interface for all renderers
@@ -23,29 +26,41 @@ func (sc *StyleConverter) ToInlineStyle(styles map[string]interface{}) string {
 		return ""
 	}
 
+	// React inline styles need double braces: style={{ ... }}
+	return "{{ " + sc.toObjectEntries(styles) + " }}"
+}
+
+// toObjectEntries converts a style map to the entries of a JS object literal
+func (sc *StyleConverter) toObjectEntries(styles map[string]interface{}) string {
 	var styleStrings []string
-	for key, value := range styles {
+	for _, key := range SortedKeys(styles) {
 		jsKey := sc.toJSProperty(key)
-		jsValue := sc.formatValue(value)
+		if strings.HasPrefix(jsKey, "--") {
+			jsKey = JSString(jsKey)
+		}
+		jsValue := sc.formatValue(styles[key])
 		styleStrings = append(styleStrings, fmt.Sprintf("%s: %s", jsKey, jsValue))
 	}
-
-	// React inline styles need double braces: style={{ ... }}
-	return "{{ " + strings.Join(styleStrings, ", ") + " }}"
+	return strings.Join(styleStrings, ", ")
 }
 
 // ToCSSModule converts styles to CSS module format
 func (sc *StyleConverter) ToCSSModule(className string, styles map[string]interface{}) string {
+	return sc.ToCSSRule("."+className, styles)
+}
+
+// ToCSSRule converts styles to a CSS rule for any selector
+func (sc *StyleConverter) ToCSSRule(selector string, styles map[string]interface{}) string {
 	if len(styles) == 0 {
 		return ""
 	}
 
 	var cssLines []string
-	cssLines = append(cssLines, fmt.Sprintf(".%s {", className))
+	cssLines = append(cssLines, fmt.Sprintf("%s {", selector))
 
-	for key, value := range styles {
+	for _, key := range SortedKeys(styles) {
 		cssKey := sc.toCSSProperty(key)
-		cssValue := sc.formatValue(value)
+		cssValue := sc.formatCSSValue(key, styles[key])
 		cssLines = append(cssLines, fmt.Sprintf("  %s: %s;", cssKey, cssValue))
 	}
 
@@ -55,6 +70,11 @@ func (sc *StyleConverter) ToCSSModule(className string, styles map[string]interf
 
 // toJSProperty converts CSS property names to camelCase for React
 func (sc *StyleConverter) toJSProperty(prop string) string {
+	// Custom properties keep their case-sensitive name
+	if strings.HasPrefix(prop, "--") {
+		return prop
+	}
+
 	// Convert kebab-case to camelCase
 	parts := strings.Split(prop, "-")
 	if len(parts) == 1 {
@@ -72,6 +92,9 @@ func (sc *StyleConverter) toJSProperty(prop string) string {
 
 // toCSSProperty converts camelCase to kebab-case for CSS
 func (sc *StyleConverter) toCSSProperty(prop string) string {
+	if strings.HasPrefix(prop, "--") {
+		return prop
+	}
 	var result []rune
 	for i, r := range prop {
 		if i > 0 && r >= 'A' && r <= 'Z' {
@@ -88,13 +111,8 @@ func (sc *StyleConverter) toCSSProperty(prop string) string {
 func (sc *StyleConverter) formatValue(value interface{}) string {
 	switch v := value.(type) {
 	case string:
-		// Check if it's a CSS variable or function
-		if strings.HasPrefix(v, "var(") || strings.HasPrefix(v, "clamp(") || 
-		   strings.HasPrefix(v, "calc(") || strings.HasPrefix(v, "rgba(") ||
-		   strings.HasPrefix(v, "rgb(") {
-			return fmt.Sprintf("'%s'", v)
-		}
-		return fmt.Sprintf("'%s'", v)
+		// CSS values can hold quotes of their own (font families, content)
+		return JSString(v)
 	case int, int64:
 		return fmt.Sprintf("%d", v)
 	case float64:
@@ -109,7 +127,58 @@ func (sc *StyleConverter) formatValue(value interface{}) string {
 		}
 		return "false"
 	default:
-		return fmt.Sprintf("'%v'", v)
+		return JSString(fmt.Sprintf("%v", v))
+	}
+}
+
+// SortedKeys returns the keys of a map in lexical order. Style maps are
+// emitted in this order so identical input always gives identical output;
+// shorthands (margin, border) also sort before their longhands (marginTop).
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unitlessProperties lists the CSS properties whose numeric values take no
+// unit (React adds "px" to every other number in inline styles)
+var unitlessProperties = map[string]bool{
+	"flex":        true,
+	"flexGrow":    true,
+	"flexShrink":  true,
+	"fontWeight":  true,
+	"lineHeight":  true,
+	"opacity":     true,
+	"order":       true,
+	"zIndex":      true,
+	"zoom":        true,
+	"aspectRatio": true,
+	"gridRow":     true,
+	"gridColumn":  true,
+}
+
+// formatCSSValue formats a value for a plain CSS declaration: strings are
+// written as-is and numbers get "px" unless the property is unitless, the
+// same way React treats numbers in inline styles
+func (sc *StyleConverter) formatCSSValue(prop string, value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		formatted := fmt.Sprintf("%g", v)
+		if v == 0 || unitlessProperties[sc.toJSProperty(prop)] {
+			return formatted
+		}
+		return formatted + "px"
+	case int:
+		return sc.formatCSSValue(prop, float64(v))
+	case int64:
+		return sc.formatCSSValue(prop, float64(v))
+	default:
+		return fmt.Sprintf("%v", v)
 	}
 }
 
@@ -135,15 +204,27 @@ func Capitalize(s string) string {
 
 // ToPascalCase converts a string to PascalCase
 func ToPascalCase(s string) string {
-	// Handle snake_case and kebab-case
-	s = strings.ReplaceAll(s, "_", "-")
-	parts := strings.Split(s, "-")
-	
-	var result string
-	for _, part := range parts {
-		if len(part) > 0 {
-			result += Capitalize(part)
-		}
-	}
-	return result
+	return parser.ComponentName(s)
 }
+
+// jsStringEscaper escapes text for a single-quoted JavaScript string
+var jsStringEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\u2028", `\u2028`,
+	"\u2029", `\u2029`,
+)
+
+// JSString quotes s as a single-quoted JavaScript string literal
+func JSString(s string) string {
+	return "'" + jsStringEscaper.Replace(s) + "'"
+}
+
+// jsTemplateEscaper escapes text for a JavaScript template literal
+var jsTemplateEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"${", `\${`,
+)
@@ -0,0 +1,22 @@
+package renderers
+
+import "testing"
+
+func TestStyleConverterCustomProperties(t *testing.T) {
+	sc := NewStyleConverter()
+	styles := map[string]interface{}{
+		"--accentColor":    "var(--color-primary)",
+		"background-color": "var(--accentColor)",
+		"fontSize":         "12px",
+	}
+
+	wantJS := "'--accentColor': 'var(--color-primary)', backgroundColor: 'var(--accentColor)', fontSize: '12px'"
+	if got := sc.toObjectEntries(styles); got != wantJS {
+		t.Errorf("toObjectEntries() = %s, want %s", got, wantJS)
+	}
+
+	wantCSS := ".card {\n  --accentColor: var(--color-primary);\n  background-color: var(--accentColor);\n  font-size: 12px;\n}"
+	if got := sc.ToCSSRule(".card", styles); got != wantCSS {
+		t.Errorf("ToCSSRule() =\n%s\nwant\n%s", got, wantCSS)
+	}
+}
@@ -0,0 +1,197 @@
+package renderers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/parser"
+)
+
+// restyleProps are the props of a component its parents restyle: a class
+// and styles merged into its root element
+var restyleProps = []componentProp{
+	{name: "className", tsType: "string", defaultValue: "''"},
+	{name: "style", tsType: "React.CSSProperties"},
+}
+
+// composes reports whether parents import their children's components
+// instead of inlining their markup, which they do unless asked not to
+func (c *Context) composes() bool {
+	return c.Options.Composition != CompositionInline
+}
+
+// restyled reports whether a parent restyles the component with the given
+// ID (an inherited state or a responsive swap), so the component must
+// accept the restyle props
+func (c *Context) restyled(id string) bool {
+	if !c.composes() {
+		return false
+	}
+	if c.restyledIDs == nil {
+		c.restyledIDs = c.findRestyled()
+	}
+	return c.restyledIDs[id]
+}
+
+// findRestyled walks every molecule and organism for the children they
+// restyle, exactly as their renderers do
+func (c *Context) findRestyled() map[string]bool {
+	restyled := make(map[string]bool)
+	structure := c.Registry.Structure()
+
+	for i := range structure.Molecules {
+		molecule := &structure.Molecules[i]
+		slots := append(molecule.Atoms.Keys(), molecule.Molecules.Keys()...)
+		_, nested := SplitStates(molecule.States, slots)
+		for _, variant := range NewMoleculeRenderer(molecule, c).atomVariants() {
+			if len(variant.hiddenAt) > 0 || len(nested[variant.key]) > 0 {
+				restyled[variant.atomID] = true
+			}
+		}
+		for _, ref := range molecule.Molecules {
+			if len(nested[ref.Key]) > 0 {
+				restyled[ref.ID] = true
+			}
+		}
+	}
+
+	for i := range structure.Organisms {
+		organism := &structure.Organisms[i]
+		slots := append(organism.Atoms.Keys(), organism.Molecules.Refs.Keys()...)
+		_, nested := SplitStates(organism.States, slots)
+		for _, ref := range organism.Atoms {
+			if len(nested[ref.Key]) > 0 {
+				restyled[ref.ID] = true
+			}
+		}
+		if !organism.Molecules.List {
+			for _, ref := range organism.Molecules.Refs {
+				if len(nested[ref.Key]) > 0 {
+					restyled[ref.ID] = true
+				}
+			}
+		}
+	}
+
+	return restyled
+}
+
+// contentProps returns the content types (text, href, src) parents set on
+// an atom through their props, which the atom's component must accept
+func (c *Context) contentProps(id string) []string {
+	if !c.composes() {
+		return nil
+	}
+	if c.atomProps == nil {
+		c.atomProps = c.findContentProps()
+	}
+	return c.atomProps[id]
+}
+
+// findContentProps collects the content props of every molecule and
+// organism by the atoms they set
+func (c *Context) findContentProps() map[string][]string {
+	found := make(map[string]map[string]bool)
+	bind := func(props map[string]models.Prop, slots map[string][]string) {
+		for _, prop := range props {
+			if contentKeys[prop.Type] == "" {
+				continue
+			}
+			for _, atomID := range slots[prop.Slot] {
+				if found[atomID] == nil {
+					found[atomID] = make(map[string]bool)
+				}
+				found[atomID][prop.Type] = true
+			}
+		}
+	}
+
+	structure := c.Registry.Structure()
+	for _, molecule := range structure.Molecules {
+		slots := make(map[string][]string)
+		for _, ref := range molecule.Atoms {
+			slots[ref.Key] = append(slots[ref.Key], ref.ID)
+		}
+		for _, responsive := range molecule.Responsive {
+			for _, ref := range responsive.Atoms {
+				slots[ref.Key] = append(slots[ref.Key], ref.ID)
+			}
+		}
+		bind(molecule.Props, slots)
+	}
+	for _, organism := range structure.Organisms {
+		slots := make(map[string][]string)
+		for _, ref := range organism.Atoms {
+			slots[ref.Key] = []string{ref.ID}
+		}
+		bind(organism.Props, slots)
+	}
+
+	props := make(map[string][]string)
+	for atomID, kinds := range found {
+		props[atomID] = SortedKeys(kinds)
+	}
+	return props
+}
+
+// componentDir returns the components subdirectory of an organism, molecule
+// or atom ID
+func componentDir(registry *parser.Registry, id string) string {
+	kind, _ := registry.KindOf(id)
+	switch kind {
+	case parser.KindOrganism:
+		return "organisms"
+	case parser.KindMolecule:
+		return "molecules"
+	default:
+		return "atoms"
+	}
+}
+
+// componentImports collects the child components a component renders, in
+// first-use order, and imports them relative to the component's directory
+type componentImports struct {
+	ctx *Context
+	dir string
+	ids []string
+}
+
+func newComponentImports(ctx *Context, dir string) *componentImports {
+	return &componentImports{ctx: ctx, dir: dir}
+}
+
+// element renders a child as its own component, passing it values as
+// props, and imports it. The parent's state and breakpoint styles for the
+// child are registered with styles and passed through the child's restyle
+// props.
+func (ci *componentImports) element(styles *StyleEmitter, id string, values map[string]propValue, inherited []InheritedState, hiddenAt []string) *JSXElement {
+	if !slices.Contains(ci.ids, id) {
+		ci.ids = append(ci.ids, id)
+	}
+
+	attrs := valueAttrs(values)
+	attrs = append(attrs, styles.Attrs(StyleSpec{
+		Name:      id,
+		Inherited: inherited,
+		HiddenAt:  hiddenAt,
+	})...)
+	return &JSXElement{Tag: ToPascalCase(id), Attrs: attrs}
+}
+
+// statements returns the import lines of the collected components, each
+// preceded by a line break so they can follow the React import
+func (ci *componentImports) statements() string {
+	var b strings.Builder
+	for _, id := range ci.ids {
+		name := ToPascalCase(id)
+		dir := componentDir(ci.ctx.Registry, id)
+		path := fmt.Sprintf("../%s/%s", dir, name)
+		if dir == ci.dir {
+			path = "./" + name
+		}
+		fmt.Fprintf(&b, "\nimport %s from '%s';", name, path)
+	}
+	return b.String()
+}
@@ -0,0 +1,184 @@
+package renderers
+
+import (
+	"fmt"
+	"strings"
+
+	"atomic-generator/pkg/parser"
+)
+
+// StyleMode selects how component styles are emitted
+type StyleMode string
+
+const (
+	// StyleModeInline emits style={{ ... }} objects (the default)
+	StyleModeInline StyleMode = "inline"
+	// StyleModeCSSModules emits a sibling .module.css file per component
+	// and references it through className={styles.x}
+	StyleModeCSSModules StyleMode = "css-modules"
+)
+
+// ParseStyleMode validates a -style-mode flag value
+func ParseStyleMode(value string) (StyleMode, error) {
+	switch StyleMode(value) {
+	case "", StyleModeInline:
+		return StyleModeInline, nil
+	case StyleModeCSSModules:
+		return StyleModeCSSModules, nil
+	default:
+		return "", fmt.Errorf("unknown style mode %q (expected %s or %s)", value, StyleModeInline, StyleModeCSSModules)
+	}
+}
+
+// Lang selects the language of the generated sources
+type Lang string
+
+const (
+	// LangJS emits .jsx/.js files (the default)
+	LangJS Lang = "js"
+	// LangTS emits .tsx/.ts files with typed props, routes and metadata
+	LangTS Lang = "ts"
+)
+
+// ParseLang validates a -lang flag value
+func ParseLang(value string) (Lang, error) {
+	switch Lang(value) {
+	case "", LangJS:
+		return LangJS, nil
+	case LangTS:
+		return LangTS, nil
+	default:
+		return "", fmt.Errorf("unknown language %q (expected %s or %s)", value, LangJS, LangTS)
+	}
+}
+
+// ComponentExt returns the extension of files containing JSX
+func (l Lang) ComponentExt() string {
+	if l == LangTS {
+		return ".tsx"
+	}
+	return ".jsx"
+}
+
+// ScriptExt returns the extension of plain script files
+func (l Lang) ScriptExt() string {
+	if l == LangTS {
+		return ".ts"
+	}
+	return ".js"
+}
+
+// Composition selects how molecules and organisms render their children
+type Composition string
+
+const (
+	// CompositionImport renders children as the components generated for
+	// them, imported by their parent (the default of the React targets;
+	// template targets always inline)
+	CompositionImport Composition = "import"
+	// CompositionInline copies the markup of every child into its parent
+	CompositionInline Composition = "inline"
+)
+
+// ParseComposition validates a -composition flag value. An empty value
+// leaves the choice to the target.
+func ParseComposition(value string) (Composition, error) {
+	switch Composition(value) {
+	case "":
+		return "", nil
+	case CompositionImport:
+		return CompositionImport, nil
+	case CompositionInline:
+		return CompositionInline, nil
+	default:
+		return "", fmt.Errorf("unknown composition %q (expected %s or %s)", value, CompositionImport, CompositionInline)
+	}
+}
+
+// Options holds the generation settings shared by every renderer
+type Options struct {
+	StyleMode   StyleMode
+	Lang        Lang
+	Composition Composition
+}
+
+// Context is shared by all renderers taking part in one generation run
+type Context struct {
+	Registry *parser.Registry
+	Options  Options
+
+	// restyledIDs holds the components a parent restyles, and atomProps
+	// the content props parents set on atoms, both computed on first use
+	restyledIDs map[string]bool
+	atomProps   map[string][]string
+}
+
+func NewContext(registry *parser.Registry, options Options) *Context {
+	if options.StyleMode == "" {
+		options.StyleMode = StyleModeInline
+	}
+	if options.Lang == "" {
+		options.Lang = LangJS
+	}
+	return &Context{
+		Registry: registry,
+		Options:  options,
+	}
+}
+
+// componentProp is a prop a component accepts
+type componentProp struct {
+	name   string
+	tsType string
+	// defaultValue is a JS expression, or "" for no default
+	defaultValue string
+}
+
+// componentDeclaration opens the arrow function of a component,
+// destructuring its props. In TypeScript a component taking props also gets
+// an exported props interface.
+func (c *Context) componentDeclaration(name string, props ...componentProp) string {
+	if len(props) == 0 {
+		return fmt.Sprintf("const %s = () => {", name)
+	}
+
+	var names []string
+	for _, prop := range props {
+		if prop.defaultValue != "" {
+			names = append(names, fmt.Sprintf("%s = %s", prop.name, prop.defaultValue))
+		} else {
+			names = append(names, prop.name)
+		}
+	}
+	params := fmt.Sprintf("({ %s })", strings.Join(names, ", "))
+
+	if c.Options.Lang == LangTS {
+		var fields []string
+		for _, prop := range props {
+			fields = append(fields, fmt.Sprintf("  %s?: %s;", prop.name, prop.tsType))
+		}
+		return fmt.Sprintf("export interface %sProps {\n%s\n}\n\nconst %s: React.FC<%sProps> = %s => {", name, strings.Join(fields, "\n"), name, name, params)
+	}
+	return fmt.Sprintf("const %s = %s => {", name, params)
+}
+
+// reactImport returns the React import line, naming only the hooks the
+// component uses (server components can't import them at all)
+func reactImport(hooks ...string) string {
+	if len(hooks) == 0 {
+		return "import React from 'react';"
+	}
+	return fmt.Sprintf("import React, { %s } from 'react';", strings.Join(hooks, ", "))
+}
+
+// ComponentRenderer is implemented by every renderer that can emit a
+// standalone component file
+type ComponentRenderer interface {
+	RenderAsComponent() (string, error)
+	// StyleSheet returns the CSS Module collected by the last
+	// RenderAsComponent call, or "" when there is nothing to write
+	StyleSheet() string
+	// Interactive reports whether the last rendered component holds state
+	// or runs effects, i.e. must run on the client
+	Interactive() bool
+}
@@ -0,0 +1,166 @@
+package renderers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JSXNode is a node of a JSX tree: a *JSXElement, JSXText, JSXExpr or
+// *JSXMap.
+// Renderers build trees of nodes and PrintJSX writes them, escaping text and
+// attribute values for the context they end up in, so content never needs
+// escaping where it is built.
+type JSXNode interface {
+	jsxNode()
+}
+
+// JSXElement is a JSX element. An element without Tag is a fragment
+// (<>...</>).
+type JSXElement struct {
+	Tag      string
+	Attrs    []JSXAttr
+	Children []JSXNode
+}
+
+// JSXAttr is an attribute of a JSX element: a string (Value), an expression
+// (Expr) or a boolean attribute without value
+type JSXAttr struct {
+	Name string
+	// Value is a string value, unescaped
+	Value string
+	// Expr is a JavaScript expression, written between braces instead of
+	// Value
+	Expr    string
+	Boolean bool
+}
+
+// JSXText is text content, unescaped
+type JSXText string
+
+// JSXExpr is a JavaScript expression child, written between braces
+type JSXExpr string
+
+// JSXMap renders Body once per element of the array List, bound to Item
+type JSXMap struct {
+	List string
+	Item string
+	Body *JSXElement
+}
+
+func (*JSXElement) jsxNode() {}
+func (JSXText) jsxNode()     {}
+func (JSXExpr) jsxNode()     {}
+func (*JSXMap) jsxNode()     {}
+
+// jsxAttrNames maps HTML attributes to their JSX names
+var jsxAttrNames = map[string]string{
+	"class":      "className",
+	"for":        "htmlFor",
+	"http-equiv": "httpEquiv",
+}
+
+// ElementJSX converts a markup element to JSX: its tag, attributes, static
+// classes, text and children. Class bindings are left out, JSX components
+// style elements through a StyleEmitter instead.
+func ElementJSX(el *Element) *JSXElement {
+	jsx := &JSXElement{Tag: el.Tag}
+	if len(el.Classes) > 0 {
+		jsx.Attrs = append(jsx.Attrs, JSXAttr{Name: "className", Value: strings.Join(el.Classes, " ")})
+	}
+	for _, attr := range el.Attrs {
+		name := attr.Name
+		if jsxName, ok := jsxAttrNames[name]; ok {
+			name = jsxName
+		}
+		jsx.Attrs = append(jsx.Attrs, JSXAttr{Name: name, Value: attr.Value, Boolean: attr.Boolean})
+	}
+	if el.Text != "" {
+		jsx.Children = append(jsx.Children, JSXText(el.Text))
+	}
+	for _, child := range el.Children {
+		jsx.Children = append(jsx.Children, ElementJSX(child))
+	}
+	return jsx
+}
+
+// jsxAttrValue returns a string attribute value, as an expression when it
+// can't be written between double quotes (JSX strings have no escapes and
+// decode HTML entities)
+func jsxAttrValue(value string) string {
+	if strings.ContainsAny(value, "\"&{}\n\r") {
+		return "{" + JSString(value) + "}"
+	}
+	return `"` + value + `"`
+}
+
+// jsxText returns text content as JSX: verbatim when JSX reads it back
+// unchanged, otherwise as a string expression. Braces and angle brackets
+// would open expressions and tags, ampersands entities, and JSX trims the
+// whitespace around line breaks.
+func jsxText(text string) string {
+	if strings.ContainsAny(text, "{}<>&\n\r") || strings.TrimSpace(text) != text {
+		return "{" + JSString(text) + "}"
+	}
+	return text
+}
+
+// PrintJSX prints a JSX tree, indenting every level by two spaces from
+// indent. Elements with a single text or expression child stay on one line.
+func PrintJSX(node JSXNode, indent int) string {
+	var b strings.Builder
+	writeJSX(&b, node, indent)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeJSX(b *strings.Builder, node JSXNode, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch n := node.(type) {
+	case JSXText:
+		fmt.Fprintf(b, "%s%s\n", pad, jsxText(string(n)))
+		return
+	case JSXExpr:
+		fmt.Fprintf(b, "%s{%s}\n", pad, n)
+		return
+	case *JSXMap:
+		fmt.Fprintf(b, "%s{%s.map((%s) => (\n", pad, n.List, n.Item)
+		writeJSX(b, n.Body, indent+1)
+		fmt.Fprintf(b, "%s))}\n", pad)
+		return
+	}
+
+	el := node.(*JSXElement)
+	open := el.Tag
+	for _, attr := range el.Attrs {
+		switch {
+		case attr.Boolean:
+			open += " " + attr.Name
+		case attr.Expr != "":
+			open += fmt.Sprintf(" %s={%s}", attr.Name, attr.Expr)
+		default:
+			open += fmt.Sprintf(" %s=%s", attr.Name, jsxAttrValue(attr.Value))
+		}
+	}
+
+	switch {
+	case len(el.Children) == 0 && el.Tag != "":
+		fmt.Fprintf(b, "%s<%s />\n", pad, open)
+	case len(el.Children) == 1 && inlineJSX(el.Children[0]):
+		fmt.Fprintf(b, "%s<%s>%s</%s>\n", pad, open, strings.TrimSpace(PrintJSX(el.Children[0], 0)), el.Tag)
+	default:
+		fmt.Fprintf(b, "%s<%s>\n", pad, open)
+		for _, child := range el.Children {
+			writeJSX(b, child, indent+1)
+		}
+		fmt.Fprintf(b, "%s</%s>\n", pad, el.Tag)
+	}
+}
+
+// inlineJSX reports whether a node is printed on its parent's line
+func inlineJSX(node JSXNode) bool {
+	switch node.(type) {
+	case JSXText, JSXExpr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package renderers
+
+import "testing"
+
+func TestPrintJSXEscapesText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Plain text", "<p>Plain text</p>"},
+		{"Don't stop", "<p>Don't stop</p>"},
+		{"{name}", "<p>{'{name}'}</p>"},
+		{"<script>alert(1)</script>", "<p>{'<script>alert(1)</script>'}</p>"},
+		{"Fish & chips", "<p>{'Fish & chips'}</p>"},
+		{"&amp;", "<p>{'&amp;'}</p>"},
+		{"It's {here}", `<p>{'It\'s {here}'}</p>`},
+		{"one\ntwo", `<p>{'one\ntwo'}</p>`},
+		{"  padded ", "<p>{'  padded '}</p>"},
+		{"back\\slash <b>", `<p>{'back\\slash <b>'}</p>`},
+	}
+	for _, tt := range tests {
+		node := &JSXElement{Tag: "p", Children: []JSXNode{JSXText(tt.text)}}
+		if got := PrintJSX(node, 0); got != tt.want {
+			t.Errorf("text %q printed as %s, want %s", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPrintJSXEscapesAttributes(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"/about", `<a href="/about" />`},
+		{"it's", `<a href="it's" />`},
+		{`say "hi"`, `<a href={'say "hi"'} />`},
+		{"a&b", `<a href={'a&b'} />`},
+		{"{x}", `<a href={'{x}'} />`},
+		{"line\nbreak", `<a href={'line\nbreak'} />`},
+		{"</a><script>", `<a href="</a><script>" />`},
+	}
+	for _, tt := range tests {
+		node := &JSXElement{Tag: "a", Attrs: []JSXAttr{{Name: "href", Value: tt.value}}}
+		if got := PrintJSX(node, 0); got != tt.want {
+			t.Errorf("attribute %q printed as %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestPrintJSXExpressionsVerbatim(t *testing.T) {
+	node := &JSXElement{
+		Tag: "div",
+		Attrs: []JSXAttr{
+			{Name: "style", Expr: "styles.card"},
+			{Name: "disabled", Boolean: true},
+		},
+		Children: []JSXNode{
+			JSXExpr("title"),
+			&JSXMap{List: "items", Item: "item", Body: &JSXElement{Tag: "Card", Attrs: []JSXAttr{{Name: "key", Expr: "item.id"}}}},
+		},
+	}
+
+	want := `<div style={styles.card} disabled>
+  {title}
+  {items.map((item) => (
+    <Card key={item.id} />
+  ))}
+</div>`
+	if got := PrintJSX(node, 0); got != want {
+		t.Errorf("PrintJSX() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestJSString(t *testing.T) {
+	tests := map[string]string{
+		"plain":        `'plain'`,
+		"it's":         `'it\'s'`,
+		`back\slash`:   `'back\\slash'`,
+		"a\nb\r":       `'a\nb\r'`,
+		"sep\u2028tor": `'sep\u2028tor'`,
+	}
+	for in, want := range tests {
+		if got := JSString(in); got != want {
+			t.Errorf("JSString(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package renderers
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/parser"
+)
+
+// Element is a framework-neutral HTML element. Template based targets (Vue,
+// Svelte, plain HTML...) build a tree of elements with MarkupBuilder and
+// print it in their own template syntax with PrintMarkup. An element
+// without Tag is a fragment: only its children are printed.
+type Element struct {
+	Tag   string
+	Attrs []Attr
+	// Text is the element's text content, unescaped
+	Text string
+	// TextProp names the component prop the text content is bound to, Text
+	// then being its default
+	TextProp string
+	Children []*Element
+	// Void elements (img, input) have no closing tag
+	Void bool
+
+	// Classes are the element's static classes: its type class
+	// (molecule-search_form) and its stylesheet class
+	Classes []string
+	// Bindings toggle classes while a component state holds (e.g. the
+	// header's scrolled modifier)
+	Bindings []ClassBinding
+	// Style is the spec behind the element's stylesheet class, for targets
+	// without CSS that compile styles themselves (React Native). It is nil
+	// when the element has no stylesheet class.
+	Style *ElementStyle
+}
+
+// ElementStyle is the style spec of an element and its stylesheet class,
+// which is unique within the builder
+type ElementStyle struct {
+	Class string
+	StyleSpec
+}
+
+// Attr is an HTML attribute. Boolean attributes (disabled) have no value.
+type Attr struct {
+	Name    string
+	Value   string
+	Boolean bool
+	// Prop names the component prop the value is bound to, Value then
+	// being its default
+	Prop string
+}
+
+// PropAttrs returns the attributes passing prop values to a component,
+// sorted by name
+func PropAttrs(props map[string]string) []Attr {
+	var attrs []Attr
+	for _, name := range SortedKeys(props) {
+		attrs = append(attrs, Attr{Name: name, Value: props[name]})
+	}
+	return attrs
+}
+
+// ClassBinding adds Class while the component state Condition is true
+type ClassBinding struct {
+	Class     string
+	Condition string
+}
+
+// voidTags lists the elements without closing tag
+var voidTags = map[string]bool{
+	"img":   true,
+	"input": true,
+}
+
+// isDisabled reports whether an atom is configured as disabled
+func isDisabled(atom *models.Atom) bool {
+	disabled, _ := atom.Config["disabled"].(bool)
+	return disabled
+}
+
+// subatomElement maps an atom's config to its HTML element, without styles
+func subatomElement(atom *models.Atom) (*Element, error) {
+	config := atom.Config
+	el := &Element{}
+
+	str := func(key string) (string, bool) {
+		value, ok := config[key].(string)
+		return value, ok
+	}
+	attr := func(name, key string) {
+		if value, ok := str(key); ok {
+			el.Attrs = append(el.Attrs, Attr{Name: name, Value: value})
+		}
+	}
+	disabled := func() {
+		if isDisabled(atom) {
+			el.Attrs = append(el.Attrs, Attr{Name: "disabled", Boolean: true})
+		}
+	}
+	content := func() {
+		el.Text, _ = str("content")
+	}
+
+	switch atom.Subatom {
+	case "Image":
+		el.Tag = "img"
+		attr("src", "src")
+		attr("alt", "alt")
+		attr("loading", "loading")
+		for _, key := range []string{"width", "height"} {
+			if value, ok := config[key]; ok {
+				el.Attrs = append(el.Attrs, Attr{Name: key, Value: fmt.Sprintf("%v", value)})
+			}
+		}
+	case "Heading":
+		level := 1
+		if l, ok := config["level"].(float64); ok {
+			level = int(l)
+		}
+		if level < 1 || level > 6 {
+			return nil, fmt.Errorf("heading level %d of atom %s is not between 1 and 6", level, atom.ID)
+		}
+		el.Tag = fmt.Sprintf("h%d", level)
+		content()
+	case "Link":
+		el.Tag = "a"
+		attr("href", "href")
+		attr("target", "target")
+		attr("aria-label", "ariaLabel")
+		content()
+	case "Button":
+		el.Tag = "button"
+		attr("type", "type")
+		attr("data-action", "dataAction")
+		attr("data-target", "dataTarget")
+		disabled()
+		content()
+	case "Input":
+		el.Tag = "input"
+		attr("type", "type")
+		attr("name", "name")
+		attr("placeholder", "placeholder")
+		attr("aria-label", "ariaLabel")
+		disabled()
+	case "Text":
+		el.Tag = "span"
+		if tag, ok := str("tag"); ok {
+			if !parser.TextTags[tag] {
+				return nil, fmt.Errorf("unsupported tag %q of atom %s", tag, atom.ID)
+			}
+			el.Tag = tag
+		}
+		content()
+	default:
+		return nil, fmt.Errorf("unknown subatom type: %s", atom.Subatom)
+	}
+
+	el.Void = voidTags[el.Tag]
+	return el, nil
+}
+
+// MarkupDialect adapts PrintMarkup to a template language
+type MarkupDialect struct {
+	// EscapeText escapes text content
+	EscapeText func(text string) string
+	// EscapeAttr escapes a double-quoted attribute value
+	EscapeAttr func(value string) string
+	// BindClasses returns the attribute toggling classes, or "" when the
+	// dialect can't bind (the bindings are then left to scripts)
+	BindClasses func(bindings []ClassBinding) string
+	// BindText returns the text content showing a component prop, and
+	// BindAttr the attribute set to one. Dialects without them print the
+	// props' defaults.
+	BindText func(prop string) string
+	BindAttr func(name, prop string) string
+}
+
+// HTMLDialect prints plain HTML
+var HTMLDialect = MarkupDialect{
+	EscapeText:  html.EscapeString,
+	EscapeAttr:  html.EscapeString,
+	BindClasses: func([]ClassBinding) string { return "" },
+}
+
+// ScriptDialect prints plain HTML for pages enhanced by vanilla scripts.
+// Class bindings become data-state-<condition> attributes listing the
+// classes a script toggles while the state holds, so states must be driven
+// by their own name (Drive("scrolled", "scrolled")).
+var ScriptDialect = MarkupDialect{
+	EscapeText: html.EscapeString,
+	EscapeAttr: html.EscapeString,
+	BindClasses: func(bindings []ClassBinding) string {
+		var states []string
+		classes := make(map[string][]string)
+		for _, binding := range bindings {
+			if classes[binding.Condition] == nil {
+				states = append(states, binding.Condition)
+			}
+			classes[binding.Condition] = append(classes[binding.Condition], binding.Class)
+		}
+
+		var attrs []string
+		for _, state := range states {
+			attrs = append(attrs, fmt.Sprintf(`data-state-%s="%s"`, state, strings.Join(classes[state], " ")))
+		}
+		return strings.Join(attrs, " ")
+	},
+}
+
+// PrintMarkup prints an element tree, indenting every level by two spaces
+// from indent
+func PrintMarkup(el *Element, indent int, dialect MarkupDialect) string {
+	var b strings.Builder
+	printElement(&b, el, indent, dialect)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func printElement(b *strings.Builder, el *Element, indent int, dialect MarkupDialect) {
+	if el.Tag == "" {
+		for _, child := range el.Children {
+			printElement(b, child, indent, dialect)
+		}
+		return
+	}
+
+	pad := strings.Repeat("  ", indent)
+
+	var attrs []string
+	if len(el.Classes) > 0 {
+		attrs = append(attrs, fmt.Sprintf(`class="%s"`, dialect.EscapeAttr(strings.Join(el.Classes, " "))))
+	}
+	if len(el.Bindings) > 0 {
+		if binding := dialect.BindClasses(el.Bindings); binding != "" {
+			attrs = append(attrs, binding)
+		}
+	}
+	for _, attr := range el.Attrs {
+		switch {
+		case attr.Boolean:
+			attrs = append(attrs, attr.Name)
+		case attr.Prop != "" && dialect.BindAttr != nil:
+			attrs = append(attrs, dialect.BindAttr(attr.Name, attr.Prop))
+		default:
+			attrs = append(attrs, fmt.Sprintf(`%s="%s"`, attr.Name, dialect.EscapeAttr(attr.Value)))
+		}
+	}
+
+	open := el.Tag
+	if len(attrs) > 0 {
+		open += " " + strings.Join(attrs, " ")
+	}
+	text := dialect.EscapeText(el.Text)
+	if el.TextProp != "" && dialect.BindText != nil {
+		text = dialect.BindText(el.TextProp)
+	}
+
+	switch {
+	case el.Void:
+		fmt.Fprintf(b, "%s<%s />\n", pad, open)
+	case len(el.Children) == 0:
+		fmt.Fprintf(b, "%s<%s>%s</%s>\n", pad, open, text, el.Tag)
+	default:
+		fmt.Fprintf(b, "%s<%s>\n", pad, open)
+		if text != "" {
+			fmt.Fprintf(b, "%s  %s\n", pad, text)
+		}
+		for _, child := range el.Children {
+			printElement(b, child, indent+1, dialect)
+		}
+		fmt.Fprintf(b, "%s</%s>\n", pad, el.Tag)
+	}
+}
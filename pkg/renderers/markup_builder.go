@@ -0,0 +1,359 @@
+package renderers
+
+import (
+	"fmt"
+
+	"atomic-generator/pkg/models"
+)
+
+// MarkupBuilder builds the element trees of atoms, molecules and organisms
+// for template based targets. It walks the structure exactly like the React
+// renderers do, but styles always compile to classes: every rule, state
+// (as a pseudo-class or a modifier class) and breakpoint override ends up
+// in the builder's stylesheet.
+type MarkupBuilder struct {
+	ctx    *Context
+	styles *StyleEmitter
+	// slide binds the visibility of a carousel's slides, see BindSlides
+	slide func(slide *Element, index int)
+	// variant drives the states of bound variant props, see DriveVariants
+	variant func(prop, variant string) string
+}
+
+func NewMarkupBuilder(ctx *Context) *MarkupBuilder {
+	options := ctx.Options
+	options.StyleMode = StyleModeCSSModules
+	return &MarkupBuilder{
+		ctx:    ctx,
+		styles: NewStyleEmitter(NewContext(ctx.Registry, options)),
+	}
+}
+
+// Drive binds a custom state to an expression of the target's template
+// language, see StyleEmitter.Drive
+func (mb *MarkupBuilder) Drive(state, expression string) {
+	mb.styles.Drive(state, expression)
+}
+
+// DriveVariants sets the expression driving the state of each value of a
+// variant prop bound to a component prop. By default the state holds while
+// the prop equals the value (tone === 'dark').
+func (mb *MarkupBuilder) DriveVariants(condition func(prop, variant string) string) {
+	mb.variant = condition
+}
+
+// BindSlides makes carousels call bind with each of their slides (the list
+// molecules, then the repeated items) and its index, so the target can tie
+// the slide's visibility to the current slide
+func (mb *MarkupBuilder) BindSlides(bind func(slide *Element, index int)) {
+	mb.slide = bind
+}
+
+// StyleSheet returns the CSS collected while building, or ""
+func (mb *MarkupBuilder) StyleSheet() string {
+	return mb.styles.StyleSheet()
+}
+
+// styled applies a styled element's classes to el
+func styled(el *Element, styles *StyledElement) *Element {
+	el.Classes = styles.Classes()
+	el.Bindings = styles.Bindings()
+	if styles.class != "" {
+		el.Style = &ElementStyle{Class: styles.class, StyleSpec: styles.spec}
+	}
+	return el
+}
+
+// Atom builds the element of an atom
+func (mb *MarkupBuilder) Atom(atom *models.Atom) (*Element, error) {
+	return mb.atom(atom, nil, nil)
+}
+
+func (mb *MarkupBuilder) atom(atom *models.Atom, inherited []InheritedState, hiddenAt []string) (*Element, error) {
+	el, err := subatomElement(atom)
+	if err != nil {
+		return nil, err
+	}
+
+	return styled(el, mb.styles.Element(StyleSpec{
+		Name:      atom.ID,
+		Styles:    atom.Styles,
+		States:    atom.States,
+		Inherited: inherited,
+		Disabled:  isDisabled(atom),
+		HiddenAt:  hiddenAt,
+	})), nil
+}
+
+// Molecule builds the element of a molecule component and its atoms. The
+// content its props set is bound to them over their defaults (see
+// Element.TextProp and Attr.Prop) and its variants become states driven by
+// the props (see DriveVariants).
+func (mb *MarkupBuilder) Molecule(molecule *models.Molecule) (*Element, error) {
+	return mb.MoleculeVariant(molecule, nil)
+}
+
+// MoleculeVariant builds a molecule component with the props in variants
+// fixed to a value, for targets that can't drive states and branch on
+// their variant props instead
+func (mb *MarkupBuilder) MoleculeVariant(molecule *models.Molecule, variants map[string]string) (*Element, error) {
+	return mb.molecule(molecule, nil, templateScope(MoleculeProps(mb.ctx.Registry, molecule), variants))
+}
+
+// molecule builds a molecule with the prop values in scope: the props of
+// the component being built, or the values an instance passes
+func (mb *MarkupBuilder) molecule(molecule *models.Molecule, inherited []InheritedState, scope propScope) (*Element, error) {
+	mr := NewMoleculeRenderer(molecule, mb.ctx)
+
+	variants := mr.atomVariants()
+	if len(variants) == 0 && len(molecule.Molecules) == 0 {
+		return &Element{Tag: "div"}, nil
+	}
+
+	// Register the wrapper first so atoms and nested molecules can inherit
+	// its states
+	slots := append(molecule.Atoms.Keys(), molecule.Molecules.Keys()...)
+	ownStates, nestedStates := SplitStates(molecule.States, slots)
+	spec := StyleSpec{
+		Name:      molecule.ID,
+		Styles:    molecule.Styles,
+		States:    ownStates,
+		Inherited: inherited,
+		Anchor:    len(nestedStates) > 0,
+	}
+	if molecule.Type != "" {
+		spec.ClassName = fmt.Sprintf("molecule-%s", molecule.Type)
+	}
+	applyVariants(&spec, molecule.Props, scope, mb.styles, mb.variant)
+	wrapper := mb.styles.Element(spec)
+	el := styled(&Element{Tag: mr.getSemanticTag()}, wrapper)
+
+	for _, variant := range variants {
+		atom := mb.ctx.Registry.Atom(variant.atomID)
+		if atom == nil {
+			return nil, fmt.Errorf("atom %s (key: %s) not found in molecule %s", variant.atomID, variant.key, molecule.ID)
+		}
+
+		content := scope.atomContent(molecule.Props, variant.key)
+		child, err := mb.atom(atomWithContent(atom, content), wrapper.Inherit(nestedStates[variant.key]), variant.hiddenAt)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering atom %s in molecule %s: %w", variant.atomID, molecule.ID, err)
+		}
+		bindContent(child, content)
+		el.Children = append(el.Children, child)
+	}
+
+	for _, ref := range molecule.Molecules {
+		nested := mb.ctx.Registry.Molecule(ref.ID)
+		if nested == nil {
+			return nil, fmt.Errorf("molecule %s (key: %s) not found in molecule %s", ref.ID, ref.Key, molecule.ID)
+		}
+		values := scope.moleculeValues(molecule.Props, ref)
+		child, err := mb.molecule(nested, wrapper.Inherit(nestedStates[ref.Key]), instanceScope(nested.Props, values))
+		if err != nil {
+			return nil, fmt.Errorf("error rendering molecule %s (key: %s) in molecule %s: %w", ref.ID, ref.Key, molecule.ID, err)
+		}
+		el.Children = append(el.Children, child)
+	}
+
+	return el, nil
+}
+
+// Organism builds the element of an organism component with its atoms,
+// molecules, sections and layout zones, its props bound like Molecule's
+func (mb *MarkupBuilder) Organism(organism *models.Organism) (*Element, error) {
+	return mb.OrganismVariant(organism, nil)
+}
+
+// OrganismVariant builds an organism component with the props in variants
+// fixed to a value, see MoleculeVariant
+func (mb *MarkupBuilder) OrganismVariant(organism *models.Organism, variants map[string]string) (*Element, error) {
+	return mb.organism(organism, templateScope(OrganismProps(mb.ctx.Registry, organism), variants))
+}
+
+// OrganismInstance builds an organism inline with the prop values an
+// instance (a layout section) passes
+func (mb *MarkupBuilder) OrganismInstance(organism *models.Organism, props map[string]string) (*Element, error) {
+	values := make(map[string]propValue)
+	for name, value := range props {
+		values[name] = propValue{value: value}
+	}
+	return mb.organism(organism, instanceScope(organism.Props, values))
+}
+
+func (mb *MarkupBuilder) organism(organism *models.Organism, scope propScope) (*Element, error) {
+	or := NewOrganismRenderer(organism, mb.ctx)
+
+	// Register the wrapper first so children can inherit its states
+	slots := append(organism.Atoms.Keys(), organism.Molecules.Refs.Keys()...)
+	ownStates, nestedStates := SplitStates(organism.States, slots)
+	spec := StyleSpec{
+		Name:   organism.ID,
+		Styles: organism.Styles,
+		States: ownStates,
+		Anchor: len(nestedStates) > 0,
+	}
+	if organism.Type != "" {
+		spec.ClassName = fmt.Sprintf("organism-%s", organism.Type)
+	}
+	applyVariants(&spec, organism.Props, scope, mb.styles, mb.variant)
+	wrapper := mb.styles.Element(spec)
+
+	var children []*Element
+	slides := 0
+	slide := func(el *Element) {
+		if mb.slide != nil && IsCarousel(organism) {
+			mb.slide(el, slides)
+			slides++
+		}
+	}
+
+	for _, ref := range organism.Atoms {
+		atom := mb.ctx.Registry.Atom(ref.ID)
+		if atom == nil {
+			return nil, fmt.Errorf("atom %s (key: %s) not found in organism %s", ref.ID, ref.Key, organism.ID)
+		}
+		content := scope.atomContent(organism.Props, ref.Key)
+		child, err := mb.atom(atomWithContent(atom, content), wrapper.Inherit(nestedStates[ref.Key]), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering atom %s (key: %s): %w", ref.ID, ref.Key, err)
+		}
+		bindContent(child, content)
+		children = append(children, child)
+	}
+
+	for i, ref := range organism.Molecules.Refs {
+		molecule := mb.ctx.Registry.Molecule(ref.ID)
+		if molecule == nil {
+			return nil, fmt.Errorf("molecule %s (key: %s) not found in organism %s", ref.ID, ref.Key, organism.ID)
+		}
+		var inherited []InheritedState
+		if !organism.Molecules.List {
+			inherited = wrapper.Inherit(nestedStates[ref.Key])
+		}
+		values := scope.moleculeValues(organism.Props, ref)
+		child, err := mb.molecule(molecule, inherited, instanceScope(molecule.Props, values))
+		if err != nil {
+			if organism.Molecules.List {
+				return nil, fmt.Errorf("error rendering molecule %s at index %d: %w", ref.ID, i, err)
+			}
+			return nil, fmt.Errorf("error rendering molecule %s (key: %s): %w", ref.ID, ref.Key, err)
+		}
+		if organism.Molecules.List {
+			slide(child)
+		}
+		children = append(children, child)
+	}
+
+	// Templates have no loops here: a repeat renders one instance per item
+	if repeat := organism.Repeat; repeat != nil {
+		molecule := mb.ctx.Registry.Molecule(repeat.Molecule)
+		if molecule == nil {
+			return nil, fmt.Errorf("repeated molecule %s not found", repeat.Molecule)
+		}
+		for i, item := range repeat.Items.Values {
+			values := make(map[string]propValue)
+			for name, value := range item {
+				values[name] = propValue{value: value}
+			}
+			child, err := mb.molecule(molecule, nil, instanceScope(molecule.Props, values))
+			if err != nil {
+				return nil, fmt.Errorf("error rendering repeated molecule %s at item %d: %w", molecule.ID, i, err)
+			}
+			slide(child)
+			children = append(children, child)
+		}
+	}
+
+	for _, section := range organism.Sections {
+		el := &Element{Tag: "div", Classes: []string{fmt.Sprintf("section-%s", section.Type)}}
+		for _, molID := range section.Molecules {
+			molecule := mb.ctx.Registry.Molecule(molID)
+			if molecule == nil {
+				return nil, fmt.Errorf("molecule %s not found in organism %s", molID, organism.ID)
+			}
+			child, err := mb.molecule(molecule, nil, instanceScope(molecule.Props, nil))
+			if err != nil {
+				return nil, fmt.Errorf("error rendering molecule %s in section: %w", molID, err)
+			}
+			el.Children = append(el.Children, child)
+		}
+		children = append(children, el)
+	}
+
+	// Layout zones (background, overlay, content) wrap the children like
+	// OrganismRenderer.applyLayout does
+	zone := func(name string) *Element {
+		return styled(&Element{Tag: "div"}, mb.styles.Element(StyleSpec{
+			Name:      fmt.Sprintf("%s_%s", organism.ID, name),
+			ClassName: fmt.Sprintf("layout-%s", name),
+			Styles:    or.getLayoutStyles(name),
+		}))
+	}
+	hasBackground := or.getLayoutStyles("background") != nil
+	hasOverlay := or.getLayoutStyles("overlay") != nil
+	hasContent := or.getLayoutStyles("content") != nil
+	if hasBackground || hasOverlay || hasContent {
+		var zones []*Element
+		if hasBackground {
+			zones = append(zones, zone("background"))
+		}
+		if hasOverlay {
+			zones = append(zones, zone("overlay"))
+		}
+		if hasContent {
+			content := zone("content")
+			content.Children = children
+			zones = append(zones, content)
+		} else {
+			zones = append(zones, children...)
+		}
+		children = zones
+	}
+
+	el := styled(&Element{Tag: or.getSemanticTag()}, wrapper)
+	el.Children = children
+	return el, nil
+}
+
+// Page builds the body of a page from its layout. Pages don't inline their
+// organisms: embed returns the element standing for each one, typically a
+// reference to the organism's component, given the prop values its section
+// passes.
+func (mb *MarkupBuilder) Page(layout *models.Layout, embed func(organism *models.Organism, props map[string]string) (*Element, error)) (*Element, error) {
+	page := &Element{}
+
+	for _, section := range layout.Structure {
+		// Single organism
+		if section.Organism != "" {
+			organism := mb.ctx.Registry.Organism(section.Organism)
+			if organism == nil {
+				return nil, fmt.Errorf("organism not found: %s", section.Organism)
+			}
+			el, err := embed(organism, section.Props)
+			if err != nil {
+				return nil, err
+			}
+			page.Children = append(page.Children, el)
+		}
+
+		// Multiple organisms
+		if len(section.Organisms) > 0 {
+			main := &Element{Tag: "main"}
+			for _, orgID := range section.Organisms {
+				organism := mb.ctx.Registry.Organism(orgID)
+				if organism == nil {
+					return nil, fmt.Errorf("organism not found: %s", orgID)
+				}
+				el, err := embed(organism, nil)
+				if err != nil {
+					return nil, err
+				}
+				main.Children = append(main.Children, el)
+			}
+			page.Children = append(page.Children, main)
+		}
+	}
+
+	return page, nil
+}
@@ -2,86 +2,229 @@ package renderers
 
 import (
 	"fmt"
-	"strings"
 
 	"atomic-generator/pkg/models"
-	"atomic-generator/pkg/parser"
 )
 
 // MoleculeRenderer generates React components from Molecules
 type MoleculeRenderer struct {
 	molecule  *models.Molecule
-	structure *models.AtomicStructure
-	parser    *parser.AtomicParser
-	converter *StyleConverter
+	ctx       *Context
+	styles    *StyleEmitter
+	inherited []InheritedState
+	// imports collects the atom components the molecule renders
+	imports *componentImports
+	// forward is set while rendering the component of a molecule its
+	// parents restyle
+	forward bool
+	// scope holds the values of the molecule's props
+	scope propScope
 }
 
-func NewMoleculeRenderer(molecule *models.Molecule, structure *models.AtomicStructure) *MoleculeRenderer {
+func NewMoleculeRenderer(molecule *models.Molecule, ctx *Context) *MoleculeRenderer {
 	return &MoleculeRenderer{
-		molecule:  molecule,
-		structure: structure,
-		parser:    &parser.AtomicParser{},
-		converter: NewStyleConverter(),
+		molecule: molecule,
+		ctx:      ctx,
+		styles:   NewStyleEmitter(ctx),
+		imports:  newComponentImports(ctx, "molecules"),
 	}
 }
 
+// withStyles makes the molecule emit its styles into a parent's stylesheet
+func (mr *MoleculeRenderer) withStyles(styles *StyleEmitter) *MoleculeRenderer {
+	mr.styles = styles
+	return mr
+}
+
+// withScope renders the molecule with the given prop values
+func (mr *MoleculeRenderer) withScope(scope propScope) *MoleculeRenderer {
+	mr.scope = scope
+	return mr
+}
+
+// withInherited applies states inherited from the molecule's organism
+func (mr *MoleculeRenderer) withInherited(inherited []InheritedState) *MoleculeRenderer {
+	mr.inherited = inherited
+	return mr
+}
+
+// StyleSheet returns the CSS Module of the last rendered component
+func (mr *MoleculeRenderer) StyleSheet() string {
+	return mr.styles.StyleSheet()
+}
+
+// Interactive reports whether the last rendered component tracks
+// interaction states with hooks
+func (mr *MoleculeRenderer) Interactive() bool {
+	return mr.styles.HasHooks()
+}
+
 // Render generates the JSX for a molecule - completely generic
 func (mr *MoleculeRenderer) Render() (string, error) {
-	// All molecules are rendered generically based on their atom composition
-	return mr.renderGenericMolecule()
+	el, err := mr.element()
+	if err != nil {
+		return "", err
+	}
+	return PrintJSX(el, 0), nil
 }
 
-func (mr *MoleculeRenderer) renderGenericMolecule() (string, error) {
-	var children []string
+// element builds the JSX element of the molecule. All molecules are
+// rendered generically based on their composition: their atoms, then the
+// molecules they nest, each rendered the same way.
+func (mr *MoleculeRenderer) element() (*JSXElement, error) {
+	variants := mr.atomVariants()
+	if len(variants) == 0 && len(mr.molecule.Molecules) == 0 {
+		// Empty molecule - return empty div
+		return &JSXElement{Tag: "div"}, nil
+	}
+
+	// Register the wrapper first so atoms can inherit its states (e.g. an
+	// icon changing color while the whole card is hovered)
+	slots := append(mr.molecule.Atoms.Keys(), mr.molecule.Molecules.Keys()...)
+	ownStates, nestedStates := SplitStates(mr.molecule.States, slots)
+	spec := StyleSpec{
+		Name:      mr.molecule.ID,
+		Styles:    mr.molecule.Styles,
+		States:    ownStates,
+		Inherited: mr.inherited,
+		Anchor:    len(nestedStates) > 0,
+		Forward:   mr.forward,
+	}
+	if mr.molecule.Type != "" {
+		spec.ClassName = fmt.Sprintf("molecule-%s", mr.molecule.Type)
+	}
+	applyVariants(&spec, mr.molecule.Props, mr.scope, mr.styles, nil)
+	wrapper := mr.styles.Element(spec)
+
+	// Use semantic HTML tag if it makes sense, otherwise div
+	el := &JSXElement{Tag: mr.getSemanticTag(), Attrs: wrapper.Attrs()}
 
-	// Render all atoms in the molecule
-	for atomKey, atomID := range mr.molecule.Atoms {
-		atom := mr.parser.GetAtomByID(mr.structure, atomID)
-		if atom != nil {
-			renderer := NewAtomRenderer(atom, mr.structure)
-			jsx, err := renderer.Render()
+	// Render all atoms in the molecule, in declaration order: as their
+	// imported components, or inlined
+	for _, variant := range variants {
+		atomID := variant.atomID
+		atom := mr.ctx.Registry.Atom(atomID)
+		if atom == nil {
+			return nil, fmt.Errorf("atom %s (key: %s) not found in molecule %s", atomID, variant.key, mr.molecule.ID)
+		}
+		content := mr.scope.atomContent(mr.molecule.Props, variant.key)
+		if mr.ctx.composes() {
+			inherited := wrapper.Inherit(nestedStates[variant.key])
+			el.Children = append(el.Children, mr.imports.element(mr.styles, atomID, content, inherited, variant.hiddenAt))
+		} else {
+			renderer := NewAtomRenderer(atom, mr.ctx).
+				withStyles(mr.styles).
+				withContent(content).
+				withInherited(wrapper.Inherit(nestedStates[variant.key])).
+				withHiddenAt(variant.hiddenAt)
+			child, err := renderer.element()
 			if err != nil {
-				return "", fmt.Errorf("error rendering atom %s in molecule %s: %w", atomID, mr.molecule.ID, err)
+				return nil, fmt.Errorf("error rendering atom %s in molecule %s: %w", atomID, mr.molecule.ID, err)
 			}
-			
-			// Wrap with a key for React if needed (for lists)
-			children = append(children, jsx)
-		} else {
-			// Log warning but continue - atom might be optional
-			fmt.Printf("Warning: atom %s (key: %s) not found in molecule %s\n", atomID, atomKey, mr.molecule.ID)
+			el.Children = append(el.Children, child)
 		}
 	}
 
-	if len(children) == 0 {
-		// Empty molecule - return empty div
-		return "<div></div>", nil
+	// Nested molecules, with the prop values passed to them
+	for _, ref := range mr.molecule.Molecules {
+		molecule := mr.ctx.Registry.Molecule(ref.ID)
+		if molecule == nil {
+			return nil, fmt.Errorf("molecule %s (key: %s) not found in molecule %s", ref.ID, ref.Key, mr.molecule.ID)
+		}
+		values := mr.scope.moleculeValues(mr.molecule.Props, ref)
+		inherited := wrapper.Inherit(nestedStates[ref.Key])
+		if mr.ctx.composes() {
+			el.Children = append(el.Children, mr.imports.element(mr.styles, ref.ID, values, inherited, nil))
+		} else {
+			renderer := NewMoleculeRenderer(molecule, mr.ctx).
+				withStyles(mr.styles).
+				withScope(instanceScope(molecule.Props, values)).
+				withInherited(inherited)
+			child, err := renderer.element()
+			if err != nil {
+				return nil, fmt.Errorf("error rendering molecule %s (key: %s) in molecule %s: %w", ref.ID, ref.Key, mr.molecule.ID, err)
+			}
+			el.Children = append(el.Children, child)
+		}
 	}
 
-	// Build wrapper with molecule's styles
-	var attrs []string
-	if len(mr.molecule.Styles) > 0 {
-		styleStr := mr.converter.ToInlineStyle(mr.molecule.Styles)
-		attrs = append(attrs, fmt.Sprintf("style=%s", styleStr))
-	}
+	return el, nil
+}
 
-	// Add className if specified
-	if mr.molecule.Type != "" {
-		attrs = append(attrs, fmt.Sprintf(`className="molecule-%s"`, mr.molecule.Type))
+// atomVariant is one atom rendered in a molecule slot, hidden at the
+// breakpoints where the slot shows a different atom
+type atomVariant struct {
+	key      string
+	atomID   string
+	hiddenAt []string
+}
+
+// atomVariants resolves the molecule's responsive atom sets against the
+// brand breakpoints. Every atom a slot shows at some breakpoint is rendered
+// and CSS hides it at the others, so the right one is visible from the
+// first (server-rendered) paint without measuring the viewport in JS.
+func (mr *MoleculeRenderer) atomVariants() []atomVariant {
+	var variants []atomVariant
+
+	breakpoints := mr.ctx.Registry.Breakpoints().Names()
+	if len(mr.molecule.Responsive) == 0 || len(breakpoints) == 0 {
+		for _, ref := range mr.molecule.Atoms {
+			variants = append(variants, atomVariant{key: ref.Key, atomID: ref.ID})
+		}
+		return variants
 	}
 
-	wrapperAttrs := ""
-	if len(attrs) > 0 {
-		wrapperAttrs = " " + strings.Join(attrs, " ")
+	overrides := make(map[string]models.RefMap)
+	for _, config := range mr.molecule.Responsive {
+		overrides[config.Breakpoint] = config.Atoms
 	}
 
-	childrenJSX := strings.Join(children, "\n      ")
+	// Slots in declaration order, including slots only a breakpoint fills
+	var slots []string
+	seen := make(map[string]bool)
+	addSlots := func(refs models.RefMap) {
+		for _, key := range refs.Keys() {
+			if !seen[key] {
+				seen[key] = true
+				slots = append(slots, key)
+			}
+		}
+	}
+	addSlots(mr.molecule.Atoms)
+	for _, config := range mr.molecule.Responsive {
+		addSlots(config.Atoms)
+	}
 
-	// Use semantic HTML tag if it makes sense, otherwise div
-	tag := mr.getSemanticTag()
+	for _, slot := range slots {
+		// Atoms shown in this slot, narrowest breakpoint first
+		var atomIDs []string
+		shownAt := make(map[string]map[string]bool)
+		for _, breakpoint := range breakpoints {
+			atomID, overridden := overrides[breakpoint].Get(slot)
+			if !overridden {
+				atomID, _ = mr.molecule.Atoms.Get(slot)
+			}
+			if atomID == "" {
+				continue
+			}
+			if shownAt[atomID] == nil {
+				shownAt[atomID] = make(map[string]bool)
+				atomIDs = append(atomIDs, atomID)
+			}
+			shownAt[atomID][breakpoint] = true
+		}
 
-	return fmt.Sprintf(`<%s%s>
-      %s
-    </%s>`, tag, wrapperAttrs, childrenJSX, tag), nil
+		for _, atomID := range atomIDs {
+			variant := atomVariant{key: slot, atomID: atomID}
+			for _, breakpoint := range breakpoints {
+				if !shownAt[atomID][breakpoint] {
+					variant.hiddenAt = append(variant.hiddenAt, breakpoint)
+				}
+			}
+			variants = append(variants, variant)
+		}
+	}
+	return variants
 }
 
 // getSemanticTag returns appropriate HTML tag based on molecule type
@@ -102,47 +245,33 @@ func (mr *MoleculeRenderer) getSemanticTag() string {
 // RenderAsComponent generates a full React component for the molecule
 func (mr *MoleculeRenderer) RenderAsComponent() (string, error) {
 	componentName := ToPascalCase(mr.molecule.ID)
-	
-	// Generate JSX
-	jsx, err := mr.Render()
-	if err != nil {
-		return "", err
+	mr.styles = NewStyleEmitter(mr.ctx)
+	mr.imports = newComponentImports(mr.ctx, "molecules")
+	mr.forward = mr.ctx.restyled(mr.molecule.ID)
+	mr.scope = componentScope(mr.molecule.Props)
+	props := declaredProps(mr.molecule.Props, func(name string, prop models.Prop) models.Prop {
+		return moleculeProp(mr.ctx.Registry, mr.molecule, name)
+	})
+	if mr.forward {
+		props = append(props, restyleProps...)
 	}
 
-	// Generate state management for responsive behavior
-	var stateCode string
-	var hookCode string
-	
-	if len(mr.molecule.Responsive) > 0 {
-		stateCode = `  const [breakpoint, setBreakpoint] = useState('desktop');
-
-  useEffect(() => {
-    const updateBreakpoint = () => {
-      const width = window.innerWidth;
-      if (width < 768) setBreakpoint('mobile');
-      else if (width < 1024) setBreakpoint('tablet');
-      else setBreakpoint('desktop');
-    };
-
-    updateBreakpoint();
-    window.addEventListener('resize', updateBreakpoint);
-    return () => window.removeEventListener('resize', updateBreakpoint);
-  }, []);
-
-`
-		hookCode = ", useEffect"
+	// Build the JSX
+	el, err := mr.element()
+	if err != nil {
+		return "", err
 	}
 
-	component := fmt.Sprintf(`import React, { useState%s } from 'react';
-
-const %s = () => {
+	component := fmt.Sprintf(`%s
+%s
+%s
 %s  return (
-    %s
+%s
   );
 };
 
 export default %s;
-`, hookCode, componentName, stateCode, IndentCode(jsx, 2), componentName)
+`, reactImport(mr.styles.hookImports()...)+mr.imports.statements(), mr.styles.ImportStatement(componentName), mr.ctx.componentDeclaration(componentName, props...), mr.styles.HookDeclarations(), PrintJSX(el, 2), componentName)
 
 	return component, nil
 }
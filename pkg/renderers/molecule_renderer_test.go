@@ -0,0 +1,117 @@
+package renderers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/parser"
+)
+
+// newTestContext indexes a structure written as JSON
+func newTestContext(t *testing.T, source string, options Options) *Context {
+	t.Helper()
+	var structure models.AtomicStructure
+	if err := json.Unmarshal([]byte(source), &structure); err != nil {
+		t.Fatalf("parsing structure: %v", err)
+	}
+	registry, err := parser.NewRegistry(&structure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewContext(registry, options)
+}
+
+// TestResponsiveAtomSwap checks that a molecule renders every atom its
+// slots show at some breakpoint, hidden by CSS at the others rather than
+// measured in JS, so server-rendered markup already shows the right one
+func TestResponsiveAtomSwap(t *testing.T) {
+	ctx := newTestContext(t, `{
+		"project": {"brand": {"breakpoints": {"mobile": "0px", "tablet": "768px", "desktop": "1024px"}}},
+		"atoms": {
+			"images": [
+				{"id": "logo_full", "subatom": "Image", "config": {"src": "/logo.svg", "alt": "Acme"}},
+				{"id": "logo_mark", "subatom": "Image", "config": {"src": "/mark.svg", "alt": "Acme"}}
+			],
+			"links": [{"id": "menu", "subatom": "Link", "config": {"content": "Menu", "href": "#menu"}}]
+		},
+		"molecules": [{
+			"id": "brand",
+			"atoms": {"logo": "logo_full"},
+			"responsive": [{"breakpoint": "mobile", "atoms": {"logo": "logo_mark", "menu": "menu"}}]
+		}]
+	}`, Options{StyleMode: StyleModeCSSModules})
+
+	mr := NewMoleculeRenderer(ctx.Registry.Molecule("brand"), ctx)
+	component, err := mr.RenderAsComponent()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantJSX := "    <div>\n" +
+		"      <LogoMark className={styles.logoMark} />\n" +
+		"      <LogoFull className={styles.logoFull} />\n" +
+		"      <Menu className={styles.menu} />\n" +
+		"    </div>"
+	if !strings.Contains(component, wantJSX) {
+		t.Errorf("RenderAsComponent() =\n%s\nwant the atoms of every breakpoint:\n%s", component, wantJSX)
+	}
+	if strings.Contains(component, "useState") || strings.Contains(component, "resize") {
+		t.Errorf("RenderAsComponent() =\n%s\nwant no viewport measuring", component)
+	}
+
+	wantCSS := "@media (min-width: 768px) {\n  .logoMark {\n    display: none !important;\n  }\n}\n\n" +
+		"@media (max-width: 767.98px) {\n  .logoFull {\n    display: none !important;\n  }\n}\n\n" +
+		"@media (min-width: 768px) {\n  .menu {\n    display: none !important;\n  }\n}\n"
+	if got := mr.StyleSheet(); got != wantCSS {
+		t.Errorf("StyleSheet() =\n%s\nwant\n%s", got, wantCSS)
+	}
+}
+
+const nestedStructure = `{
+	"atoms": {
+		"headings": [{"id": "title", "subatom": "Heading", "config": {"content": "Plans", "level": 3}}],
+		"buttons": [
+			{"id": "buy", "subatom": "Button", "config": {"content": "Buy"}},
+			{"id": "info", "subatom": "Button", "config": {"content": "More"}}
+		]
+	},
+	"molecules": [
+		{"id": "card", "atoms": {"title": "title"}, "molecules": {"actions": "button_group"}},
+		{"id": "button_group", "atoms": {"primary": "buy", "secondary": "info"}}
+	]
+}`
+
+func TestNestedMolecules(t *testing.T) {
+	tests := []struct {
+		composition Composition
+		want        []string
+	}{
+		{CompositionInline, []string{
+			"    <div>\n" +
+				"      <h3>Plans</h3>\n" +
+				"      <div>\n" +
+				"        <button>Buy</button>\n" +
+				"        <button>More</button>\n" +
+				"      </div>\n" +
+				"    </div>",
+		}},
+		{CompositionImport, []string{
+			"import ButtonGroup from './ButtonGroup';",
+			"    <div>\n      <Title />\n      <ButtonGroup />\n    </div>",
+		}},
+	}
+	for _, tt := range tests {
+		ctx := newTestContext(t, nestedStructure, Options{Composition: tt.composition})
+		component, err := NewMoleculeRenderer(ctx.Registry.Molecule("card"), ctx).RenderAsComponent()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.composition, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(component, want) {
+				t.Errorf("%s: RenderAsComponent() =\n%s\nwant it to contain\n%s", tt.composition, component, want)
+			}
+		}
+	}
+}
@@ -2,29 +2,54 @@ package renderers
 
 import (
 	"fmt"
-	"strings"
+	"slices"
 
 	"atomic-generator/pkg/models"
-	"atomic-generator/pkg/parser"
 )
 
 // OrganismRenderer generates React components from Organisms
 type OrganismRenderer struct {
-	organism  *models.Organism
-	structure *models.AtomicStructure
-	parser    *parser.AtomicParser
-	converter *StyleConverter
+	organism *models.Organism
+	ctx      *Context
+	styles   *StyleEmitter
+
+	// wrapper is the organism's root element; nested holds the state
+	// styles it passes down to child slots
+	wrapper *StyledElement
+	nested  map[string]map[string]map[string]interface{}
+
+	// imports collects the atom and molecule components the organism
+	// renders
+	imports *componentImports
+	// scope holds the values of the organism's props
+	scope propScope
+	// repeatData declares the items of the organism's repeat
+	repeatData string
+
+	// interactive is set when the last rendered component holds state
+	interactive bool
 }
 
-func NewOrganismRenderer(organism *models.Organism, structure *models.AtomicStructure) *OrganismRenderer {
+func NewOrganismRenderer(organism *models.Organism, ctx *Context) *OrganismRenderer {
 	return &OrganismRenderer{
-		organism:  organism,
-		structure: structure,
-		parser:    &parser.AtomicParser{},
-		converter: NewStyleConverter(),
+		organism: organism,
+		ctx:      ctx,
+		styles:   NewStyleEmitter(ctx),
+		imports:  newComponentImports(ctx, "organisms"),
 	}
 }
 
+// StyleSheet returns the CSS Module of the last rendered component
+func (or *OrganismRenderer) StyleSheet() string {
+	return or.styles.StyleSheet()
+}
+
+// Interactive reports whether the last rendered component holds state: a
+// carousel, a scroll-aware header or tracked interaction states
+func (or *OrganismRenderer) Interactive() bool {
+	return or.interactive
+}
+
 // getLayoutStyles safely extracts styles from layout field
 // Layout can contain either direct styles or nested style maps
 func (or *OrganismRenderer) getLayoutStyles(key string) map[string]interface{} {
@@ -48,36 +73,67 @@ func (or *OrganismRenderer) getLayoutStyles(key string) map[string]interface{} {
 
 // Render generates the JSX for an organism - completely generic and data-driven
 func (or *OrganismRenderer) Render() (string, error) {
-	// All organisms are rendered generically based on their composition
-	return or.renderGenericOrganism()
+	el, err := or.element()
+	if err != nil {
+		return "", err
+	}
+	return PrintJSX(el, 0), nil
 }
 
-func (or *OrganismRenderer) renderGenericOrganism() (string, error) {
-	var elements []string
+// element builds the JSX element of the organism. All organisms are
+// rendered generically based on their composition.
+func (or *OrganismRenderer) element() (*JSXElement, error) {
+	var elements []JSXNode
+
+	// Register the wrapper first so children can inherit its states (e.g. a
+	// header restyling its logo once scrolled)
+	slots := append(or.organism.Atoms.Keys(), or.organism.Molecules.Refs.Keys()...)
+	ownStates, nestedStates := SplitStates(or.organism.States, slots)
+	spec := StyleSpec{
+		Name:   or.organism.ID,
+		Styles: or.organism.Styles,
+		States: ownStates,
+		Anchor: len(nestedStates) > 0,
+	}
+	if or.organism.Type != "" {
+		spec.ClassName = fmt.Sprintf("organism-%s", or.organism.Type)
+	}
+	applyVariants(&spec, or.organism.Props, or.scope, or.styles, nil)
+	or.wrapper = or.styles.Element(spec)
+	or.nested = nestedStates
 
 	// 1. Render atoms if present
 	if len(or.organism.Atoms) > 0 {
 		atomElements, err := or.renderAtoms()
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		elements = append(elements, atomElements...)
 	}
 
 	// 2. Render molecules if present
-	if or.organism.Molecules != nil {
+	if len(or.organism.Molecules.Refs) > 0 {
 		moleculeElements, err := or.renderMolecules()
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		elements = append(elements, moleculeElements...)
 	}
 
+	// Repeat the molecule of a data-driven list once per item
+	if or.organism.Repeat != nil {
+		repeated, err := or.renderRepeat()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, repeated)
+	}
+
 	// 3. Render sections if present (for complex organisms like footers)
 	if len(or.organism.Sections) > 0 {
 		sectionElements, err := or.renderSections()
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		elements = append(elements, sectionElements...)
 	}
@@ -87,49 +143,38 @@ func (or *OrganismRenderer) renderGenericOrganism() (string, error) {
 		elements = or.applyLayout(elements)
 	}
 
-	// 5. Build wrapper with organism's styles
-	var attrs []string
-	if len(or.organism.Styles) > 0 {
-		styleStr := or.converter.ToInlineStyle(or.organism.Styles)
-		attrs = append(attrs, fmt.Sprintf("style=%s", styleStr))
-	}
-
-	// Add className based on type
-	if or.organism.Type != "" {
-		attrs = append(attrs, fmt.Sprintf(`className="organism-%s"`, or.organism.Type))
-	}
-
-	wrapperAttrs := ""
-	if len(attrs) > 0 {
-		wrapperAttrs = " " + strings.Join(attrs, " ")
-	}
-
-	// Determine semantic tag
-	tag := or.getSemanticTag()
-
-	// Join elements
-	elementsJSX := strings.Join(elements, "\n      ")
-
-	return fmt.Sprintf(`<%s%s>
-      %s
-    </%s>`, tag, wrapperAttrs, elementsJSX, tag), nil
+	// 5. Build wrapper with organism's styles and className based on type,
+	// in its semantic tag
+	return &JSXElement{
+		Tag:      or.getSemanticTag(),
+		Attrs:    or.wrapper.Attrs(),
+		Children: elements,
+	}, nil
 }
 
 // renderAtoms renders all atoms in the organism
-func (or *OrganismRenderer) renderAtoms() ([]string, error) {
-	var elements []string
-
-	for atomKey, atomID := range or.organism.Atoms {
-		atom := or.parser.GetAtomByID(or.structure, atomID)
-		if atom != nil {
-			renderer := NewAtomRenderer(atom, or.structure)
-			jsx, err := renderer.Render()
+func (or *OrganismRenderer) renderAtoms() ([]JSXNode, error) {
+	var elements []JSXNode
+
+	for _, ref := range or.organism.Atoms {
+		atom := or.ctx.Registry.Atom(ref.ID)
+		if atom == nil {
+			return nil, fmt.Errorf("atom %s (key: %s) not found in organism %s", ref.ID, ref.Key, or.organism.ID)
+		}
+		content := or.scope.atomContent(or.organism.Props, ref.Key)
+		if or.ctx.composes() {
+			inherited := or.wrapper.Inherit(or.nested[ref.Key])
+			elements = append(elements, or.imports.element(or.styles, ref.ID, content, inherited, nil))
+		} else {
+			renderer := NewAtomRenderer(atom, or.ctx).
+				withStyles(or.styles).
+				withContent(content).
+				withInherited(or.wrapper.Inherit(or.nested[ref.Key]))
+			el, err := renderer.element()
 			if err != nil {
-				return nil, fmt.Errorf("error rendering atom %s (key: %s): %w", atomID, atomKey, err)
+				return nil, fmt.Errorf("error rendering atom %s (key: %s): %w", ref.ID, ref.Key, err)
 			}
-			elements = append(elements, jsx)
-		} else {
-			fmt.Printf("Warning: atom %s (key: %s) not found in organism %s\n", atomID, atomKey, or.organism.ID)
+			elements = append(elements, el)
 		}
 	}
 
@@ -137,40 +182,38 @@ func (or *OrganismRenderer) renderAtoms() ([]string, error) {
 }
 
 // renderMolecules renders all molecules in the organism
-func (or *OrganismRenderer) renderMolecules() ([]string, error) {
-	var elements []string
-
-	// Molecules can be a map or an array
-	switch molecules := or.organism.Molecules.(type) {
-	case map[string]interface{}:
-		// Map of molecules
-		for molKey, molID := range molecules {
-			if molIDStr, ok := molID.(string); ok {
-				molecule := or.parser.GetMoleculeByID(or.structure, molIDStr)
-				if molecule != nil {
-					renderer := NewMoleculeRenderer(molecule, or.structure)
-					jsx, err := renderer.Render()
-					if err != nil {
-						return nil, fmt.Errorf("error rendering molecule %s (key: %s): %w", molIDStr, molKey, err)
-					}
-					elements = append(elements, jsx)
-				}
-			}
+func (or *OrganismRenderer) renderMolecules() ([]JSXNode, error) {
+	var elements []JSXNode
+
+	// Molecules can be a map or an array (e.g., carousel items); both keep
+	// declaration order
+	for i, ref := range or.organism.Molecules.Refs {
+		molecule := or.ctx.Registry.Molecule(ref.ID)
+		if molecule == nil {
+			return nil, fmt.Errorf("molecule %s (key: %s) not found in organism %s", ref.ID, ref.Key, or.organism.ID)
 		}
-	case []interface{}:
-		// Array of molecules (e.g., carousel items)
-		for i, molID := range molecules {
-			if molIDStr, ok := molID.(string); ok {
-				molecule := or.parser.GetMoleculeByID(or.structure, molIDStr)
-				if molecule != nil {
-					renderer := NewMoleculeRenderer(molecule, or.structure)
-					jsx, err := renderer.Render()
-					if err != nil {
-						return nil, fmt.Errorf("error rendering molecule %s at index %d: %w", molIDStr, i, err)
-					}
-					elements = append(elements, jsx)
+		values := or.scope.moleculeValues(or.organism.Props, ref)
+		if or.ctx.composes() {
+			var inherited []InheritedState
+			if !or.organism.Molecules.List {
+				inherited = or.wrapper.Inherit(or.nested[ref.Key])
+			}
+			elements = append(elements, or.imports.element(or.styles, ref.ID, values, inherited, nil))
+		} else {
+			renderer := NewMoleculeRenderer(molecule, or.ctx).
+				withStyles(or.styles).
+				withScope(instanceScope(molecule.Props, values))
+			if !or.organism.Molecules.List {
+				renderer.withInherited(or.wrapper.Inherit(or.nested[ref.Key]))
+			}
+			el, err := renderer.element()
+			if err != nil {
+				if or.organism.Molecules.List {
+					return nil, fmt.Errorf("error rendering molecule %s at index %d: %w", ref.ID, i, err)
 				}
+				return nil, fmt.Errorf("error rendering molecule %s (key: %s): %w", ref.ID, ref.Key, err)
 			}
+			elements = append(elements, el)
 		}
 	}
 
@@ -178,38 +221,45 @@ func (or *OrganismRenderer) renderMolecules() ([]string, error) {
 }
 
 // renderSections renders organism sections (used in complex organisms like footers)
-func (or *OrganismRenderer) renderSections() ([]string, error) {
-	var sections []string
+func (or *OrganismRenderer) renderSections() ([]JSXNode, error) {
+	var sections []JSXNode
 
 	for _, section := range or.organism.Sections {
-		var sectionMolecules []string
+		el := &JSXElement{
+			Tag:   "div",
+			Attrs: []JSXAttr{{Name: "className", Value: fmt.Sprintf("section-%s", section.Type)}},
+		}
 
 		for _, molID := range section.Molecules {
-			molecule := or.parser.GetMoleculeByID(or.structure, molID)
-			if molecule != nil {
-				renderer := NewMoleculeRenderer(molecule, or.structure)
-				jsx, err := renderer.Render()
+			molecule := or.ctx.Registry.Molecule(molID)
+			if molecule == nil {
+				return nil, fmt.Errorf("molecule %s not found in organism %s", molID, or.organism.ID)
+			}
+			if or.ctx.composes() {
+				el.Children = append(el.Children, or.imports.element(or.styles, molID, nil, nil, nil))
+			} else {
+				renderer := NewMoleculeRenderer(molecule, or.ctx).
+					withStyles(or.styles).
+					withScope(instanceScope(molecule.Props, nil))
+				child, err := renderer.element()
 				if err != nil {
 					return nil, fmt.Errorf("error rendering molecule %s in section: %w", molID, err)
 				}
-				sectionMolecules = append(sectionMolecules, jsx)
+				el.Children = append(el.Children, child)
 			}
 		}
 
-		sectionJSX := strings.Join(sectionMolecules, "\n        ")
-		sections = append(sections, fmt.Sprintf(`<div className="section-%s">
-        %s
-      </div>`, section.Type, sectionJSX))
+		sections = append(sections, el)
 	}
 
 	return sections, nil
 }
 
 // applyLayout wraps elements according to layout specification
-func (or *OrganismRenderer) applyLayout(elements []string) []string {
+func (or *OrganismRenderer) applyLayout(elements []JSXNode) []JSXNode {
 	// If layout specifies zones (like background, overlay, content), wrap accordingly
 	// This is a simplified version - can be extended based on layout structure
-	
+
 	// For now, just wrap elements in layout containers if layout keys suggest it
 	if len(or.organism.Layout) > 0 {
 		// Check for common layout patterns
@@ -219,27 +269,21 @@ func (or *OrganismRenderer) applyLayout(elements []string) []string {
 
 		if hasBackground || hasOverlay || hasContent {
 			// This is a complex layout (like hero) - wrap elements
-			var wrapped []string
+			var wrapped []JSXNode
 
 			// Background layer
 			if hasBackground {
-				bgStyles := or.converter.ToInlineStyle(or.getLayoutStyles("background"))
-				wrapped = append(wrapped, fmt.Sprintf(`<div style=%s className="layout-background"></div>`, bgStyles))
+				wrapped = append(wrapped, &JSXElement{Tag: "div", Attrs: or.layoutAttrs("background")})
 			}
 
 			// Overlay layer
 			if hasOverlay {
-				overlayStyles := or.converter.ToInlineStyle(or.getLayoutStyles("overlay"))
-				wrapped = append(wrapped, fmt.Sprintf(`<div style=%s className="layout-overlay"></div>`, overlayStyles))
+				wrapped = append(wrapped, &JSXElement{Tag: "div", Attrs: or.layoutAttrs("overlay")})
 			}
 
 			// Content layer with elements
 			if hasContent {
-				contentStyles := or.converter.ToInlineStyle(or.getLayoutStyles("content"))
-				contentJSX := strings.Join(elements, "\n          ")
-				wrapped = append(wrapped, fmt.Sprintf(`<div style=%s className="layout-content">
-          %s
-        </div>`, contentStyles, contentJSX))
+				wrapped = append(wrapped, &JSXElement{Tag: "div", Attrs: or.layoutAttrs("content"), Children: elements})
 			} else {
 				// No content wrapper, just add elements
 				wrapped = append(wrapped, elements...)
@@ -253,6 +297,15 @@ func (or *OrganismRenderer) applyLayout(elements []string) []string {
 	return elements
 }
 
+// layoutAttrs returns the attributes of a layout zone wrapper
+func (or *OrganismRenderer) layoutAttrs(zone string) []JSXAttr {
+	return or.styles.Attrs(StyleSpec{
+		Name:      fmt.Sprintf("%s_%s", or.organism.ID, zone),
+		ClassName: fmt.Sprintf("layout-%s", zone),
+		Styles:    or.getLayoutStyles(zone),
+	})
+}
+
 // getSemanticTag returns appropriate HTML tag based on organism type
 func (or *OrganismRenderer) getSemanticTag() string {
 	// Map common types to semantic HTML
@@ -274,12 +327,49 @@ func (or *OrganismRenderer) getSemanticTag() string {
 	}
 }
 
+// IsScrollAware reports whether an organism is a header reacting to the
+// page scroll (config.scrollBehavior), which drives its "scrolled" state
+func IsScrollAware(organism *models.Organism) bool {
+	return organism.Type == "site_header" && organism.Config["scrollBehavior"] != nil
+}
+
+// IsCarousel reports whether an organism has carousel behavior
+func IsCarousel(organism *models.Organism) bool {
+	return organism.Behavior != nil && organism.Behavior.Type == "carousel"
+}
+
+// CarouselSlides returns the number of slides of a carousel: the molecules
+// of its list (keyed molecules aren't slides) and its repeated items
+func CarouselSlides(organism *models.Organism) int {
+	slides := 0
+	if organism.Molecules.List {
+		slides = len(organism.Molecules.Refs)
+	}
+	if organism.Repeat != nil {
+		slides += len(organism.Repeat.Items.Values)
+	}
+	return slides
+}
+
 // RenderAsComponent generates a full React component for the organism
 func (or *OrganismRenderer) RenderAsComponent() (string, error) {
 	componentName := ToPascalCase(or.organism.ID)
-	
-	// Generate JSX
-	jsx, err := or.Render()
+	or.styles = NewStyleEmitter(or.ctx)
+	or.imports = newComponentImports(or.ctx, "organisms")
+	or.scope = componentScope(or.organism.Props)
+	or.repeatData = ""
+	props := declaredProps(or.organism.Props, func(name string, prop models.Prop) models.Prop {
+		return resolveProp(or.ctx.Registry, or.organism.Atoms, or.organism.Molecules.Refs, name, prop)
+	})
+
+	// Header scroll behavior drives the "scrolled" state
+	scrollAware := IsScrollAware(or.organism)
+	if scrollAware {
+		or.styles.Drive("scrolled", "scrolled")
+	}
+
+	// Build the JSX
+	el, err := or.element()
 	if err != nil {
 		return "", err
 	}
@@ -289,15 +379,10 @@ func (or *OrganismRenderer) RenderAsComponent() (string, error) {
 	var stateCode string
 	var effectCode string
 
-	imports = append(imports, "useState")
-
 	// Add state for carousel
-	if or.organism.Behavior != nil && or.organism.Behavior.Type == "carousel" {
-		imports = append(imports, "useEffect")
-		moleculeCount := 0
-		if moleculeList, ok := or.organism.Molecules.([]interface{}); ok {
-			moleculeCount = len(moleculeList)
-		}
+	if IsCarousel(or.organism) {
+		imports = append(imports, "useState", "useEffect")
+		moleculeCount := CarouselSlides(or.organism)
 		
 		stateCode = `  const [currentSlide, setCurrentSlide] = useState(0);
 `
@@ -318,8 +403,8 @@ func (or *OrganismRenderer) RenderAsComponent() (string, error) {
 	}
 
 	// Add state for header scroll behavior
-	if or.organism.Type == "site_header" && or.organism.Config["scrollBehavior"] != nil {
-		imports = append(imports, "useEffect")
+	if scrollAware {
+		imports = append(imports, "useState", "useEffect")
 		stateCode += `  const [scrolled, setScrolled] = useState(false);
 `
 		effectCode += `
@@ -333,18 +418,25 @@ func (or *OrganismRenderer) RenderAsComponent() (string, error) {
 `
 	}
 
-	importStr := strings.Join(imports, ", ")
-
-	component := fmt.Sprintf(`import React, { %s } from 'react';
+	// Hooks in first-use order, each once
+	hooks := or.styles.hookImports()
+	for _, hook := range imports {
+		if !slices.Contains(hooks, hook) {
+			hooks = append(hooks, hook)
+		}
+	}
+	or.interactive = len(hooks) > 0
 
-const %s = () => {
-%s%s  return (
-    %s
+	component := fmt.Sprintf(`%s
+%s
+%s
+%s%s%s  return (
+%s
   );
 };
 
 export default %s;
-`, importStr, componentName, stateCode, effectCode, IndentCode(jsx, 2), componentName)
+`, reactImport(hooks...)+or.imports.statements(), or.styles.ImportStatement(componentName), or.repeatData+or.ctx.componentDeclaration(componentName, props...), or.styles.HookDeclarations(), stateCode, effectCode, PrintJSX(el, 2), componentName)
 
 	return component, nil
 }
@@ -10,205 +10,234 @@ import (
 
 // PageRenderer generates a complete React page from layout definition
 type PageRenderer struct {
-	page      *models.Page
-	layout    *models.Layout
-	structure *models.AtomicStructure
-	parser    *parser.AtomicParser
+	page   *models.Page
+	layout *models.Layout
+	ctx    *Context
 }
 
-func NewPageRenderer(page *models.Page, layout *models.Layout, structure *models.AtomicStructure) *PageRenderer {
+func NewPageRenderer(page *models.Page, layout *models.Layout, ctx *Context) *PageRenderer {
 	return &PageRenderer{
-		page:      page,
-		layout:    layout,
-		structure: structure,
-		parser:    &parser.AtomicParser{},
+		page:   page,
+		layout: layout,
+		ctx:    ctx,
 	}
 }
 
 // Render generates the complete page component
 func (pr *PageRenderer) Render() (string, error) {
+	return pr.render(false)
+}
+
+// render generates the page component, optionally exporting its route
+func (pr *PageRenderer) render(withRoute bool) (string, error) {
 	var imports []string
-	var sections []string
+	// The page renders its metadata, then the sections of its layout
+	root := &JSXElement{Children: []JSXNode{&JSXElement{Tag: "PageMetadata"}}}
 
-	// Track which components we need to import
-	componentImports := make(map[string]bool)
+	// Track which components (by ID) we need to import, in first-use order
+	var componentImports []string
+	imported := make(map[string]bool)
 
 	// Process each layout section
 	for _, layoutSection := range pr.layout.Structure {
-		sectionJSX, componentNames, err := pr.renderLayoutSection(layoutSection)
+		sectionNodes, componentIDs, err := pr.renderLayoutSection(layoutSection)
 		if err != nil {
 			return "", err
 		}
 
-		// Add all component names to imports (componentNames can be multiple)
-		for _, name := range componentNames {
-			if name != "" {
-				componentImports[name] = true
+		// Add all component IDs to imports (a section can use several)
+		for _, id := range componentIDs {
+			if id != "" && !imported[id] {
+				imported[id] = true
+				componentImports = append(componentImports, id)
 			}
 		}
 
-		sections = append(sections, sectionJSX)
+		root.Children = append(root.Children, sectionNodes...)
+	}
+
+	imports = append(imports, "import { Helmet } from 'react-helmet-async';")
+	if pr.ctx.Options.Lang == LangTS {
+		types := "PageMeta"
+		if withRoute {
+			types = "AppRoute, PageMeta"
+		}
+		imports = append(imports, fmt.Sprintf("import type { %s } from '../types/routing';", types))
 	}
 
 	// Build imports - one per component with correct paths
-	for componentName := range componentImports {
+	for _, componentID := range componentImports {
 		// Determine component type (organism, molecule, or atom)
-		componentType := pr.getComponentType(componentName)
+		componentType := componentDir(pr.ctx.Registry, componentID)
+		componentName := ToPascalCase(componentID)
 		
 		// Pages are in src/pages/, components in src/components/
 		importPath := fmt.Sprintf("../components/%s/%s", componentType, componentName)
 		imports = append(imports, fmt.Sprintf("import %s from '%s';", componentName, importPath))
 	}
 
-	importsStr := ""
-	if len(imports) > 0 {
-		importsStr = strings.Join(imports, "\n") + "\n\n"
-	}
-
 	// Build page component
 	componentName := ToPascalCase(pr.page.ID)
-	sectionsJSX := strings.Join(sections, "\n    ")
 
 	// Generate metadata component
 	metaComponent := pr.generateMetadata()
 
 	component := fmt.Sprintf(`import React from 'react';
-%s%s
+%s
 
-const %s = () => {
+%s
+%s
   return (
-    <>
-      %s
-    </>
+%s
   );
 };
 
 export default %s;
-`, importsStr, metaComponent, componentName, sectionsJSX, componentName)
+`, strings.Join(imports, "\n"), metaComponent, pr.ctx.componentDeclaration(componentName), PrintJSX(root, 2), componentName)
+
+	if withRoute {
+		component += "\n" + pr.generateRoute()
+	}
 
 	return component, nil
 }
 
-func (pr *PageRenderer) renderLayoutSection(section models.LayoutSection) (string, []string, error) {
-	var jsx string
-	var componentNames []string
+func (pr *PageRenderer) renderLayoutSection(section models.LayoutSection) ([]JSXNode, []string, error) {
+	var nodes []JSXNode
+	var componentIDs []string
 
 	// Single organism
 	if section.Organism != "" {
-		organism := pr.parser.GetOrganismByID(pr.structure, section.Organism)
+		organism := pr.ctx.Registry.Organism(section.Organism)
 		if organism == nil {
-			return "", nil, fmt.Errorf("organism not found: %s", section.Organism)
+			return nil, nil, fmt.Errorf("organism not found: %s", section.Organism)
 		}
 
-		componentName := ToPascalCase(organism.ID)
-		componentNames = append(componentNames, componentName)
-		jsx = fmt.Sprintf("<%s />", componentName)
+		componentIDs = append(componentIDs, organism.ID)
+		var attrs []JSXAttr
+		for _, prop := range SortedKeys(section.Props) {
+			attrs = append(attrs, JSXAttr{Name: prop, Value: section.Props[prop]})
+		}
+		nodes = append(nodes, &JSXElement{Tag: ToPascalCase(organism.ID), Attrs: attrs})
 	}
 
 	// Multiple organisms
 	if len(section.Organisms) > 0 {
-		var organismComponents []string
+		main := &JSXElement{Tag: "main"}
 
 		for _, orgID := range section.Organisms {
-			organism := pr.parser.GetOrganismByID(pr.structure, orgID)
-			if organism != nil {
-				compName := ToPascalCase(organism.ID)
-				organismComponents = append(organismComponents, fmt.Sprintf("<%s />", compName))
-				componentNames = append(componentNames, compName)
+			organism := pr.ctx.Registry.Organism(orgID)
+			if organism == nil {
+				return nil, nil, fmt.Errorf("organism not found: %s", orgID)
 			}
+			main.Children = append(main.Children, &JSXElement{Tag: ToPascalCase(organism.ID)})
+			componentIDs = append(componentIDs, organism.ID)
 		}
 
-		jsx = fmt.Sprintf(`<main>
-      %s
-    </main>`, strings.Join(organismComponents, "\n      "))
+		nodes = append(nodes, main)
 	}
 
-	return jsx, componentNames, nil
+	return nodes, componentIDs, nil
 }
 
-// getComponentType determines if a component is an organism, molecule, or atom
-func (pr *PageRenderer) getComponentType(componentName string) string {
-	// Convert PascalCase component name back to ID format (snake_case)
-	// MainHeader -> main_header
-	componentID := ""
-	for i, r := range componentName {
-		if i > 0 && r >= 'A' && r <= 'Z' {
-			componentID += "_"
-		}
-		componentID += string(r)
-	}
-	componentID = strings.ToLower(componentID)
-	
-	// Check if it's an organism
-	for _, organism := range pr.structure.Organisms {
-		if organism.ID == componentID {
-			return "organisms"
-		}
-	}
-	
-	// Check if it's a molecule
-	for _, molecule := range pr.structure.Molecules {
-		if molecule.ID == componentID {
-			return "molecules"
-		}
-	}
-	
-	// Default to atoms (though pages typically use organisms)
-	return "atoms"
+// metadataFields lists the page metadata rendered into the <head> as
+// <meta> tags, with the attribute naming each one
+var metadataFields = []struct {
+	key   string
+	attr  string
+	value string
+}{
+	{"description", "name", "description"},
+	{"keywords", "name", "keywords"},
+	{"ogImage", "property", "og:image"},
+	{"language", "http-equiv", "content-language"},
 }
 
-func (pr *PageRenderer) generateMetadata() string {
-	// Generate Helmet or react-helmet-async metadata
-	var metaTags []string
-
-	metaTags = append(metaTags, fmt.Sprintf(`<title>%s</title>`, pr.page.Title))
-
-	if desc, ok := pr.page.Meta["description"]; ok {
-		metaTags = append(metaTags, fmt.Sprintf(`<meta name="description" content="%s" />`, desc))
-	}
+// MetaTag is a <meta> tag of a page's <head>: Attr="Name" content="Content"
+type MetaTag struct {
+	// Key is the Page.Meta key the tag comes from
+	Key     string
+	Attr    string
+	Name    string
+	Content string
+}
 
-	if keywords, ok := pr.page.Meta["keywords"]; ok {
-		metaTags = append(metaTags, fmt.Sprintf(`<meta name="keywords" content="%s" />`, keywords))
+// PageMetaTags returns the <meta> tags of a page, in a stable order
+func PageMetaTags(page *models.Page) []MetaTag {
+	var tags []MetaTag
+	for _, field := range metadataFields {
+		if value, ok := page.Meta[field.key]; ok {
+			tags = append(tags, MetaTag{Key: field.key, Attr: field.attr, Name: field.value, Content: value})
+		}
 	}
+	return tags
+}
 
-	if ogImage, ok := pr.page.Meta["ogImage"]; ok {
-		metaTags = append(metaTags, fmt.Sprintf(`<meta property="og:image" content="%s" />`, ogImage))
+// generateMetadata emits the page's metadata object and the Helmet
+// component rendering it (react-helmet-async)
+func (pr *PageRenderer) generateMetadata() string {
+	entries := []string{fmt.Sprintf("title: %s,", JSString(pr.page.Title))}
+	helmet := &JSXElement{Tag: "Helmet", Children: []JSXNode{
+		&JSXElement{Tag: "title", Children: []JSXNode{JSXExpr("metadata.title")}},
+	}}
+
+	for _, tag := range PageMetaTags(pr.page) {
+		entries = append(entries, fmt.Sprintf("%s: %s,", tag.Key, JSString(tag.Content)))
+		meta := ElementJSX(&Element{Tag: "meta", Attrs: []Attr{{Name: tag.Attr, Value: tag.Name}}})
+		meta.Attrs = append(meta.Attrs, JSXAttr{Name: "content", Expr: "metadata." + tag.Key})
+		helmet.Children = append(helmet.Children, meta)
 	}
 
-	if lang, ok := pr.page.Meta["language"]; ok {
-		metaTags = append(metaTags, fmt.Sprintf(`<meta httpEquiv="content-language" content="%s" />`, lang))
+	declaration := "const metadata = {"
+	if pr.ctx.Options.Lang == LangTS {
+		declaration = "const metadata: PageMeta = {"
 	}
 
-	metaTagsStr := strings.Join(metaTags, "\n        ")
-
-	return fmt.Sprintf(`import { Helmet } from 'react-helmet-async';
+	return fmt.Sprintf(`%s
+  %s
+};
 
 const PageMetadata = () => (
-  <Helmet>
-    %s
-  </Helmet>
+%s
 );
-`, metaTagsStr)
+`, declaration, strings.Join(entries, "\n  "), PrintJSX(helmet, 1))
 }
 
 // RenderWithRouter generates the page component with React Router integration
 func (pr *PageRenderer) RenderWithRouter() (string, error) {
-	component, err := pr.Render()
-	if err != nil {
-		return "", err
+	return pr.render(true)
+}
+
+// PageRoute returns the full route of a page, prefixed with the base route
+// of the clump it belongs to
+func PageRoute(registry *parser.Registry, page *models.Page) string {
+	route := page.Route
+	if clump := registry.ClumpForPage(page.ID); clump != nil {
+		route = strings.TrimRight(clump.BaseRoute, "/") + "/" + strings.TrimLeft(route, "/")
+	}
+	if !strings.HasPrefix(route, "/") {
+		route = "/" + route
+	}
+	if len(route) > 1 {
+		route = strings.TrimRight(route, "/")
 	}
+	return route
+}
 
-	// Wrap with route information
-	route := pr.page.Route
+// generateRoute exports the route configuration of the page
+func (pr *PageRenderer) generateRoute() string {
 	componentName := ToPascalCase(pr.page.ID)
 
-	routeConfig := fmt.Sprintf(`// Route configuration for %s
-export const %sRoute = {
-  path: '%s',
+	declaration := fmt.Sprintf("export const %sRoute = {", componentName)
+	if pr.ctx.Options.Lang == LangTS {
+		declaration = fmt.Sprintf("export const %sRoute: AppRoute = {", componentName)
+	}
+
+	return fmt.Sprintf(`// Route configuration for %s
+%s
+  path: %s,
   component: %s,
-  title: '%s'
+  title: %s
 };
-`, componentName, componentName, route, componentName, pr.page.Title)
-
-	return component + "\n\n" + routeConfig, nil
+`, componentName, declaration, JSString(PageRoute(pr.ctx.Registry, pr.page)), componentName, JSString(pr.page.Title))
 }
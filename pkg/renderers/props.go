@@ -0,0 +1,367 @@
+package renderers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/parser"
+)
+
+// contentKeys maps content prop types to the atom config key holding the
+// content. Atom components name their content props after the type.
+var contentKeys = map[string]string{
+	models.PropText: "content",
+	models.PropHref: "href",
+	models.PropSrc:  "src",
+}
+
+// propValue is the value of a prop where a component is rendered: a static
+// string, or a JS expression such as a prop of the enclosing component
+type propValue struct {
+	value string
+	expr  string
+}
+
+// jsxAttr returns the attribute passing the value as the named prop
+func (v propValue) jsxAttr(name string) JSXAttr {
+	if v.expr != "" {
+		return JSXAttr{Name: name, Expr: v.expr}
+	}
+	return JSXAttr{Name: name, Value: v.value}
+}
+
+// propScope maps the props of the component being rendered to their values.
+// Props without value render their defaults.
+type propScope map[string]propValue
+
+// componentScope is the scope of a component rendering its own props, each
+// bound to its parameter
+func componentScope(props map[string]models.Prop) propScope {
+	scope := make(propScope)
+	for name := range props {
+		scope[name] = propValue{expr: name}
+	}
+	return scope
+}
+
+// templateScope is the scope of a template component: each prop is bound
+// to its parameter over its resolved default, which targets print where
+// they can't bind. The props in fixed take that static value instead.
+func templateScope(props []TemplateProp, fixed map[string]string) propScope {
+	scope := make(propScope)
+	for _, prop := range props {
+		if value, ok := fixed[prop.Name]; ok {
+			scope[prop.Name] = propValue{value: value}
+		} else {
+			scope[prop.Name] = propValue{value: prop.Default, expr: prop.Name}
+		}
+	}
+	return scope
+}
+
+// instanceScope is the scope of a component rendered inline with the
+// values an instance passes, falling back to the props' declared defaults
+func instanceScope(props map[string]models.Prop, values map[string]propValue) propScope {
+	scope := make(propScope)
+	for name, prop := range props {
+		if value, ok := values[name]; ok {
+			scope[name] = value
+		} else if prop.Default != "" {
+			scope[name] = propValue{value: prop.Default}
+		}
+	}
+	return scope
+}
+
+// sortedProps returns prop names in lexical order
+func sortedProps(props map[string]models.Prop) []string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// atomContent returns the content the props in scope set on the atom in
+// slot, keyed by content type
+func (s propScope) atomContent(props map[string]models.Prop, slot string) map[string]propValue {
+	content := make(map[string]propValue)
+	for name, prop := range props {
+		if value, ok := s[name]; ok && prop.Slot == slot && contentKeys[prop.Type] != "" {
+			content[prop.Type] = value
+		}
+	}
+	return content
+}
+
+// moleculeValues returns the prop values passed to the molecule in slot:
+// the instance's own values, overridden by the props in scope bound to the
+// slot
+func (s propScope) moleculeValues(props map[string]models.Prop, ref models.Ref) map[string]propValue {
+	values := make(map[string]propValue)
+	for name, value := range ref.Props {
+		values[name] = propValue{value: value}
+	}
+	if ref.Key == "" {
+		return values
+	}
+	for name, prop := range props {
+		if value, ok := s[name]; ok && prop.Slot == ref.Key {
+			values[prop.ChildProp(name)] = value
+		}
+	}
+	return values
+}
+
+// valueAttrs returns the attributes passing values as props, sorted by name
+func valueAttrs(values map[string]propValue) []JSXAttr {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var attrs []JSXAttr
+	for _, name := range names {
+		attrs = append(attrs, values[name].jsxAttr(name))
+	}
+	return attrs
+}
+
+// applyVariants applies the variant props in scope to the spec of a
+// component's root element. A static variant merges its styles; a variant
+// set by an expression becomes a custom state per value, driven by
+// condition, or by comparing the expression to the value when it is nil.
+func applyVariants(spec *StyleSpec, props map[string]models.Prop, scope propScope, styles *StyleEmitter, condition func(expr, variant string) string) {
+	for _, name := range sortedProps(props) {
+		prop := props[name]
+		value, ok := scope[name]
+		if prop.Type != models.PropVariant || prop.Slot != "" || !ok {
+			continue
+		}
+
+		if value.expr == "" {
+			merged := make(map[string]interface{})
+			for property, style := range spec.Styles {
+				merged[property] = style
+			}
+			for property, style := range prop.Variants[value.value] {
+				merged[property] = style
+			}
+			spec.Styles = merged
+			continue
+		}
+
+		states := make(map[string]map[string]interface{})
+		for state, stateStyles := range spec.States {
+			states[state] = stateStyles
+		}
+		for _, variant := range SortedKeys(prop.Variants) {
+			state := name + "-" + variant
+			states[state] = prop.Variants[variant]
+			if condition != nil {
+				styles.Drive(state, condition(value.expr, variant))
+			} else {
+				styles.Drive(state, fmt.Sprintf("%s === %s", value.expr, JSString(variant)))
+			}
+		}
+		spec.States = states
+	}
+}
+
+// atomWithContent returns a copy of an atom whose content (text, href or
+// src) is replaced by the values in content: the static ones, and the
+// defaults of those bound to an expression
+func atomWithContent(atom *models.Atom, content map[string]propValue) *models.Atom {
+	if len(content) == 0 {
+		return atom
+	}
+	copied := *atom
+	copied.Config = make(map[string]interface{}, len(atom.Config))
+	for key, value := range atom.Config {
+		copied.Config[key] = value
+	}
+	for kind, value := range content {
+		if value.expr == "" || value.value != "" {
+			copied.Config[contentKeys[kind]] = value.value
+		}
+	}
+	return &copied
+}
+
+// bindContent binds the content of an atom's element to the expressions
+// in content, see Element.TextProp and Attr.Prop
+func bindContent(el *Element, content map[string]propValue) {
+	for _, kind := range []string{models.PropText, models.PropHref, models.PropSrc} {
+		value, ok := content[kind]
+		if !ok || value.expr == "" {
+			continue
+		}
+		if kind == models.PropText {
+			el.TextProp = value.expr
+			continue
+		}
+
+		bound := false
+		for i := range el.Attrs {
+			if el.Attrs[i].Name == kind {
+				el.Attrs[i].Prop = value.expr
+				bound = true
+			}
+		}
+		if !bound {
+			el.Attrs = append(el.Attrs, Attr{Name: kind, Value: value.value, Prop: value.expr})
+		}
+	}
+}
+
+// applyContent binds the content of an atom's element to the expressions
+// in content (static values are applied with atomWithContent)
+func applyContent(el *JSXElement, content map[string]propValue) {
+	for kind, value := range content {
+		if value.expr == "" {
+			continue
+		}
+		if kind == models.PropText {
+			el.Children = []JSXNode{JSXExpr(value.expr)}
+			continue
+		}
+
+		replaced := false
+		for i, attr := range el.Attrs {
+			if attr.Name == kind {
+				el.Attrs[i] = value.jsxAttr(kind)
+				replaced = true
+			}
+		}
+		if !replaced {
+			el.Attrs = append(el.Attrs, value.jsxAttr(kind))
+		}
+	}
+}
+
+// atomContentDefault returns the content an atom renders without props
+func atomContentDefault(atom *models.Atom, kind string) string {
+	if atom == nil {
+		return ""
+	}
+	value, _ := atom.Config[contentKeys[kind]].(string)
+	return value
+}
+
+// declaredProps returns the parameters of a molecule or organism component
+// declaring props. resolve returns a prop as it applies to what it sets:
+// its default falls back to the content of the atom it sets, and a prop
+// passed on to a molecule takes that molecule's prop default and variants.
+func declaredProps(props map[string]models.Prop, resolve func(name string, prop models.Prop) models.Prop) []componentProp {
+	var params []componentProp
+	for _, name := range sortedProps(props) {
+		prop := resolve(name, props[name])
+		param := componentProp{name: name, tsType: "string"}
+		if prop.Default != "" {
+			param.defaultValue = JSString(prop.Default)
+		}
+
+		if prop.Type == models.PropVariant && len(prop.Variants) > 0 {
+			var variants []string
+			for _, variant := range SortedKeys(prop.Variants) {
+				variants = append(variants, JSString(variant))
+			}
+			param.tsType = strings.Join(variants, " | ")
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// resolveProp resolves a prop against the slots of its component. A prop
+// without default defaults to the content of the atom it sets; a prop
+// passed on to a molecule defaults to the value the instance passes, then to
+// the molecule's own prop, whose variants it takes.
+func resolveProp(registry *parser.Registry, atoms, molecules models.RefMap, name string, prop models.Prop) models.Prop {
+	if prop.Slot == "" {
+		return prop
+	}
+	if atomID, isAtom := atoms.Get(prop.Slot); isAtom {
+		if prop.Default == "" {
+			prop.Default = atomContentDefault(registry.Atom(atomID), prop.Type)
+		}
+		return prop
+	}
+	for _, ref := range molecules {
+		molecule := registry.Molecule(ref.ID)
+		if ref.Key != prop.Slot || molecule == nil {
+			continue
+		}
+		child := moleculeProp(registry, molecule, prop.ChildProp(name))
+		if prop.Default == "" {
+			prop.Default = ref.Props[prop.ChildProp(name)]
+		}
+		if prop.Default == "" {
+			prop.Default = child.Default
+		}
+		prop.Variants = child.Variants
+	}
+	return prop
+}
+
+// TemplateProp is a prop of a template component, resolved like the
+// parameters of the React components
+type TemplateProp struct {
+	Name    string
+	Type    string
+	Default string
+	// Variants are the values of a variant prop, sorted
+	Variants []string
+}
+
+// MoleculeProps returns the props of a molecule's template component,
+// sorted by name
+func MoleculeProps(registry *parser.Registry, molecule *models.Molecule) []TemplateProp {
+	return templateProps(molecule.Props, func(name string, prop models.Prop) models.Prop {
+		return moleculeProp(registry, molecule, name)
+	})
+}
+
+// OrganismProps returns the props of an organism's template component,
+// sorted by name
+func OrganismProps(registry *parser.Registry, organism *models.Organism) []TemplateProp {
+	return templateProps(organism.Props, func(name string, prop models.Prop) models.Prop {
+		return resolveProp(registry, organism.Atoms, organism.Molecules.Refs, name, prop)
+	})
+}
+
+func templateProps(props map[string]models.Prop, resolve func(name string, prop models.Prop) models.Prop) []TemplateProp {
+	var params []TemplateProp
+	for _, name := range sortedProps(props) {
+		prop := resolve(name, props[name])
+		param := TemplateProp{Name: name, Type: prop.Type, Default: prop.Default}
+		if prop.Type == models.PropVariant {
+			param.Variants = SortedKeys(prop.Variants)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// moleculeProp resolves a prop of a molecule
+func moleculeProp(registry *parser.Registry, molecule *models.Molecule, name string) models.Prop {
+	return resolveProp(registry, molecule.Atoms, molecule.Molecules, name, molecule.Props[name])
+}
+
+// atomContentProps returns the parameters of an atom component whose
+// content parents set, defaulting to the atom's own content
+func atomContentProps(atom *models.Atom, kinds []string) []componentProp {
+	var params []componentProp
+	for _, kind := range kinds {
+		param := componentProp{name: kind, tsType: "string"}
+		if value := atomContentDefault(atom, kind); value != "" {
+			param.defaultValue = JSString(value)
+		}
+		params = append(params, param)
+	}
+	return params
+}
@@ -0,0 +1,137 @@
+package renderers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"atomic-generator/pkg/models"
+)
+
+// repeatItem is the name a repeat's map callback binds each item to
+const repeatItem = "item"
+
+// repeatKey returns the item field a repeat keys its items by: its own key
+// field, or the position keys the generator adds
+func repeatKey(repeat *models.Repeat) string {
+	if repeat.Key != "" {
+		return repeat.Key
+	}
+	return "key"
+}
+
+// repeatList returns the name of the constant holding a repeat's items,
+// e.g. valueCardItems
+func repeatList(repeat *models.Repeat) string {
+	name := ToPascalCase(repeat.Molecule)
+	if name == "" {
+		return "items"
+	}
+	return strings.ToLower(name[:1]) + name[1:] + "Items"
+}
+
+// repeatProps returns the props of the molecule the items set, in lexical
+// order. Inlined molecules bind all their props, as every item carries them.
+func repeatProps(repeat *models.Repeat, molecule *models.Molecule, inline bool) []string {
+	if inline {
+		return sortedProps(molecule.Props)
+	}
+	set := make(map[string]bool)
+	for _, item := range repeat.Items.Values {
+		for name := range item {
+			if _, isProp := molecule.Props[name]; isProp {
+				set[name] = true
+			}
+		}
+	}
+	return SortedKeys(set)
+}
+
+// repeatData returns the declaration of the constant holding a repeat's
+// items. Items inlined into the organism carry every prop, missing ones
+// taking the molecule's defaults; items passed to the molecule's component
+// leave them to it.
+func (c *Context) repeatData(repeat *models.Repeat, molecule *models.Molecule, inline bool) string {
+	key := repeatKey(repeat)
+
+	var items []string
+	for i, item := range repeat.Items.Values {
+		fields := make(map[string]string, len(item)+1)
+		for name, value := range item {
+			fields[name] = value
+		}
+		if repeat.Key == "" {
+			fields[key] = fmt.Sprintf("%s-%d", repeat.Molecule, i+1)
+		}
+		if inline {
+			for name := range molecule.Props {
+				if _, set := fields[name]; !set {
+					fields[name] = moleculeProp(c.Registry, molecule, name).Default
+				}
+			}
+		}
+
+		// The key comes first, then the props in lexical order
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			if name != key {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		entries := []string{fmt.Sprintf("%s: %s", key, JSString(fields[key]))}
+		for _, name := range names {
+			entries = append(entries, fmt.Sprintf("%s: %s", name, JSString(fields[name])))
+		}
+		items = append(items, "{ "+strings.Join(entries, ", ")+" }")
+	}
+
+	// TypeScript types items passed to the component by its props
+	var itemType string
+	declaration := repeatList(repeat)
+	if c.Options.Lang == LangTS && !inline {
+		name := ToPascalCase(molecule.ID) + "Item"
+		itemType = fmt.Sprintf("type %s = React.ComponentProps<typeof %s>", name, ToPascalCase(molecule.ID))
+		if _, isProp := molecule.Props[key]; !isProp {
+			itemType += fmt.Sprintf(" & { %s: string }", key)
+		}
+		itemType += ";\n\n"
+		declaration += ": " + name + "[]"
+	}
+	return fmt.Sprintf("%sconst %s = [%s];\n\n", itemType, declaration, strings.Join(items, ", "))
+}
+
+// renderRepeat renders the organism's repeated molecule once per item,
+// keyed by the item's key
+func (or *OrganismRenderer) renderRepeat() (JSXNode, error) {
+	repeat := or.organism.Repeat
+	molecule := or.ctx.Registry.Molecule(repeat.Molecule)
+	if molecule == nil {
+		return nil, fmt.Errorf("repeated molecule %s not found", repeat.Molecule)
+	}
+
+	inline := !or.ctx.composes()
+	or.repeatData = or.ctx.repeatData(repeat, molecule, inline)
+
+	values := make(map[string]propValue)
+	for _, name := range repeatProps(repeat, molecule, inline) {
+		values[name] = propValue{expr: repeatItem + "." + name}
+	}
+	key := JSXAttr{Name: "key", Expr: repeatItem + "." + repeatKey(repeat)}
+
+	var el *JSXElement
+	if inline {
+		renderer := NewMoleculeRenderer(molecule, or.ctx).
+			withStyles(or.styles).
+			withScope(values)
+		var err error
+		if el, err = renderer.element(); err != nil {
+			return nil, fmt.Errorf("error rendering repeated molecule %s: %w", molecule.ID, err)
+		}
+	} else {
+		el = or.imports.element(or.styles, molecule.ID, values, nil, nil)
+	}
+	el.Attrs = append([]JSXAttr{key}, el.Attrs...)
+
+	return &JSXMap{List: repeatList(repeat), Item: repeatItem, Body: el}, nil
+}
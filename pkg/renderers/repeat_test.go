@@ -0,0 +1,59 @@
+package renderers
+
+import (
+	"strings"
+	"testing"
+)
+
+const repeatStructure = `{
+	"atoms": {"text": [{"id": "value_title", "subatom": "Text", "config": {"content": "Value"}}]},
+	"molecules": [{
+		"id": "value_card",
+		"atoms": {"title": "value_title"},
+		"props": {"title": {"type": "text", "slot": "title"}}
+	}],
+	"organisms": [
+		{"id": "values", "repeat": {"molecule": "value_card", "items": [{"title": "Quality"}, {"title": "Speed"}]}},
+		{"id": "posts", "repeat": {"molecule": "value_card", "key": "slug", "items": [
+			{"slug": "launch", "title": "Launch"},
+			{"slug": "roadmap", "title": "Roadmap"}
+		]}}
+	]
+}`
+
+func TestRepeatRendersItemsMap(t *testing.T) {
+	tests := []struct {
+		organism    string
+		composition Composition
+		want        []string
+	}{
+		{"values", CompositionInline, []string{
+			"const valueCardItems = [{ key: 'value_card-1', title: 'Quality' }, { key: 'value_card-2', title: 'Speed' }];",
+			"{valueCardItems.map((item) => (\n" +
+				"        <div key={item.key}>\n" +
+				"          <span>{item.title}</span>\n" +
+				"        </div>\n" +
+				"      ))}",
+		}},
+		{"values", CompositionImport, []string{
+			"import ValueCard from '../molecules/ValueCard';",
+			"{valueCardItems.map((item) => (\n        <ValueCard key={item.key} title={item.title} />\n      ))}",
+		}},
+		{"posts", CompositionImport, []string{
+			"const valueCardItems = [{ slug: 'launch', title: 'Launch' }, { slug: 'roadmap', title: 'Roadmap' }];",
+			"<ValueCard key={item.slug} title={item.title} />",
+		}},
+	}
+	for _, tt := range tests {
+		ctx := newTestContext(t, repeatStructure, Options{Composition: tt.composition})
+		component, err := NewOrganismRenderer(ctx.Registry.Organism(tt.organism), ctx).RenderAsComponent()
+		if err != nil {
+			t.Fatalf("%s (%s): %v", tt.organism, tt.composition, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(component, want) {
+				t.Errorf("%s (%s) =\n%s\nwant it to contain\n%s", tt.organism, tt.composition, component, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,702 @@
+package renderers
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"atomic-generator/pkg/parser"
+)
+
+// StyleSpec describes the styles of a single element
+type StyleSpec struct {
+	// Name identifies the element inside its component (usually the atom,
+	// molecule or organism ID) and becomes its CSS Module class name
+	Name string
+	// ClassName is an optional static class kept next to the module class
+	ClassName string
+	Styles    map[string]interface{}
+	// States maps state names (hover, focus, active, disabled,
+	// focus-visible, or a custom state driven by the component) to the
+	// styles applied while the element is in that state
+	States map[string]map[string]interface{}
+	// Inherited holds styles applied while an ancestor is in a state
+	Inherited []InheritedState
+	// Disabled marks an element rendered with the disabled attribute, so
+	// its disabled state applies in inline mode too
+	Disabled bool
+	// Anchor gives the element a CSS Module class even without styles of
+	// its own, so children can inherit its states
+	Anchor bool
+	// Responsive maps brand breakpoint names to style overrides. Element
+	// fills it from styles.responsive.
+	Responsive map[string]map[string]interface{}
+	// HiddenAt lists the breakpoints at which the element is hidden, e.g.
+	// the desktop logo of a molecule that swaps logos on mobile
+	HiddenAt []string
+	// Forward merges the component's className and style props into the
+	// element, the root of a component its parents restyle
+	Forward bool
+}
+
+// InheritedState restyles a child element while its parent is in a state,
+// e.g. a molecule's hover state changing the color of one of its atoms
+type InheritedState struct {
+	// Selector is the parent's CSS selector for the state (CSS Modules mode)
+	Selector string
+	// Condition is a JS expression true while the parent is in the state
+	// (inline mode)
+	Condition string
+	Styles    map[string]interface{}
+}
+
+// pseudoStates maps the interaction states we support to CSS pseudo-classes,
+// in the order they are applied (later states win)
+var pseudoStates = []struct {
+	name   string
+	pseudo string
+}{
+	{"hover", ":hover"},
+	{"focus", ":focus"},
+	{"focus-within", ":focus-within"},
+	{"focus-visible", ":focus-visible"},
+	{"active", ":active"},
+	{"disabled", ":disabled"},
+}
+
+// stateAliases accepts camelCase state names from JSON
+var stateAliases = map[string]string{
+	"focusWithin":  "focus-within",
+	"focusVisible": "focus-visible",
+}
+
+func canonicalState(state string) string {
+	if alias, ok := stateAliases[state]; ok {
+		return alias
+	}
+	return state
+}
+
+func pseudoClass(state string) (string, bool) {
+	for _, ps := range pseudoStates {
+		if ps.name == state {
+			return ps.pseudo, true
+		}
+	}
+	return "", false
+}
+
+// orderedStates returns state names with pseudo states first (in
+// precedence order) and custom states after them, sorted
+func orderedStates(states map[string]map[string]interface{}) []string {
+	byName := make(map[string]string)
+	for name := range states {
+		byName[canonicalState(name)] = name
+	}
+
+	var ordered []string
+	for _, ps := range pseudoStates {
+		if original, ok := byName[ps.name]; ok {
+			ordered = append(ordered, original)
+			delete(byName, ps.name)
+		}
+	}
+
+	var custom []string
+	for _, original := range byName {
+		custom = append(custom, original)
+	}
+	sort.Strings(custom)
+	return append(ordered, custom...)
+}
+
+// SplitStates separates the states of a molecule or organism into the
+// styles of the element itself and nested styles for its children. A state
+// entry whose key is one of the child slots and whose value is a style map
+// restyles that child: {"hover": {"opacity": 0.9, "icon": {"color": "red"}}}
+func SplitStates(states map[string]map[string]interface{}, slots []string) (map[string]map[string]interface{}, map[string]map[string]map[string]interface{}) {
+	isSlot := make(map[string]bool)
+	for _, slot := range slots {
+		isSlot[slot] = true
+	}
+
+	own := make(map[string]map[string]interface{})
+	nested := make(map[string]map[string]map[string]interface{})
+	for state, styles := range states {
+		// Keep every state on the element, even one that only restyles
+		// children, so the element still tracks it
+		own[state] = make(map[string]interface{})
+		for prop, value := range styles {
+			childStyles, isMap := value.(map[string]interface{})
+			if isMap && isSlot[prop] {
+				if nested[prop] == nil {
+					nested[prop] = make(map[string]map[string]interface{})
+				}
+				nested[prop][state] = childStyles
+				continue
+			}
+			own[state][prop] = value
+		}
+	}
+	return own, nested
+}
+
+// splitResponsive separates styles.responsive from the regular styles
+func splitResponsive(styles map[string]interface{}) (map[string]interface{}, map[string]map[string]interface{}) {
+	raw, ok := styles["responsive"].(map[string]interface{})
+	if !ok {
+		return styles, nil
+	}
+
+	base := make(map[string]interface{}, len(styles))
+	for prop, value := range styles {
+		if prop != "responsive" {
+			base[prop] = value
+		}
+	}
+	responsive := make(map[string]map[string]interface{})
+	for breakpoint, value := range raw {
+		if overrides, ok := value.(map[string]interface{}); ok && len(overrides) > 0 {
+			responsive[breakpoint] = overrides
+		}
+	}
+	return base, responsive
+}
+
+// cssRule is one rule of a component's CSS Module
+type cssRule struct {
+	selector string
+	styles   map[string]interface{}
+	// media is the media query wrapping the rule, if any
+	media string
+}
+
+// StyleEmitter is the one path through which every renderer attaches styles
+// to an element. In inline mode it returns a style={{ ... }} attribute, plus
+// the state hooks and event handlers that merge state styles in; in CSS
+// Modules mode it writes rules (including :hover and friends) to the
+// component's stylesheet and returns className={styles.x}. One emitter is
+// shared by a component and all the children inlined into it.
+type StyleEmitter struct {
+	mode        StyleMode
+	lang        Lang
+	converter   *StyleConverter
+	breakpoints parser.Breakpoints
+
+	rules   []cssRule
+	classes map[string]StyleSpec
+	hooks   []string
+	vars    map[string]bool
+
+	// drivers maps custom state names to the JS expression driving them
+	drivers map[string]string
+}
+
+func NewStyleEmitter(ctx *Context) *StyleEmitter {
+	return &StyleEmitter{
+		mode:        ctx.Options.StyleMode,
+		lang:        ctx.Options.Lang,
+		converter:   NewStyleConverter(),
+		breakpoints: ctx.Registry.Breakpoints(),
+		classes:     make(map[string]StyleSpec),
+		vars:        make(map[string]bool),
+		drivers:     make(map[string]string),
+	}
+}
+
+// Drive binds a custom state (e.g. "scrolled") to a JS expression declared
+// by the component, so elements declaring that state react to it. An empty
+// expression stops driving the state.
+func (se *StyleEmitter) Drive(state, expression string) {
+	if expression == "" {
+		delete(se.drivers, state)
+		return
+	}
+	se.drivers[state] = expression
+}
+
+// StyledElement is an element registered with the emitter
+type StyledElement struct {
+	emitter *StyleEmitter
+	spec    StyleSpec
+	attrs   []JSXAttr
+	// class is the element's CSS Module class (CSS Modules mode)
+	class string
+	// base tells whether the stylesheet selects class itself, rather than
+	// only the modifier classes derived from it
+	base bool
+	// bindings toggle the modifier classes of driven custom states
+	bindings []ClassBinding
+	// conditions maps each state to the JS expression true while the
+	// element is in it (inline mode), or to the driver of a custom state
+	conditions map[string]string
+}
+
+// Attrs returns the JSX attributes applying spec to an element
+func (se *StyleEmitter) Attrs(spec StyleSpec) []JSXAttr {
+	return se.Element(spec).Attrs()
+}
+
+// Element registers an element and computes its attributes. Use it instead
+// of Attrs when children need to inherit the element's states.
+func (se *StyleEmitter) Element(spec StyleSpec) *StyledElement {
+	spec.Styles, spec.Responsive = splitResponsive(spec.Styles)
+	el := &StyledElement{
+		emitter:    se,
+		spec:       spec,
+		conditions: make(map[string]string),
+	}
+	if se.mode == StyleModeCSSModules {
+		el.attrs = se.cssModuleAttrs(el)
+	} else {
+		el.attrs = se.inlineAttrs(el)
+	}
+	return el
+}
+
+// Attrs returns the JSX attributes of the element
+func (el *StyledElement) Attrs() []JSXAttr {
+	return el.attrs
+}
+
+// Classes returns the element's static classes: its ClassName and its
+// stylesheet class (CSS Modules mode)
+func (el *StyledElement) Classes() []string {
+	var classes []string
+	if el.spec.ClassName != "" {
+		classes = append(classes, el.spec.ClassName)
+	}
+	if el.base {
+		classes = append(classes, el.class)
+	}
+	return classes
+}
+
+// Bindings returns the modifier classes toggled by the custom states the
+// component drives (CSS Modules mode)
+func (el *StyledElement) Bindings() []ClassBinding {
+	return el.bindings
+}
+
+// Inherit turns a child's nested state styles into states the child
+// inherits from this element
+func (el *StyledElement) Inherit(states map[string]map[string]interface{}) []InheritedState {
+	var inherited []InheritedState
+	for _, state := range orderedStates(states) {
+		canonical := canonicalState(state)
+		condition := el.conditions[canonical]
+
+		var selector string
+		if pseudo, ok := pseudoClass(canonical); ok && el.class != "" {
+			selector = "." + el.class + pseudo
+		} else if _, driven := el.emitter.drivers[canonical]; driven && el.class != "" {
+			selector = "." + el.class + ToPascalCase(canonical)
+		}
+
+		if selector == "" && condition == "" {
+			continue
+		}
+		inherited = append(inherited, InheritedState{
+			Selector:  selector,
+			Condition: condition,
+			Styles:    states[state],
+		})
+	}
+	return inherited
+}
+
+func (se *StyleEmitter) hasStyles(spec StyleSpec) bool {
+	return spec.Anchor || len(spec.Styles) > 0 || len(spec.States) > 0 || len(spec.Inherited) > 0 || len(spec.Responsive) > 0 || len(spec.HiddenAt) > 0
+}
+
+func (se *StyleEmitter) cssModuleAttrs(el *StyledElement) []JSXAttr {
+	spec := el.spec
+	if se.hasStyles(spec) {
+		el.class, el.base = se.register(spec)
+	}
+
+	// Custom states driven by the component toggle a modifier class (an
+	// element without class only has undriven custom states)
+	for _, state := range orderedStates(spec.States) {
+		canonical := canonicalState(state)
+		if driver, driven := se.drivers[canonical]; driven && el.class != "" {
+			el.conditions[canonical] = driver
+			if len(spec.States[state]) > 0 {
+				modifier := el.class + ToPascalCase(canonical)
+				el.bindings = append(el.bindings, ClassBinding{Class: modifier, Condition: driver})
+			}
+		}
+	}
+
+	// An element styled only by driven states has no rule for its class
+	// itself, and CSS Modules map unknown classes to undefined
+	class := ""
+	if el.base {
+		class = el.class
+	}
+	attrs := classNameAttr(spec.ClassName, class, el.bindings, spec.Forward)
+	if spec.Forward {
+		attrs = append(attrs, JSXAttr{Name: "style", Expr: "style"})
+	}
+	return attrs
+}
+
+// classNameAttr returns the className attribute joining an element's static
+// class, its CSS Module class, the modifier classes its bindings toggle and,
+// when it forwards them, the component's className prop
+func classNameAttr(static, class string, bindings []ClassBinding, forward bool) []JSXAttr {
+	var parts []string
+	if static != "" {
+		parts = append(parts, jsTemplateEscaper.Replace(static))
+	}
+	if class != "" {
+		parts = append(parts, fmt.Sprintf("${styles.%s}", class))
+	}
+	for _, binding := range bindings {
+		parts = append(parts, fmt.Sprintf("${%s ? styles.%s : ''}", binding.Condition, binding.Class))
+	}
+	if forward {
+		parts = append(parts, "${className}")
+	}
+
+	switch {
+	case len(parts) == 0:
+		return nil
+	case len(parts) > 1 || len(bindings) > 0:
+		return []JSXAttr{{Name: "className", Expr: "`" + strings.Join(parts, " ") + "`"}}
+	case static != "":
+		return []JSXAttr{{Name: "className", Value: static}}
+	case class != "":
+		return []JSXAttr{{Name: "className", Expr: "styles." + class}}
+	default:
+		return []JSXAttr{{Name: "className", Expr: "className"}}
+	}
+}
+
+// register adds the rules for an element's class, reusing the class when the
+// same element is rendered twice and suffixing it when a different element
+// claims the name. base reports whether a rule selects the class itself.
+func (se *StyleEmitter) register(spec StyleSpec) (class string, base bool) {
+	name := toCamelCase(spec.Name)
+	if name == "" {
+		name = "element"
+	}
+
+	class = name
+	for i := 2; ; i++ {
+		existing, taken := se.classes[class]
+		if !taken {
+			break
+		}
+		if reflect.DeepEqual(existing, spec) {
+			return class, se.selectsBase(spec)
+		}
+		class = fmt.Sprintf("%s%d", name, i)
+	}
+	ruleCount := len(se.rules)
+	if len(spec.Styles) > 0 {
+		se.rules = append(se.rules, cssRule{selector: "." + class, styles: spec.Styles})
+	}
+	for _, state := range orderedStates(spec.States) {
+		canonical := canonicalState(state)
+		if len(spec.States[state]) == 0 {
+			continue
+		}
+		if pseudo, ok := pseudoClass(canonical); ok {
+			se.rules = append(se.rules, cssRule{selector: "." + class + pseudo, styles: spec.States[state]})
+		} else if _, driven := se.drivers[canonical]; driven {
+			se.rules = append(se.rules, cssRule{selector: "." + class + ToPascalCase(canonical), styles: spec.States[state]})
+		}
+	}
+	for _, inherited := range spec.Inherited {
+		if inherited.Selector != "" {
+			se.rules = append(se.rules, cssRule{selector: inherited.Selector + " ." + class, styles: inherited.Styles})
+		}
+	}
+	// Breakpoint overrides go last, narrowest first, so they win over the
+	// base rule
+	for _, breakpoint := range se.breakpoints.Names() {
+		if styles := spec.Responsive[breakpoint]; len(styles) > 0 {
+			query, _ := se.breakpoints.MediaQuery(breakpoint)
+			se.rules = append(se.rules, cssRule{selector: "." + class, styles: styles, media: query})
+		}
+	}
+	if len(spec.HiddenAt) > 0 {
+		queries := se.breakpoints.MediaQueries(spec.HiddenAt)
+		// !important so the rule also beats inline display styles
+		se.rules = append(se.rules, cssRule{
+			selector: "." + class,
+			styles:   map[string]interface{}{"display": "none !important"},
+			media:    strings.Join(queries, ", "),
+		})
+	}
+
+	if len(se.rules) == ruleCount && !spec.Anchor {
+		return "", false
+	}
+	se.classes[class] = spec
+	return class, se.selectsBase(spec)
+}
+
+// selectsBase tells whether register emits a rule for the class of spec
+// itself: everything but the modifier rules of driven states does
+func (se *StyleEmitter) selectsBase(spec StyleSpec) bool {
+	if spec.Anchor || len(spec.Styles) > 0 || len(spec.HiddenAt) > 0 {
+		return true
+	}
+	for state, styles := range spec.States {
+		if _, ok := pseudoClass(canonicalState(state)); ok && len(styles) > 0 {
+			return true
+		}
+	}
+	for _, inherited := range spec.Inherited {
+		if inherited.Selector != "" {
+			return true
+		}
+	}
+	for _, styles := range spec.Responsive {
+		if len(styles) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (se *StyleEmitter) inlineAttrs(el *StyledElement) []JSXAttr {
+	spec := el.spec
+	var attrs []JSXAttr
+
+	// Event handlers, grouped by event so states sharing one (hover and
+	// active both reset on mouse leave) end up in the same callback
+	handlers := make(map[string][]string)
+	var events []string
+	on := func(event, statement string) {
+		if _, exists := handlers[event]; !exists {
+			events = append(events, event)
+		}
+		handlers[event] = append(handlers[event], statement)
+	}
+
+	var merges []string
+	// custom tells whether a custom property is set, which React's
+	// CSSProperties type doesn't declare
+	custom := false
+	for _, state := range orderedStates(spec.States) {
+		canonical := canonicalState(state)
+		styles := spec.States[state]
+
+		var condition string
+		switch canonical {
+		case "hover", "focus", "focus-within", "focus-visible", "active":
+			variable := se.stateVar(canonical, spec.Name)
+			setter := "set" + Capitalize(variable)
+			condition = variable
+
+			switch canonical {
+			case "hover":
+				on("onMouseEnter", setter+"(true)")
+				on("onMouseLeave", setter+"(false)")
+			case "focus", "focus-within":
+				on("onFocus", setter+"(true)")
+				on("onBlur", setter+"(false)")
+			case "focus-visible":
+				on("onFocus", setter+"(event.currentTarget.matches(':focus-visible'))")
+				on("onBlur", setter+"(false)")
+			case "active":
+				on("onMouseDown", setter+"(true)")
+				on("onMouseUp", setter+"(false)")
+				on("onMouseLeave", setter+"(false)")
+			}
+		case "disabled":
+			if spec.Disabled {
+				condition = "true"
+			}
+		default:
+			condition = se.drivers[canonical]
+		}
+
+		if condition == "" {
+			continue
+		}
+		el.conditions[canonical] = condition
+		if len(styles) > 0 {
+			merges = append(merges, se.merge(condition, styles))
+			custom = custom || hasCustomProperty(styles)
+		}
+	}
+
+	for _, inherited := range spec.Inherited {
+		if inherited.Condition != "" {
+			merges = append(merges, se.merge(inherited.Condition, inherited.Styles))
+			custom = custom || hasCustomProperty(inherited.Styles)
+		}
+	}
+
+	base := spec.Styles
+	if len(spec.Responsive) > 0 || len(spec.HiddenAt) > 0 {
+		// Inline styles can't hold media queries: move the properties a
+		// breakpoint overrides to the stylesheet so its @media rules win
+		var moved map[string]interface{}
+		base, moved = splitProperties(spec.Styles, spec.Responsive)
+		el.class, el.base = se.register(StyleSpec{
+			Name:       spec.Name,
+			Styles:     moved,
+			Responsive: spec.Responsive,
+			HiddenAt:   spec.HiddenAt,
+		})
+	}
+
+	entries := []string{}
+	if body := se.converter.toObjectEntries(base); body != "" {
+		entries = append(entries, body)
+	}
+	entries = append(entries, merges...)
+	switch {
+	case spec.Forward && len(entries) == 0:
+		attrs = append(attrs, JSXAttr{Name: "style", Expr: "style"})
+	case spec.Forward:
+		// Styles passed by the parent win
+		entries = append(entries, "...style")
+		fallthrough
+	case len(entries) > 0:
+		style := "{ " + strings.Join(entries, ", ") + " }"
+		if se.lang == LangTS && (custom || hasCustomProperty(base)) {
+			style += " as React.CSSProperties"
+		}
+		attrs = append(attrs, JSXAttr{Name: "style", Expr: style})
+	}
+	attrs = append(attrs, classNameAttr(spec.ClassName, el.class, nil, spec.Forward)...)
+
+	for _, event := range events {
+		statements := handlers[event]
+		param := ""
+		for _, statement := range statements {
+			if strings.Contains(statement, "event.") {
+				param = "event"
+			}
+		}
+		if len(statements) == 1 {
+			attrs = append(attrs, JSXAttr{Name: event, Expr: fmt.Sprintf("(%s) => %s", param, statements[0])})
+		} else {
+			attrs = append(attrs, JSXAttr{Name: event, Expr: fmt.Sprintf("(%s) => { %s; }", param, strings.Join(statements, "; "))})
+		}
+	}
+
+	return attrs
+}
+
+// hasCustomProperty reports whether styles set a CSS custom property
+func hasCustomProperty(styles map[string]interface{}) bool {
+	for prop := range styles {
+		if strings.HasPrefix(prop, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitProperties separates the styles a breakpoint overrides from the rest
+func splitProperties(styles map[string]interface{}, responsive map[string]map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	rest := make(map[string]interface{})
+	overridden := make(map[string]interface{})
+	for prop, value := range styles {
+		isOverridden := false
+		for _, overrides := range responsive {
+			if _, ok := overrides[prop]; ok {
+				isOverridden = true
+				break
+			}
+		}
+		if isOverridden {
+			overridden[prop] = value
+		} else {
+			rest[prop] = value
+		}
+	}
+	return rest, overridden
+}
+
+// merge returns an object spread applying styles while condition holds
+func (se *StyleEmitter) merge(condition string, styles map[string]interface{}) string {
+	if condition == "true" {
+		// Static states (e.g. disabled) always apply
+		return se.converter.toObjectEntries(styles)
+	}
+	return fmt.Sprintf("...(%s ? { %s } : {})", condition, se.converter.toObjectEntries(styles))
+}
+
+// stateVar declares a useState hook tracking one state of one element and
+// returns its variable name
+func (se *StyleEmitter) stateVar(state, name string) string {
+	base := "is" + ToPascalCase(state) + ToPascalCase(name)
+	variable := base
+	for i := 2; se.vars[variable]; i++ {
+		variable = fmt.Sprintf("%s%d", base, i)
+	}
+	se.vars[variable] = true
+	se.hooks = append(se.hooks, fmt.Sprintf("const [%s, set%s] = useState(false);", variable, Capitalize(variable)))
+	return variable
+}
+
+// HasHooks reports whether the component must declare state hooks
+func (se *StyleEmitter) HasHooks() bool {
+	return len(se.hooks) > 0
+}
+
+// hookImports returns the React hooks the emitter's declarations use
+func (se *StyleEmitter) hookImports() []string {
+	if se.HasHooks() {
+		return []string{"useState"}
+	}
+	return nil
+}
+
+// HookDeclarations returns the useState hooks the component must declare,
+// indented for the component body
+func (se *StyleEmitter) HookDeclarations() string {
+	if len(se.hooks) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(se.hooks, "\n  ") + "\n\n"
+}
+
+// HasStyleSheet reports whether any CSS rule was registered
+func (se *StyleEmitter) HasStyleSheet() bool {
+	return len(se.rules) > 0
+}
+
+// StyleSheet returns the component's CSS Module, in registration order
+func (se *StyleEmitter) StyleSheet() string {
+	if !se.HasStyleSheet() {
+		return ""
+	}
+
+	var rules []string
+	for _, rule := range se.rules {
+		css := se.converter.ToCSSRule(rule.selector, rule.styles)
+		if rule.media != "" && rule.media != "all" {
+			css = fmt.Sprintf("@media %s {\n%s\n}", rule.media, IndentCode(css, 1))
+		}
+		rules = append(rules, css)
+	}
+	return strings.Join(rules, "\n\n") + "\n"
+}
+
+// ImportStatement returns the import line for the component's CSS Module,
+// or "" when the component has no stylesheet
+func (se *StyleEmitter) ImportStatement(componentName string) string {
+	if !se.HasStyleSheet() {
+		return ""
+	}
+	return fmt.Sprintf("import styles from './%s.module.css';\n", componentName)
+}
+
+// toCamelCase converts snake_case or kebab-case IDs to camelCase class names
+func toCamelCase(s string) string {
+	pascal := ToPascalCase(s)
+	if pascal == "" {
+		return ""
+	}
+	return strings.ToLower(pascal[:1]) + pascal[1:]
+}
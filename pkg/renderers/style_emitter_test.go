@@ -0,0 +1,119 @@
+package renderers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/parser"
+)
+
+func newTestEmitter(t *testing.T, mode StyleMode) *StyleEmitter {
+	t.Helper()
+	registry, err := parser.NewRegistry(&models.AtomicStructure{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewStyleEmitter(NewContext(registry, Options{StyleMode: mode}))
+}
+
+func TestCSSModulesDrivenStateOnly(t *testing.T) {
+	se := newTestEmitter(t, StyleModeCSSModules)
+	se.Drive("scrolled", "scrolled")
+	el := se.Element(StyleSpec{
+		Name:   "header",
+		States: map[string]map[string]interface{}{"scrolled": {"background": "white"}},
+	})
+
+	want := []JSXAttr{{Name: "className", Expr: "`${scrolled ? styles.headerScrolled : ''}`"}}
+	if got := el.Attrs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Attrs() = %v, want %v", got, want)
+	}
+	if classes := el.Classes(); len(classes) != 0 {
+		t.Errorf("Classes() = %v, want none", classes)
+	}
+	css := se.StyleSheet()
+	if !strings.Contains(css, ".headerScrolled {") || strings.Contains(css, ".header {") {
+		t.Errorf("StyleSheet() =\n%s\nwant only the .headerScrolled rule", css)
+	}
+}
+
+func TestCSSModulesDrivenStateWithBase(t *testing.T) {
+	se := newTestEmitter(t, StyleModeCSSModules)
+	se.Drive("scrolled", "scrolled")
+	el := se.Element(StyleSpec{
+		Name:   "header",
+		Styles: map[string]interface{}{"padding": "8px"},
+		States: map[string]map[string]interface{}{"scrolled": {"background": "white"}},
+	})
+
+	want := []JSXAttr{{Name: "className", Expr: "`${styles.header} ${scrolled ? styles.headerScrolled : ''}`"}}
+	if got := el.Attrs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Attrs() = %v, want %v", got, want)
+	}
+	if classes := el.Classes(); !reflect.DeepEqual(classes, []string{"header"}) {
+		t.Errorf("Classes() = %v, want [header]", classes)
+	}
+}
+
+func newBreakpointEmitter(t *testing.T, mode StyleMode) *StyleEmitter {
+	t.Helper()
+	structure := &models.AtomicStructure{}
+	structure.Project.Brand.Breakpoints = map[string]string{"mobile": "0px", "tablet": "768px", "desktop": "1024px"}
+	registry, err := parser.NewRegistry(structure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewStyleEmitter(NewContext(registry, Options{StyleMode: mode}))
+}
+
+func TestResponsiveStylesCompileToMediaRules(t *testing.T) {
+	se := newBreakpointEmitter(t, StyleModeCSSModules)
+	el := se.Element(StyleSpec{
+		Name: "title",
+		Styles: map[string]interface{}{
+			"fontSize": "32px",
+			"responsive": map[string]interface{}{
+				"desktop": map[string]interface{}{"fontSize": "48px"},
+				"mobile":  map[string]interface{}{"fontSize": "24px"},
+			},
+		},
+	})
+
+	if classes := el.Classes(); !reflect.DeepEqual(classes, []string{"title"}) {
+		t.Errorf("Classes() = %v, want [title]", classes)
+	}
+	want := ".title {\n  font-size: 32px;\n}\n\n" +
+		"@media (max-width: 767.98px) {\n  .title {\n    font-size: 24px;\n  }\n}\n\n" +
+		"@media (min-width: 1024px) {\n  .title {\n    font-size: 48px;\n  }\n}\n"
+	if got := se.StyleSheet(); got != want {
+		t.Errorf("StyleSheet() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestResponsiveStylesMoveOverriddenPropertiesOutOfInlineStyles(t *testing.T) {
+	se := newBreakpointEmitter(t, StyleModeInline)
+	el := se.Element(StyleSpec{
+		Name: "title",
+		Styles: map[string]interface{}{
+			"color":    "red",
+			"fontSize": "32px",
+			"responsive": map[string]interface{}{
+				"mobile": map[string]interface{}{"fontSize": "24px"},
+			},
+		},
+	})
+
+	want := []JSXAttr{
+		{Name: "style", Expr: "{ color: 'red' }"},
+		{Name: "className", Expr: "styles.title"},
+	}
+	if got := el.Attrs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Attrs() = %v, want %v", got, want)
+	}
+	css := se.StyleSheet()
+	if !strings.Contains(css, ".title {\n  font-size: 32px;\n}") || !strings.Contains(css, "@media (max-width: 767.98px)") {
+		t.Errorf("StyleSheet() =\n%s\nwant the font size rule and its mobile override", css)
+	}
+}
@@ -0,0 +1,368 @@
+// Package compose is the Jetpack Compose target: Kotlin sources with one
+// @Composable function per component and screen, and the brand as theme
+// objects. Only the sources are generated, to add to an Android module.
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+func init() {
+	targets.Register("compose", New)
+}
+
+// Target emits Jetpack Compose sources
+type Target struct {
+	ctx   *renderers.Context
+	views *mobile.Builder
+	// pkg is the Kotlin package of the app, e.g. barcelonaculinaryhub
+	pkg string
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	if ctx.Options.Lang != renderers.LangJS {
+		return nil, fmt.Errorf("the compose target emits Kotlin; -lang=%s doesn't apply", ctx.Options.Lang)
+	}
+	if ctx.Options.Composition == renderers.CompositionImport {
+		return nil, fmt.Errorf("the compose target always inlines children (got -composition=import)")
+	}
+	return &Target{
+		ctx:   ctx,
+		views: mobile.NewBuilder(ctx),
+		pkg:   kotlinPackage(ctx.Registry.Structure().Project.ID),
+	}, nil
+}
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9]`)
+
+// kotlinPackage turns a project ID into a package name
+func kotlinPackage(id string) string {
+	pkg := nonIdentifier.ReplaceAllString(strings.ToLower(id), "")
+	if pkg == "" || pkg[0] >= '0' && pkg[0] <= '9' {
+		pkg = "app" + pkg
+	}
+	return pkg
+}
+
+func (t *Target) Name() string {
+	return "compose"
+}
+
+// dir returns the source directory of a subpackage of ui
+func (t *Target) dir(sub string) string {
+	return "src/main/kotlin/" + t.pkg + "/ui/" + sub
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		t.dir("theme"),
+		t.dir("atoms"),
+		t.dir("molecules"),
+		t.dir("organisms"),
+		t.dir("screens"),
+	}
+}
+
+func (t *Target) NextSteps() []string {
+	return []string{
+		"Copy src/main/kotlin into the app module of an Android project using Compose",
+		"Add the dependencies listed in README.md and call a screen inside BrandTheme { } from your activity",
+		"The screens render with the brand theme",
+	}
+}
+
+// reserved are the Compose functions and theme objects components can't
+// be named after
+var reserved = map[string]bool{
+	"Alignment": true, "Arrangement": true, "Box": true, "BrandColors": true,
+	"BrandFonts": true, "BrandSpacing": true, "BrandTheme": true, "BrandTypography": true,
+	"Button": true, "Color": true, "Column": true, "Image": true, "Modifier": true,
+	"Row": true, "Spacer": true, "Surface": true, "Text": true,
+}
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	el, err := renderers.NewMarkupBuilder(t.ctx).Atom(atom)
+	if err != nil {
+		return nil, err
+	}
+	c := t.newComposable("atoms")
+	return c.file(mobile.TypeName(atom.ID, "atom", reserved), c.root(t.views.View(el))), nil
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	c := t.newComposable("molecules")
+	body, err := c.branches(renderers.MoleculeProps(t.ctx.Registry, molecule), nil, func(variants map[string]string) (*mobile.View, error) {
+		el, err := renderers.NewMarkupBuilder(t.ctx).MoleculeVariant(molecule, variants)
+		if err != nil {
+			return nil, err
+		}
+		return t.views.View(el), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.file(mobile.TypeName(molecule.ID, "molecule", reserved), body), nil
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	c := t.newComposable("organisms")
+	body, err := c.branches(renderers.OrganismProps(t.ctx.Registry, organism), nil, func(variants map[string]string) (*mobile.View, error) {
+		el, err := renderers.NewMarkupBuilder(t.ctx).OrganismVariant(organism, variants)
+		if err != nil {
+			return nil, err
+		}
+		root := t.views.View(el)
+		if root != nil && renderers.IsCarousel(organism) {
+			mobile.Carousel(root, organism.Behavior)
+		}
+		return root, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.file(mobile.TypeName(organism.ID, "organism", reserved), body), nil
+}
+
+// Page writes the page's screen, a scrolling column of the organisms of
+// its layout
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	c := t.newComposable("screens")
+	body, err := renderers.NewMarkupBuilder(t.ctx).Page(layout, func(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+		name := mobile.TypeName(organism.ID, "organism", reserved)
+		c.imports[t.pkg+".ui.organisms."+name] = true
+		return &renderers.Element{Tag: name, Attrs: renderers.PropAttrs(props), Void: true}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.use("Column", "fillMaxSize", "verticalScroll", "rememberScrollState")
+	screen := &call{
+		name:     "Column",
+		args:     []string{"modifier = " + chain("modifier", []string{".fillMaxSize()", ".verticalScroll(rememberScrollState())"})},
+		lambda:   true,
+		children: c.views(t.views.View(body).Children, mobile.Column),
+	}
+	return c.file(renderers.ToPascalCase(page.ID)+"Screen", screen), nil
+}
+
+// root converts the root view of a component, nil when it is hidden
+func (c *composable) root(v *mobile.View) *call {
+	if v == nil {
+		return nil
+	}
+	return c.view(v, noScope, "modifier")
+}
+
+// branches declares the props of a molecule or organism as parameters of
+// its function and returns its body. Variant props can't drive states: the
+// body branches on them with when, each branch built by build with the
+// variants fixed so far, the default variant last as else.
+func (c *composable) branches(props []renderers.TemplateProp, fixed map[string]string, build func(variants map[string]string) (*mobile.View, error)) (*call, error) {
+	if fixed == nil {
+		c.params = props
+	}
+	for _, prop := range props {
+		if _, isFixed := fixed[prop.Name]; isFixed || len(prop.Variants) == 0 {
+			continue
+		}
+
+		when := &call{name: "when (" + prop.Name + ")", lambda: true}
+		variants := mobile.DefaultLast(prop.Variants, prop.Default)
+		for i, variant := range variants {
+			variantsFixed := map[string]string{prop.Name: variant}
+			for name, value := range fixed {
+				variantsFixed[name] = value
+			}
+			branch, err := c.branches(props, variantsFixed, build)
+			if err != nil {
+				return nil, err
+			}
+			if branch == nil {
+				continue
+			}
+			branch.prefix = kotlinString(variant) + " -> "
+			if i == len(variants)-1 {
+				branch.prefix = "else -> "
+			}
+			when.children = append(when.children, branch)
+		}
+		return when, nil
+	}
+
+	root, err := build(fixed)
+	if err != nil {
+		return nil, err
+	}
+	// Only one branch runs: they share their state
+	c.states = make(map[string]int)
+	return c.root(root), nil
+}
+
+// composable collects what one function needs while its views are
+// converted
+type composable struct {
+	t    *Target
+	kind string
+	// imports are fully qualified names
+	imports map[string]bool
+	// declarations open the function body: state and effects
+	declarations []string
+	optIn        bool
+	uriHandler   bool
+	states       map[string]int
+	// params are the props of the function, after its modifier
+	params []renderers.TemplateProp
+}
+
+func (t *Target) newComposable(kind string) *composable {
+	return &composable{
+		t:       t,
+		kind:    kind,
+		imports: map[string]bool{"androidx.compose.runtime.Composable": true, "androidx.compose.ui.Modifier": true},
+		states:  make(map[string]int),
+	}
+}
+
+// use imports the declarations of Compose symbols
+func (c *composable) use(names ...string) {
+	for _, name := range names {
+		c.imports[symbols[name]+"."+name] = true
+	}
+}
+
+// file returns the Kotlin file of a composable function
+func (c *composable) file(name string, body *call) []targets.File {
+	var b strings.Builder
+	if body != nil {
+		body.write(&b, 1)
+	}
+
+	// Branches declare the same state
+	var declarations []string
+	for _, declaration := range c.declarations {
+		if !slices.Contains(declarations, declaration) {
+			declarations = append(declarations, declaration)
+		}
+	}
+	if c.uriHandler {
+		c.use("LocalUriHandler")
+		declarations = append([]string{"val uriHandler = LocalUriHandler.current"}, declarations...)
+	}
+	head := ""
+	if len(declarations) > 0 {
+		head = indent(strings.Join(declarations, "\n"), 1) + "\n\n"
+	}
+	if c.optIn {
+		c.use("ExperimentalFoundationApi")
+	}
+
+	var imports []string
+	for _, name := range renderers.SortedKeys(c.imports) {
+		imports = append(imports, "import "+name)
+	}
+	annotations := "@Composable"
+	if c.optIn {
+		annotations = "@OptIn(ExperimentalFoundationApi::class)\n" + annotations
+	}
+
+	content := fmt.Sprintf(`package %s.ui.%s
+
+%s
+
+%s
+fun %s(%s) {
+%s%s}
+`, c.t.pkg, c.kind, strings.Join(imports, "\n"), annotations, name, c.signature(name), head, b.String())
+	return []targets.File{{Path: fmt.Sprintf("%s/%s.kt", c.t.dir(c.kind), name), Content: content}}
+}
+
+// signature returns the parameters of the function: its required props,
+// the modifier, then its props with a default, one per line when they
+// don't fit
+func (c *composable) signature(name string) string {
+	var required, optional []string
+	for _, prop := range c.params {
+		if prop.Default == "" {
+			required = append(required, prop.Name+": String")
+		} else {
+			optional = append(optional, fmt.Sprintf("%s: String = %s", prop.Name, kotlinString(prop.Default)))
+		}
+	}
+	params := append(append(required, "modifier: Modifier = Modifier"), optional...)
+	inline := strings.Join(params, ", ")
+	if len("fun "+name+"("+inline+") {") <= 100 {
+		return inline
+	}
+	return "\n" + indent(strings.Join(params, ",\n"), 1) + ",\n"
+}
+
+// symbols maps the Compose symbols the generated code uses to their
+// package
+var symbols = map[string]string{
+	"AsyncImage":                   "coil.compose",
+	"delay":                        "kotlinx.coroutines",
+	"ExperimentalFoundationApi":    "androidx.compose.foundation",
+	"BorderStroke":                 "androidx.compose.foundation",
+	"background":                   "androidx.compose.foundation",
+	"border":                       "androidx.compose.foundation",
+	"clickable":                    "androidx.compose.foundation",
+	"rememberScrollState":          "androidx.compose.foundation",
+	"verticalScroll":               "androidx.compose.foundation",
+	"Arrangement":                  "androidx.compose.foundation.layout",
+	"Box":                          "androidx.compose.foundation.layout",
+	"Column":                       "androidx.compose.foundation.layout",
+	"PaddingValues":                "androidx.compose.foundation.layout",
+	"Row":                          "androidx.compose.foundation.layout",
+	"fillMaxHeight":                "androidx.compose.foundation.layout",
+	"fillMaxSize":                  "androidx.compose.foundation.layout",
+	"fillMaxWidth":                 "androidx.compose.foundation.layout",
+	"height":                       "androidx.compose.foundation.layout",
+	"heightIn":                     "androidx.compose.foundation.layout",
+	"offset":                       "androidx.compose.foundation.layout",
+	"padding":                      "androidx.compose.foundation.layout",
+	"width":                        "androidx.compose.foundation.layout",
+	"widthIn":                      "androidx.compose.foundation.layout",
+	"HorizontalPager":              "androidx.compose.foundation.pager",
+	"rememberPagerState":           "androidx.compose.foundation.pager",
+	"CircleShape":                  "androidx.compose.foundation.shape",
+	"RoundedCornerShape":           "androidx.compose.foundation.shape",
+	"KeyboardOptions":              "androidx.compose.foundation.text",
+	"Button":                       "androidx.compose.material3",
+	"ButtonDefaults":               "androidx.compose.material3",
+	"OutlinedTextField":            "androidx.compose.material3",
+	"Text":                         "androidx.compose.material3",
+	"LaunchedEffect":               "androidx.compose.runtime",
+	"getValue":                     "androidx.compose.runtime",
+	"mutableStateOf":               "androidx.compose.runtime",
+	"remember":                     "androidx.compose.runtime",
+	"setValue":                     "androidx.compose.runtime",
+	"Alignment":                    "androidx.compose.ui",
+	"zIndex":                       "androidx.compose.ui",
+	"alpha":                        "androidx.compose.ui.draw",
+	"clip":                         "androidx.compose.ui.draw",
+	"Color":                        "androidx.compose.ui.graphics",
+	"RectangleShape":               "androidx.compose.ui.graphics",
+	"ContentScale":                 "androidx.compose.ui.layout",
+	"LocalUriHandler":              "androidx.compose.ui.platform",
+	"contentDescription":           "androidx.compose.ui.semantics",
+	"heading":                      "androidx.compose.ui.semantics",
+	"semantics":                    "androidx.compose.ui.semantics",
+	"FontFamily":                   "androidx.compose.ui.text.font",
+	"FontStyle":                    "androidx.compose.ui.text.font",
+	"FontWeight":                   "androidx.compose.ui.text.font",
+	"KeyboardType":                 "androidx.compose.ui.text.input",
+	"PasswordVisualTransformation": "androidx.compose.ui.text.input",
+	"TextAlign":                    "androidx.compose.ui.text.style",
+	"TextDecoration":               "androidx.compose.ui.text.style",
+	"dp":                           "androidx.compose.ui.unit",
+	"sp":                           "androidx.compose.ui.unit",
+}
@@ -0,0 +1,166 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+// Scaffold returns the theme package, with the brand as Kotlin objects and
+// the asset helper, and the README listing the dependencies
+func (t *Target) Scaffold() ([]targets.File, error) {
+	return []targets.File{
+		{Path: "README.md", Content: t.readme()},
+		{Path: t.dir("theme") + "/Theme.kt", Content: t.themeKt()},
+		{Path: t.dir("theme") + "/Assets.kt", Content: fmt.Sprintf(`package %s.ui.theme
+
+// Image paths such as /images/logo.png are served by the website: set the
+// URL it is served from so the app can load them
+var assetBaseUrl = ""
+
+fun asset(path: String): String = assetBaseUrl + path
+`, t.pkg)},
+	}, nil
+}
+
+// colorSchemeRoles are the Material color roles brand colors of the same
+// name fill
+var colorSchemeRoles = []string{"primary", "secondary", "tertiary", "background", "surface", "error"}
+
+// themeKt writes the brand tokens as objects, resolved like component
+// styles, and a MaterialTheme using them
+func (t *Target) themeKt() string {
+	brand := t.ctx.Registry.Structure().Project.Brand
+	tokens := t.views.Tokens()
+	imports := map[string]bool{
+		"androidx.compose.material3.MaterialTheme":    true,
+		"androidx.compose.material3.Typography":       true,
+		"androidx.compose.material3.lightColorScheme": true,
+		"androidx.compose.runtime.Composable":         true,
+		"androidx.compose.ui.text.font.FontFamily":    len(brand.Typography.FontFamily) > 0,
+		"androidx.compose.ui.text.font.FontWeight":    len(brand.Typography.FontWeights) > 0,
+		"androidx.compose.ui.text.TextStyle":          len(brand.Typography.FontSizes) > 0,
+		"androidx.compose.ui.unit.sp":                 len(brand.Typography.FontSizes) > 0,
+		"androidx.compose.ui.unit.dp":                 len(brand.Spacing) > 0,
+		"androidx.compose.ui.graphics.Color":          len(brand.Colors) > 0,
+	}
+
+	var b strings.Builder
+	object := func(name string, lines []string) {
+		fmt.Fprintf(&b, "\nobject %s {\n", name)
+		for _, line := range lines {
+			b.WriteString(indent(line, 1) + "\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	var colors, roles []string
+	for _, key := range renderers.SortedKeys(brand.Colors) {
+		name := renderers.ToPascalCase(key)
+		color, ok := mobile.ParseColor(brand.Colors[key])
+		if !ok {
+			colors = append(colors, fmt.Sprintf("// %s: %s isn't a color Compose can use", name, brand.Colors[key]))
+			continue
+		}
+		colors = append(colors, fmt.Sprintf("val %s = Color(0x%02X%06X)", name, color.Alpha(), color.RGB()))
+		for _, role := range colorSchemeRoles {
+			if key == role {
+				roles = append(roles, fmt.Sprintf("%s = BrandColors.%s,", role, name))
+			}
+		}
+	}
+	object("BrandColors", colors)
+
+	// Typography uses the primary family, or the first one
+	defaultFamily := ""
+	var families []string
+	for _, key := range renderers.SortedKeys(brand.Typography.FontFamily) {
+		if defaultFamily == "" || key == "primary" {
+			defaultFamily = renderers.ToPascalCase(key)
+		}
+		value := brand.Typography.FontFamily[key]
+		generic := "Default"
+		for _, family := range strings.Split(value, ",") {
+			if name, ok := genericFamilies[mobile.FontFamily(family)]; ok {
+				generic = name
+			}
+		}
+		families = append(families, "// "+value, fmt.Sprintf("val %s = FontFamily.%s", renderers.ToPascalCase(key), generic))
+	}
+	if len(families) > 0 {
+		b.WriteString("\n// Brand fonts fall back to a generic family until their files are added to\n// res/font, e.g. FontFamily(Font(R.font.montserrat))")
+	}
+	object("BrandFonts", families)
+
+	var weights []string
+	for _, key := range renderers.SortedKeys(brand.Typography.FontWeights) {
+		if n, ok := tokens.Pixels(brand.Typography.FontWeights[key]); ok {
+			weights = append(weights, fmt.Sprintf("val %s = FontWeight(%s)", renderers.ToPascalCase(key), mobile.FormatNumber(n)))
+		}
+	}
+	object("BrandFontWeights", weights)
+
+	var styles []string
+	for _, key := range renderers.SortedKeys(brand.Typography.FontSizes) {
+		size, ok := tokens.Pixels(brand.Typography.FontSizes[key])
+		if !ok {
+			continue
+		}
+		family := ""
+		if defaultFamily != "" {
+			family = "fontFamily = BrandFonts." + defaultFamily + ", "
+		}
+		styles = append(styles, fmt.Sprintf("val %s = TextStyle(%sfontSize = %s.sp)", renderers.ToPascalCase(key), family, mobile.FormatNumber(size)))
+	}
+	object("BrandTypography", styles)
+
+	var spacing []string
+	for _, key := range renderers.SortedKeys(brand.Spacing) {
+		if n, ok := tokens.Pixels(brand.Spacing[key]); ok {
+			spacing = append(spacing, fmt.Sprintf("val %s = %s.dp", renderers.ToPascalCase(key), mobile.FormatNumber(n)))
+		}
+	}
+	object("BrandSpacing", spacing)
+
+	// Texts use bodyLarge unless styled: give it the body of the website
+	typography := "Typography()"
+	if _, ok := brand.Typography.FontSizes["body"]; ok {
+		body := "BrandTypography.Body"
+		if _, ok := brand.Colors["text"]; ok {
+			body += ".copy(color = BrandColors.Text)"
+		}
+		typography = "Typography(bodyLarge = " + body + ")"
+	}
+	colorScheme := "lightColorScheme()"
+	if len(roles) > 0 {
+		colorScheme = "lightColorScheme(\n" + indent(strings.Join(roles, "\n"), 3) + "\n        )"
+	}
+
+	var lines []string
+	for _, name := range renderers.SortedKeys(imports) {
+		if imports[name] {
+			lines = append(lines, "import "+name)
+		}
+	}
+	return fmt.Sprintf(`package %s.ui.theme
+
+%s
+%s
+@Composable
+fun BrandTheme(content: @Composable () -> Unit) {
+    MaterialTheme(
+        colorScheme = %s,
+        typography = %s,
+        content = content,
+    )
+}
+`, t.pkg, strings.Join(lines, "\n"), b.String(), colorScheme, typography)
+}
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nThese Jetpack Compose sources were automatically generated from an atomic design structure. Only the sources are generated: add them to the app module of an Android project.\n\n## Dependencies\n\n```kotlin\nimplementation(platform(\"androidx.compose:compose-bom:2024.02.00\"))\nimplementation(\"androidx.compose.foundation:foundation\")\nimplementation(\"androidx.compose.material3:material3\")\nimplementation(\"io.coil-kt:coil-compose:2.5.0\")\n```\n\n## Usage\n\n```kotlin\nsetContent {\n    BrandTheme {\n        HomeScreen()\n    }\n}\n```\n\n## Notes\n\n- Styles are resolved for a phone: brand variables, `rem`, viewport units and `clamp()` become `dp` and `sp`, and the narrowest breakpoint's overrides apply. Properties Compose can't apply are listed in a comment above their composable.\n- Brand colors, fonts, font sizes and spacing are objects of the `theme` package. Brand fonts fall back to a generic family until their files are added to `res/font`.\n- Image paths are relative to the website: set `assetBaseUrl` in `theme/Assets.kt`.\n- Links to URLs open in the browser; links to paths of the website do nothing.\n\n## Project Structure\n\n- `ui/theme` - Brand theme and asset helper\n- `ui/atoms` - Basic UI elements\n- `ui/molecules` - Combinations of atoms\n- `ui/organisms` - Complex UI sections\n- `ui/screens` - Page screens\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
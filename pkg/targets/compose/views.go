@@ -0,0 +1,688 @@
+package compose
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+// call is a composable call: name(args) { children }
+type call struct {
+	// comment is printed above the call
+	comment string
+	// prefix is printed before the call, like a when branch (0 -> )
+	prefix string
+	name   string
+	args   []string
+	// lambda calls end with a content lambda holding children
+	lambda      bool
+	lambdaParam string
+	children    []*call
+}
+
+// indent prefixes every non-empty line of code with four spaces per level
+func indent(code string, level int) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.Repeat("    ", level) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// noScope is the parent of views no Row, Column or Box lays out, like the
+// root of a component: weight() and matchParentSize() don't apply to them
+const noScope = mobile.Component
+
+// chain writes a modifier chain, one modifier per line when there are
+// several
+func chain(base string, modifiers []string) string {
+	if len(modifiers) < 2 {
+		return base + strings.Join(modifiers, "")
+	}
+	return base + "\n" + indent(strings.Join(modifiers, "\n"), 1)
+}
+
+func (ca *call) write(b *strings.Builder, level int) {
+	pad := strings.Repeat("    ", level)
+	if ca.comment != "" {
+		fmt.Fprintf(b, "%s// %s\n", pad, ca.comment)
+	}
+
+	head := pad + ca.prefix + ca.name
+	inline := "(" + strings.Join(ca.args, ", ") + ")"
+	switch {
+	case len(ca.args) == 0 && ca.lambda:
+	case len(ca.args) == 0:
+		head += "()"
+	case !strings.Contains(inline, "\n") && len(head)+len(inline) <= 100:
+		head += inline
+	default:
+		head += "(\n"
+		for _, arg := range ca.args {
+			head += indent(arg, level+1) + ",\n"
+		}
+		head += pad + ")"
+	}
+
+	if !ca.lambda {
+		b.WriteString(head + "\n")
+		return
+	}
+	if ca.lambdaParam != "" {
+		fmt.Fprintf(b, "%s { %s ->\n", head, ca.lambdaParam)
+	} else {
+		b.WriteString(head + " {\n")
+	}
+	for _, child := range ca.children {
+		child.write(b, level+1)
+	}
+	b.WriteString(pad + "}\n")
+}
+
+// kotlinString quotes a Kotlin string literal
+func kotlinString(s string) string {
+	return `"` + kotlinEscaper.Replace(s) + `"`
+}
+
+// value returns the parameter a value is bound to, or the quoted value
+func value(s, prop string) string {
+	if prop != "" {
+		return prop
+	}
+	return kotlinString(s)
+}
+
+var kotlinEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`)
+
+// theme imports an object of the theme package
+func (c *composable) theme(name string) string {
+	c.imports[c.t.pkg+".ui.theme."+name] = true
+	return name
+}
+
+// views converts the children of a container
+func (c *composable) views(views []*mobile.View, parent mobile.Kind) []*call {
+	var calls []*call
+	for _, v := range views {
+		calls = append(calls, c.view(v, parent, "Modifier"))
+	}
+	return calls
+}
+
+// view converts a view to its composable. base is the modifier the chain
+// starts from: the function's parameter for its root.
+func (c *composable) view(v *mobile.View, parent mobile.Kind, base string) *call {
+	var ca *call
+	switch v.Kind {
+	case mobile.Component:
+		var args []string
+		for _, prop := range v.Props {
+			args = append(args, prop.Name+" = "+kotlinString(prop.Value))
+		}
+		return &call{name: v.Name, args: args}
+	case mobile.Text, mobile.Link:
+		ca = c.text(v, parent, base)
+	case mobile.Image:
+		ca = c.image(v, parent, base)
+	case mobile.Button:
+		ca = c.button(v, parent, base)
+	case mobile.Input:
+		ca = c.input(v, parent, base)
+	case mobile.Pager:
+		ca = c.pager(v, parent, base)
+	default:
+		ca = c.container(v, parent, base)
+	}
+	if len(v.Style.Unsupported) > 0 {
+		ca.comment = "Not supported in Compose: " + strings.Join(v.Style.Unsupported, ", ")
+	}
+	return ca
+}
+
+// modifierArg returns the modifier argument of a call, or nothing when the
+// chain is empty
+func modifierArg(base string, modifiers []string) []string {
+	if len(modifiers) == 0 && base == "Modifier" {
+		return nil
+	}
+	return []string{"modifier = " + chain(base, modifiers)}
+}
+
+// outer returns the modifiers placing and sizing a view: margin, offset,
+// stacking, weight, size and opacity
+func (c *composable) outer(v *mobile.View, parent mobile.Kind) []string {
+	s := v.Style
+	var m []string
+	if !s.Margin.IsZero() {
+		m = append(m, c.padding(s.Margin))
+	}
+	if s.Absolute && (s.Top != 0 || s.Left != 0) {
+		c.use("offset", "dp")
+		m = append(m, fmt.Sprintf(".offset(x = %s.dp, y = %s.dp)", mobile.FormatNumber(s.Left), mobile.FormatNumber(s.Top)))
+	}
+	if s.ZIndex != nil {
+		c.use("zIndex")
+		m = append(m, fmt.Sprintf(".zIndex(%sf)", mobile.FormatNumber(*s.ZIndex)))
+	}
+	if s.Grow > 0 && (parent == mobile.Row || parent == mobile.Column) {
+		m = append(m, fmt.Sprintf(".weight(%sf)", mobile.FormatNumber(s.Grow)))
+	}
+
+	if parent == mobile.Stack && s.Absolute && s.Width.Full() && s.Height.Full() {
+		// BoxScope sizes the view like its Box, without making it grow
+		m = append(m, ".matchParentSize()")
+	} else {
+		m = append(m, c.bounds("width", "Width", s.MinWidth, s.MaxWidth)...)
+		m = append(m, c.bounds("height", "Height", s.MinHeight, s.MaxHeight)...)
+		if s.Width.Full() && s.Height.Full() {
+			c.use("fillMaxSize")
+			m = append(m, ".fillMaxSize()")
+		} else {
+			m = append(m, c.size("Width", s.Width, v.Block)...)
+			m = append(m, c.size("Height", s.Height, false)...)
+		}
+	}
+
+	if s.Opacity != nil {
+		c.use("alpha")
+		m = append(m, fmt.Sprintf(".alpha(%sf)", mobile.FormatNumber(*s.Opacity)))
+	}
+	return m
+}
+
+// size returns the modifier of a width or height. Blocks fill the width of
+// their container.
+func (c *composable) size(dimension string, l *mobile.Length, fill bool) []string {
+	switch {
+	case l.Full() || l == nil && fill:
+		c.use("fillMax" + dimension)
+		return []string{".fillMax" + dimension + "()"}
+	case l == nil:
+		return nil
+	case l.Percent:
+		c.use("fillMax" + dimension)
+		return []string{fmt.Sprintf(".fillMax%s(%sf)", dimension, mobile.FormatNumber(l.Value/100))}
+	}
+	name := strings.ToLower(dimension)
+	c.use(name, "dp")
+	return []string{fmt.Sprintf(".%s(%s.dp)", name, mobile.FormatNumber(l.Value))}
+}
+
+// bounds returns the widthIn/heightIn modifier of min and max sizes in
+// pixels; percentages have no equivalent
+func (c *composable) bounds(name, dimension string, min, max *mobile.Length) []string {
+	var args []string
+	if min != nil && !min.Percent && min.Value > 0 {
+		args = append(args, fmt.Sprintf("min = %s.dp", mobile.FormatNumber(min.Value)))
+	}
+	if max != nil && !max.Percent {
+		args = append(args, fmt.Sprintf("max = %s.dp", mobile.FormatNumber(max.Value)))
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	c.use(name+"In", "dp")
+	return []string{fmt.Sprintf(".%sIn(%s)", name, strings.Join(args, ", "))}
+}
+
+// decoration returns the modifiers drawing a view's box: clip, border,
+// background and padding
+func (c *composable) decoration(v *mobile.View) []string {
+	s := v.Style
+	var m []string
+	shape := ""
+	if s.Clip || s.BorderWidth > 0 && s.BorderColor != nil || s.Background != nil {
+		shape = c.shape(s.Radius)
+	}
+	withShape := ""
+	if shape != "" {
+		withShape = ", " + shape
+	}
+	if s.Clip {
+		c.use("clip")
+		if shape == "" {
+			c.use("RectangleShape")
+			m = append(m, ".clip(RectangleShape)")
+		} else {
+			m = append(m, ".clip("+shape+")")
+		}
+	}
+	if s.BorderWidth > 0 && s.BorderColor != nil {
+		c.use("border", "dp")
+		m = append(m, fmt.Sprintf(".border(%s.dp, %s%s)", mobile.FormatNumber(s.BorderWidth), c.color(*s.BorderColor), withShape))
+	}
+	if s.Background != nil {
+		c.use("background")
+		m = append(m, fmt.Sprintf(".background(%s%s)", c.color(*s.Background), withShape))
+	}
+	if !s.Padding.IsZero() {
+		m = append(m, c.padding(s.Padding))
+	}
+	return m
+}
+
+// modifiers returns the whole chain of a view
+func (c *composable) modifiers(v *mobile.View, parent mobile.Kind) []string {
+	return append(c.outer(v, parent), c.decoration(v)...)
+}
+
+func (c *composable) padding(e mobile.Edges) string {
+	return ".padding(" + c.paddingArgs(e) + ")"
+}
+
+// paddingArgs returns the arguments of padding() or PaddingValues()
+func (c *composable) paddingArgs(e mobile.Edges) string {
+	c.use("padding", "dp")
+	dp := func(n float64) string { return mobile.FormatNumber(n) + ".dp" }
+	if e.Top == e.Right && e.Top == e.Bottom && e.Top == e.Left {
+		return dp(e.Top)
+	}
+	var args []string
+	if e.Top == e.Bottom && e.Left == e.Right {
+		if e.Left != 0 {
+			args = append(args, "horizontal = "+dp(e.Left))
+		}
+		if e.Top != 0 {
+			args = append(args, "vertical = "+dp(e.Top))
+		}
+		return strings.Join(args, ", ")
+	}
+	for _, side := range []struct {
+		name  string
+		value float64
+	}{{"start", e.Left}, {"top", e.Top}, {"end", e.Right}, {"bottom", e.Bottom}} {
+		if side.value != 0 {
+			args = append(args, side.name+" = "+dp(side.value))
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// shape returns the shape of a border radius, or "" for square corners
+func (c *composable) shape(radius *mobile.Length) string {
+	switch {
+	case radius == nil || radius.Value == 0:
+		return ""
+	case radius.Percent && radius.Value >= 50:
+		c.use("CircleShape")
+		return "CircleShape"
+	case radius.Percent:
+		c.use("RoundedCornerShape")
+		return fmt.Sprintf("RoundedCornerShape(percent = %s)", mobile.FormatNumber(radius.Value))
+	}
+	c.use("RoundedCornerShape", "dp")
+	return fmt.Sprintf("RoundedCornerShape(%s.dp)", mobile.FormatNumber(radius.Value))
+}
+
+// color references the brand color a value came from, or writes it
+func (c *composable) color(color mobile.Color) string {
+	if color.Token != "" {
+		return c.theme("BrandColors") + "." + renderers.ToPascalCase(color.Token)
+	}
+	c.use("Color")
+	return fmt.Sprintf("Color(0x%02X%06X)", color.Alpha(), color.RGB())
+}
+
+// fontWeights names the FontWeight constants
+var fontWeights = map[int]string{
+	100: "Thin", 200: "ExtraLight", 300: "Light", 400: "Normal", 500: "Medium",
+	600: "SemiBold", 700: "Bold", 800: "ExtraBold", 900: "Black",
+}
+
+// textStyle returns the text arguments of Text
+func (c *composable) textStyle(s mobile.Style) []string {
+	var args []string
+	if s.Color != nil {
+		args = append(args, "color = "+c.color(*s.Color))
+	}
+	if s.FontSize > 0 {
+		c.use("sp")
+		args = append(args, fmt.Sprintf("fontSize = %s.sp", mobile.FormatNumber(s.FontSize)))
+	}
+	if s.FontWeight > 0 {
+		c.use("FontWeight")
+		if name, ok := fontWeights[s.FontWeight]; ok {
+			args = append(args, "fontWeight = FontWeight."+name)
+		} else {
+			args = append(args, fmt.Sprintf("fontWeight = FontWeight(%d)", s.FontWeight))
+		}
+	}
+	if s.Italic {
+		c.use("FontStyle")
+		args = append(args, "fontStyle = FontStyle.Italic")
+	}
+	if family := c.fontFamily(s); family != "" {
+		args = append(args, "fontFamily = "+family)
+	}
+	if s.LetterSpacing != 0 {
+		c.use("sp")
+		args = append(args, fmt.Sprintf("letterSpacing = %s.sp", mobile.FormatNumber(s.LetterSpacing)))
+	}
+	switch {
+	case s.Underline && s.Strikethrough:
+		c.use("TextDecoration")
+		args = append(args, "textDecoration = TextDecoration.Underline + TextDecoration.LineThrough")
+	case s.Underline:
+		c.use("TextDecoration")
+		args = append(args, "textDecoration = TextDecoration.Underline")
+	case s.Strikethrough:
+		c.use("TextDecoration")
+		args = append(args, "textDecoration = TextDecoration.LineThrough")
+	}
+	if s.TextAlign != "" {
+		c.use("TextAlign")
+		args = append(args, "textAlign = TextAlign."+textAligns[s.TextAlign])
+	}
+	if s.LineHeight > 0 {
+		c.use("sp")
+		args = append(args, fmt.Sprintf("lineHeight = %s.sp", mobile.FormatNumber(s.LineHeight)))
+	}
+	return args
+}
+
+var textAligns = map[string]string{"left": "Start", "center": "Center", "right": "End", "justify": "Justify"}
+
+// fontFamily references the brand font family of a style, or a generic
+// family; other fonts need resources the app adds itself
+func (c *composable) fontFamily(s mobile.Style) string {
+	if s.FontToken != "" {
+		return c.theme("BrandFonts") + "." + renderers.ToPascalCase(s.FontToken)
+	}
+	if generic, ok := genericFamilies[s.FontFamily]; ok {
+		c.use("FontFamily")
+		return "FontFamily." + generic
+	}
+	return ""
+}
+
+var genericFamilies = map[string]string{
+	"serif": "Serif", "sans-serif": "SansSerif", "monospace": "Monospace", "cursive": "Cursive",
+}
+
+// semantics returns the semantics modifier of headings and labels
+func (c *composable) semantics(v *mobile.View) []string {
+	var properties []string
+	if v.Heading {
+		c.use("heading")
+		properties = append(properties, "heading()")
+	}
+	if v.Label != "" {
+		c.use("contentDescription")
+		properties = append(properties, "contentDescription = "+kotlinString(v.Label))
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	c.use("semantics")
+	return []string{".semantics { " + strings.Join(properties, "; ") + " }"}
+}
+
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// text converts a text or a link. Links to URLs open with the URI handler;
+// paths of the website can't open in the app.
+func (c *composable) text(v *mobile.View, parent mobile.Kind, base string) *call {
+	c.use("Text")
+	modifiers := c.modifiers(v, parent)
+	if v.Kind == mobile.Link && schemePattern.MatchString(v.Href) {
+		c.use("clickable")
+		c.uriHandler = true
+		modifiers = append(modifiers, fmt.Sprintf(".clickable { uriHandler.openUri(%s) }", value(v.Href, v.HrefProp)))
+	}
+	modifiers = append(modifiers, c.semantics(v)...)
+
+	args := []string{"text = " + value(v.Text, v.TextProp)}
+	args = append(args, modifierArg(base, modifiers)...)
+	args = append(args, c.textStyle(v.Style)...)
+	return &call{name: "Text", args: args}
+}
+
+var contentScales = map[string]string{"cover": "Crop", "contain": "Fit", "fill": "FillBounds"}
+
+// image converts an image, loaded with Coil from the website
+func (c *composable) image(v *mobile.View, parent mobile.Kind, base string) *call {
+	c.use("AsyncImage")
+	model := value(v.Src, v.SrcProp)
+	if !schemePattern.MatchString(v.Src) {
+		model = c.theme("asset") + "(" + model + ")"
+	}
+	args := []string{"model = " + model, "contentDescription = " + kotlinString(v.Alt)}
+	// Images keep their own width unless told otherwise
+	wasBlock := v.Block
+	v.Block = false
+	args = append(args, modifierArg(base, c.modifiers(v, parent))...)
+	v.Block = wasBlock
+	if scale, ok := contentScales[v.Style.Fit]; ok {
+		c.use("ContentScale")
+		args = append(args, "contentScale = ContentScale."+scale)
+	}
+	return &call{name: "AsyncImage", args: args}
+}
+
+// button converts a button to a Material button drawn with the element's
+// box styles, its label a Text with its text styles
+func (c *composable) button(v *mobile.View, parent mobile.Kind, base string) *call {
+	c.use("Button", "Text")
+	s := v.Style
+	args := []string{"onClick = {}"}
+	args = append(args, modifierArg(base, append(c.outer(v, parent), c.semantics(v)...))...)
+	if v.Disabled {
+		args = append(args, "enabled = false")
+	}
+
+	// CSS buttons have square corners unless rounded
+	if shape := c.shape(s.Radius); shape != "" {
+		args = append(args, "shape = "+shape)
+	} else {
+		c.use("RectangleShape")
+		args = append(args, "shape = RectangleShape")
+	}
+	if s.Background != nil {
+		c.use("ButtonDefaults")
+		colors := "containerColor = " + c.color(*s.Background)
+		if s.Color != nil {
+			colors += ", contentColor = " + c.color(*s.Color)
+		}
+		args = append(args, "colors = ButtonDefaults.buttonColors("+colors+")")
+	}
+	if s.BorderWidth > 0 && s.BorderColor != nil {
+		c.use("BorderStroke", "dp")
+		args = append(args, fmt.Sprintf("border = BorderStroke(%s.dp, %s)", mobile.FormatNumber(s.BorderWidth), c.color(*s.BorderColor)))
+	}
+	if !s.Padding.IsZero() {
+		c.use("PaddingValues")
+		args = append(args, "contentPadding = PaddingValues("+c.paddingArgs(s.Padding)+")")
+	}
+
+	label := &call{name: "Text", args: append([]string{"text = " + value(v.Text, v.TextProp)}, c.textStyle(s)...)}
+	return &call{name: "Button", args: args, lambda: true, children: []*call{label}}
+}
+
+// keyboardTypes gives HTML input types the right keyboard
+var keyboardTypes = map[string]string{
+	"email":  "Email",
+	"number": "Number",
+	"tel":    "Phone",
+	"url":    "Uri",
+}
+
+// input converts an input to a text field holding its value in state
+func (c *composable) input(v *mobile.View, parent mobile.Kind, base string) *call {
+	c.use("OutlinedTextField", "remember", "mutableStateOf", "getValue", "setValue")
+	state := c.state(v.Name)
+	c.declarations = append(c.declarations, fmt.Sprintf(`var %s by remember { mutableStateOf("") }`, state))
+
+	args := []string{
+		"value = " + state,
+		fmt.Sprintf("onValueChange = { %s = it }", state),
+	}
+	// The field draws its own outline: only its shape comes from the styles
+	for prop, set := range map[string]bool{"background": v.Style.Background != nil, "border": v.Style.BorderWidth > 0, "padding": !v.Style.Padding.IsZero()} {
+		if set {
+			v.Style.Unsupported = append(v.Style.Unsupported, prop)
+		}
+	}
+	slices.Sort(v.Style.Unsupported)
+	args = append(args, modifierArg(base, append(c.outer(v, parent), c.semantics(v)...))...)
+	if v.Disabled {
+		args = append(args, "enabled = false")
+	}
+	if v.Placeholder != "" {
+		c.use("Text")
+		args = append(args, fmt.Sprintf("placeholder = { Text(%s) }", kotlinString(v.Placeholder)))
+	}
+	if keyboard, ok := keyboardTypes[v.InputType]; ok {
+		c.use("KeyboardOptions", "KeyboardType")
+		args = append(args, "keyboardOptions = KeyboardOptions(keyboardType = KeyboardType."+keyboard+")")
+	}
+	if v.InputType == "password" {
+		c.use("PasswordVisualTransformation")
+		args = append(args, "visualTransformation = PasswordVisualTransformation()")
+	}
+	if shape := c.shape(v.Style.Radius); shape != "" {
+		args = append(args, "shape = "+shape)
+	}
+	args = append(args, "singleLine = true")
+	return &call{name: "OutlinedTextField", args: args}
+}
+
+// state returns a unique name for a state variable of the function
+func (c *composable) state(name string) string {
+	if name == "" {
+		name = "value"
+	}
+	name = strings.ToLower(name[:1]) + renderers.ToPascalCase(name)[1:]
+	c.states[name]++
+	if n := c.states[name]; n > 1 {
+		return fmt.Sprintf("%s%d", name, n)
+	}
+	return name
+}
+
+// container converts a Column, Row or Stack, laying out its children like
+// the flexbox or block layout of the element
+func (c *composable) container(v *mobile.View, parent mobile.Kind, base string) *call {
+	s := v.Style
+	ca := &call{lambda: true, args: modifierArg(base, c.modifiers(v, parent))}
+	ca.children = c.views(v.Children, v.Kind)
+	if len(ca.children) == 0 {
+		// An empty box only draws its background
+		c.use("Box")
+		ca.name = "Box"
+		ca.lambda = false
+		return ca
+	}
+
+	switch v.Kind {
+	case mobile.Row:
+		ca.name = "Row"
+		if arrangement := c.arrangement(s, "End", "CenterHorizontally"); arrangement != "" {
+			c.use("Arrangement")
+			ca.args = append(ca.args, "horizontalArrangement = "+arrangement)
+		}
+		if alignment, ok := map[string]string{"center": "CenterVertically", "flex-end": "Bottom", "end": "Bottom"}[s.Align]; ok {
+			c.use("Alignment")
+			ca.args = append(ca.args, "verticalAlignment = Alignment."+alignment)
+		}
+	case mobile.Stack:
+		ca.name = "Box"
+		if alignment := stackAlignment(s); alignment != "" {
+			c.use("Alignment")
+			ca.args = append(ca.args, "contentAlignment = Alignment."+alignment)
+		}
+	default:
+		ca.name = "Column"
+		if arrangement := c.arrangement(s, "Bottom", "CenterVertically"); arrangement != "" {
+			c.use("Arrangement")
+			ca.args = append(ca.args, "verticalArrangement = "+arrangement)
+		}
+		if alignment, ok := map[string]string{"center": "CenterHorizontally", "flex-end": "End", "end": "End"}[s.Align]; ok {
+			c.use("Alignment")
+			ca.args = append(ca.args, "horizontalAlignment = Alignment."+alignment)
+		}
+	}
+	c.use(ca.name)
+	return ca
+}
+
+// arrangement returns the Arrangement of a container's main axis from its
+// justify-content and gap
+func (c *composable) arrangement(s mobile.Style, end, center string) string {
+	switch s.Justify {
+	case "space-between":
+		return "Arrangement.SpaceBetween"
+	case "space-around":
+		return "Arrangement.SpaceAround"
+	case "space-evenly":
+		return "Arrangement.SpaceEvenly"
+	}
+
+	var alignment string
+	switch s.Justify {
+	case "center":
+		alignment = center
+	case "flex-end", "end":
+		alignment = end
+	}
+	if s.Gap > 0 {
+		c.use("dp")
+		if alignment == "" {
+			return fmt.Sprintf("Arrangement.spacedBy(%s.dp)", mobile.FormatNumber(s.Gap))
+		}
+		c.use("Alignment")
+		return fmt.Sprintf("Arrangement.spacedBy(%s.dp, Alignment.%s)", mobile.FormatNumber(s.Gap), alignment)
+	}
+	switch alignment {
+	case "":
+		return ""
+	case center:
+		return "Arrangement.Center"
+	}
+	return "Arrangement." + alignment
+}
+
+// stackAlignment returns where a Box places its children, from the
+// centering of the flex container
+func stackAlignment(s mobile.Style) string {
+	centered := func(value string) bool { return value == "center" }
+	switch {
+	case centered(s.Justify) && centered(s.Align):
+		return "Center"
+	case centered(s.Justify) || centered(s.Align):
+		return "TopCenter"
+	}
+	return ""
+}
+
+// pager converts a carousel to a HorizontalPager with one page per slide,
+// scrolled automatically with autoplay
+func (c *composable) pager(v *mobile.View, parent mobile.Kind, base string) *call {
+	c.use("HorizontalPager", "rememberPagerState")
+	c.optIn = true
+	c.declarations = append(c.declarations, fmt.Sprintf("val pagerState = rememberPagerState(pageCount = { %d })", len(v.Children)))
+	if v.Interval > 0 {
+		c.use("LaunchedEffect", "delay")
+		c.declarations = append(c.declarations, fmt.Sprintf(`
+LaunchedEffect(pagerState) {
+    while (true) {
+        delay(%d)
+        pagerState.animateScrollToPage((pagerState.currentPage + 1) %% pagerState.pageCount)
+    }
+}`, v.Interval))
+	}
+
+	pages := &call{name: "when (page)", lambda: true}
+	for i, slide := range v.Children {
+		page := c.view(slide, noScope, "Modifier")
+		page.prefix = fmt.Sprintf("%d -> ", i)
+		pages.children = append(pages.children, page)
+	}
+	args := append([]string{"state = pagerState"}, modifierArg(base, c.modifiers(v, parent))...)
+	return &call{name: "HorizontalPager", args: args, lambda: true, lambdaParam: "page", children: []*call{pages}}
+}
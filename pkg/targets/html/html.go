@@ -0,0 +1,235 @@
+// Package html is the static target: one index.html per route and a shared
+// stylesheet, no framework and no build step.
+package html
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+func init() {
+	targets.Register("html", New)
+}
+
+// Target emits a static site that opens from disk. Pages inline their
+// organisms, and every element's styles, states and breakpoint overrides
+// compile to classes in styles.css.
+type Target struct {
+	ctx *renderers.Context
+	// builder is shared by all pages so class names are unique across the
+	// one stylesheet
+	builder *renderers.MarkupBuilder
+	// pages holds the rendered pages by ID, see Scaffold
+	pages map[string]targets.File
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	if ctx.Options.Lang != renderers.LangJS {
+		return nil, fmt.Errorf("the html target only emits JavaScript (got -lang=%s)", ctx.Options.Lang)
+	}
+	if ctx.Options.Composition == renderers.CompositionImport {
+		return nil, fmt.Errorf("the html target always inlines children (got -composition=import)")
+	}
+	return &Target{
+		ctx:     ctx,
+		builder: renderers.NewMarkupBuilder(ctx),
+		pages:   make(map[string]targets.File),
+	}, nil
+}
+
+func (t *Target) Name() string {
+	return "html"
+}
+
+func (t *Target) Directories() []string {
+	return nil
+}
+
+func (t *Target) NextSteps() []string {
+	return []string{"Open index.html in your browser (no build step needed)"}
+}
+
+// Scaffold renders every page up front, since the shared stylesheet needs
+// the rules of all of them, and returns the stylesheet and the optional
+// behaviors script. Page then returns the pages rendered here.
+func (t *Target) Scaffold() ([]targets.File, error) {
+	structure := t.ctx.Registry.Structure()
+
+	var behaviors bool
+	for i := range structure.Pages {
+		page := &structure.Pages[i]
+		layout := t.ctx.Registry.Layout(page.Layout)
+		if layout == nil {
+			return nil, fmt.Errorf("layout %s not found for page %s", page.Layout, page.ID)
+		}
+
+		file, scripted, err := t.renderPage(page, layout)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering page %s: %w", page.ID, err)
+		}
+		t.pages[page.ID] = file
+		behaviors = behaviors || scripted
+	}
+
+	stylesheet := targets.GlobalStylesheet(structure.Project.Brand)
+	if css := t.builder.StyleSheet(); css != "" {
+		stylesheet += "\n/* Components */\n" + css
+	}
+
+	files := []targets.File{{Path: "styles.css", Content: stylesheet}}
+	if behaviors {
+		files = append(files, targets.File{Path: "behaviors.js", Content: behaviorsJS})
+	}
+	return files, nil
+}
+
+// Atoms, molecules and organisms are inlined into the pages
+func (t *Target) Atom(*models.Atom) ([]targets.File, error) {
+	return nil, nil
+}
+
+func (t *Target) Molecule(*models.Molecule) ([]targets.File, error) {
+	return nil, nil
+}
+
+func (t *Target) Organism(*models.Organism) ([]targets.File, error) {
+	return nil, nil
+}
+
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	file, ok := t.pages[page.ID]
+	if !ok {
+		return nil, fmt.Errorf("page %s was not rendered by Scaffold", page.ID)
+	}
+	return []targets.File{file}, nil
+}
+
+// renderPage returns the page's index.html and whether it needs
+// behaviors.js
+func (t *Target) renderPage(page *models.Page, layout *models.Layout) (targets.File, bool, error) {
+	route := renderers.PageRoute(t.ctx.Registry, page)
+	dir := targets.RouteDir(route)
+	if strings.Contains(dir, "[") {
+		return targets.File{}, false, fmt.Errorf("route %s has parameters, which a static site can't serve", route)
+	}
+
+	scripted := false
+	body, err := t.builder.Page(layout, func(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+		// Only scroll-aware headers get their scrolled classes toggled
+		if renderers.IsScrollAware(organism) {
+			scripted = true
+			t.builder.Drive("scrolled", "scrolled")
+			defer t.builder.Drive("scrolled", "")
+		}
+
+		el, err := t.builder.OrganismInstance(organism, props)
+		if err != nil {
+			return nil, err
+		}
+		if renderers.IsCarousel(organism) {
+			scripted = true
+			el.Attrs = append(el.Attrs, renderers.Attr{Name: "data-carousel", Boolean: true})
+			if organism.Behavior.Autoplay {
+				el.Attrs = append(el.Attrs, renderers.Attr{Name: "data-interval", Value: fmt.Sprint(organism.Behavior.Interval)})
+			}
+		}
+		return el, nil
+	})
+	if err != nil {
+		return targets.File{}, false, err
+	}
+
+	// Relative links, so the site works from file://
+	root := strings.Repeat("../", strings.Count(dir, "/")+1)
+	if dir == "" {
+		root = ""
+	}
+
+	var head []string
+	head = append(head, fmt.Sprintf("<title>%s</title>", html.EscapeString(page.Title)))
+	for _, tag := range renderers.PageMetaTags(page) {
+		head = append(head, fmt.Sprintf(`<meta %s="%s" content="%s" />`, tag.Attr, tag.Name, html.EscapeString(tag.Content)))
+	}
+
+	script := ""
+	if scripted {
+		script = fmt.Sprintf("\n    <script src=\"%sbehaviors.js\" defer></script>", root)
+	}
+
+	lang := "es"
+	if language, ok := page.Meta["language"]; ok {
+		lang = language
+	}
+
+	fonts := targets.FontLinks(t.ctx.Registry.Structure().Project)
+	if fonts != "" {
+		fonts += "\n"
+	}
+
+	content := fmt.Sprintf(`<!DOCTYPE html>
+<html lang="%s">
+  <head>
+    <meta charset="UTF-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    %s
+%s    <link rel="stylesheet" href="%sstyles.css" />%s
+  </head>
+  <body>
+%s
+  </body>
+</html>
+`, html.EscapeString(lang), strings.Join(head, "\n    "), fonts, root, script, renderers.PrintMarkup(body, 2, renderers.ScriptDialect))
+
+	return targets.File{Path: path.Join(dir, "index.html"), Content: content}, scripted, nil
+}
+
+// behaviorsJS enhances carousels and scroll-aware headers. It is a classic
+// script (modules don't load from file://) and is optional: without it
+// carousels show every slide and headers keep their initial styles.
+const behaviorsJS = `(function () {
+  // Autoplay carousels show one slide at a time, advancing every
+  // data-interval ms. Without autoplay there are no controls to move
+  // between slides, so they all stay visible.
+  document.querySelectorAll('[data-carousel][data-interval]').forEach(function (carousel) {
+    var slides = Array.prototype.slice.call(carousel.children);
+    var interval = Number(carousel.getAttribute('data-interval'));
+    var current = 0;
+    if (!(interval > 0) || slides.length < 2) {
+      return;
+    }
+
+    function show(index) {
+      slides.forEach(function (slide, i) {
+        slide.style.display = i === index ? '' : 'none';
+      });
+    }
+
+    show(current);
+    setInterval(function () {
+      current = (current + 1) % slides.length;
+      show(current);
+    }, interval);
+  });
+
+  // Scroll-aware headers toggle the classes of their scrolled state
+  var headers = document.querySelectorAll('[data-state-scrolled]');
+  if (headers.length > 0) {
+    var update = function () {
+      var scrolled = window.scrollY > 50;
+      headers.forEach(function (header) {
+        header.getAttribute('data-state-scrolled').split(' ').forEach(function (name) {
+          header.classList.toggle(name, scrolled);
+        });
+      });
+    };
+    window.addEventListener('scroll', update);
+    update();
+  }
+})();
+`
@@ -0,0 +1,101 @@
+package mobile
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color is a resolved CSS color
+type Color struct {
+	R, G, B uint8
+	// A is the opacity, from 0 to 1
+	A float64
+	// Token is the brand color the value referenced, if any, so emitters
+	// can use the theme instead of a literal
+	Token string
+}
+
+// RGB returns the color as 0xRRGGBB
+func (c Color) RGB() uint32 {
+	return uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
+}
+
+// Alpha returns the opacity as a byte
+func (c Color) Alpha() uint8 {
+	return uint8(math.Round(c.A * 255))
+}
+
+var namedColors = map[string]Color{
+	"black":       {0, 0, 0, 1, ""},
+	"white":       {255, 255, 255, 1, ""},
+	"transparent": {0, 0, 0, 0, ""},
+	"red":         {255, 0, 0, 1, ""},
+	"green":       {0, 128, 0, 1, ""},
+	"blue":        {0, 0, 255, 1, ""},
+	"gray":        {128, 128, 128, 1, ""},
+	"grey":        {128, 128, 128, 1, ""},
+	"orange":      {255, 165, 0, 1, ""},
+	"yellow":      {255, 255, 0, 1, ""},
+	"purple":      {128, 0, 128, 1, ""},
+}
+
+// ParseColor parses a hex, rgb()/rgba() or basic named color
+func ParseColor(raw string) (Color, bool) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if color, ok := namedColors[raw]; ok {
+		return color, true
+	}
+
+	if strings.HasPrefix(raw, "#") {
+		hex := raw[1:]
+		if len(hex) == 3 || len(hex) == 4 {
+			var expanded strings.Builder
+			for _, r := range hex {
+				expanded.WriteRune(r)
+				expanded.WriteRune(r)
+			}
+			hex = expanded.String()
+		}
+		if len(hex) != 6 && len(hex) != 8 {
+			return Color{}, false
+		}
+		value, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return Color{}, false
+		}
+		if len(hex) == 6 {
+			return Color{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 1}, true
+		}
+		return Color{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: float64(uint8(value)) / 255}, true
+	}
+
+	for _, fn := range []string{"rgba(", "rgb("} {
+		if !strings.HasPrefix(raw, fn) || !strings.HasSuffix(raw, ")") {
+			continue
+		}
+		args := SplitArgs(raw[len(fn) : len(raw)-1])
+		if len(args) != 3 && len(args) != 4 {
+			return Color{}, false
+		}
+		var channels [3]uint8
+		for i := 0; i < 3; i++ {
+			n, err := strconv.ParseFloat(args[i], 64)
+			if err != nil || n < 0 || n > 255 {
+				return Color{}, false
+			}
+			channels[i] = uint8(math.Round(n))
+		}
+		alpha := 1.0
+		if len(args) == 4 {
+			n, err := strconv.ParseFloat(args[3], 64)
+			if err != nil {
+				return Color{}, false
+			}
+			alpha = math.Max(0, math.Min(1, n))
+		}
+		return Color{R: channels[0], G: channels[1], B: channels[2], A: alpha}, true
+	}
+
+	return Color{}, false
+}
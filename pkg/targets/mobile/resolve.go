@@ -0,0 +1,353 @@
+// Package mobile holds what the native app targets (React Native, Jetpack
+// Compose, SwiftUI) share: CSS values resolved for a phone against the
+// brand tokens, and element trees mapped to native views.
+package mobile
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/parser"
+	"atomic-generator/pkg/renderers"
+)
+
+// Apps have no viewport units, rem or media queries: lengths are resolved
+// for a typical phone, which falls in the narrowest brand breakpoint
+const (
+	ViewportWidth  = 390
+	ViewportHeight = 844
+	RootFontSize   = 16
+)
+
+// Token is a brand token, e.g. the color textLight
+type Token struct {
+	// Category is the token's CSS variable prefix: color, font-family,
+	// font-size, font-weight or spacing
+	Category string
+	Key      string
+}
+
+// Tokens resolves the CSS variables of the brand
+type Tokens struct {
+	values map[string]string
+	tokens map[string]Token
+}
+
+func NewTokens(brand models.Brand) *Tokens {
+	t := &Tokens{
+		values: make(map[string]string),
+		tokens: make(map[string]Token),
+	}
+	add := func(category, key, value string) {
+		name := parser.TokenVariable(category, key)
+		t.values[name] = value
+		t.tokens[name] = Token{Category: category, Key: key}
+	}
+	for key, value := range brand.Colors {
+		add("color", key, value)
+	}
+	for key, value := range brand.Typography.FontFamily {
+		add("font-family", key, value)
+	}
+	for key, value := range brand.Typography.FontSizes {
+		add("font-size", key, value)
+	}
+	for key, value := range brand.Typography.FontWeights {
+		add("font-weight", key, strings.TrimSpace(formatAny(value)))
+	}
+	for key, value := range brand.Spacing {
+		add("spacing", key, value)
+	}
+	return t
+}
+
+var varPattern = regexp.MustCompile(`var\(\s*(--[\w-]+)\s*(?:,\s*([^()]*))?\)`)
+
+// Resolve replaces var() references with brand tokens (or their fallback),
+// reporting false when one can't be resolved
+func (t *Tokens) Resolve(value string) (string, bool) {
+	// Tokens can refer to other tokens; stop at a reasonable depth so a
+	// cycle doesn't loop forever
+	for depth := 0; depth < 10 && strings.Contains(value, "var("); depth++ {
+		ok := true
+		value = varPattern.ReplaceAllStringFunc(value, func(match string) string {
+			groups := varPattern.FindStringSubmatch(match)
+			if token, exists := t.values[groups[1]]; exists {
+				return token
+			}
+			if strings.Contains(match, ",") {
+				return strings.TrimSpace(groups[2])
+			}
+			ok = false
+			return match
+		})
+		if !ok {
+			return value, false
+		}
+	}
+	return value, !strings.Contains(value, "var(")
+}
+
+// Token returns the brand token a value refers to when the value is
+// nothing but a var() reference, e.g. var(--color-primary)
+func (t *Tokens) Token(value interface{}) (Token, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return Token{}, false
+	}
+	groups := varPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if groups == nil || groups[0] != strings.TrimSpace(s) {
+		return Token{}, false
+	}
+	token, ok := t.tokens[groups[1]]
+	return token, ok
+}
+
+// Pixels resolves a value to a number of density-independent pixels
+func (t *Tokens) Pixels(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		resolved, ok := t.Resolve(v)
+		if !ok {
+			return 0, false
+		}
+		return Pixels(resolved)
+	}
+	return 0, false
+}
+
+var lengthUnits = []struct {
+	unit   string
+	pixels float64
+}{
+	{"px", 1},
+	{"rem", RootFontSize},
+	{"em", RootFontSize},
+	{"vw", ViewportWidth / 100.0},
+	{"vh", ViewportHeight / 100.0},
+}
+
+// Pixels resolves a CSS length, including clamp(), min() and max(), to
+// pixels on the reference phone
+func Pixels(raw string) (float64, bool) {
+	raw = strings.TrimSpace(raw)
+
+	for _, fn := range []string{"clamp", "min", "max"} {
+		if !strings.HasPrefix(raw, fn+"(") || !strings.HasSuffix(raw, ")") {
+			continue
+		}
+		var values []float64
+		for _, arg := range SplitArgs(raw[len(fn)+1 : len(raw)-1]) {
+			n, ok := Pixels(arg)
+			if !ok {
+				return 0, false
+			}
+			values = append(values, n)
+		}
+		switch {
+		case fn == "clamp" && len(values) == 3:
+			return math.Max(values[0], math.Min(values[1], values[2])), true
+		case fn == "min" && len(values) > 0:
+			return slices.Min(values), true
+		case fn == "max" && len(values) > 0:
+			return slices.Max(values), true
+		}
+		return 0, false
+	}
+
+	for _, lu := range lengthUnits {
+		if strings.HasSuffix(raw, lu.unit) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(raw, lu.unit), 64)
+			if err != nil {
+				return 0, false
+			}
+			return n * lu.pixels, true
+		}
+	}
+	n, err := strconv.ParseFloat(raw, 64)
+	return n, err == nil
+}
+
+// colorFunctions are the CSS functions apps understand as colors
+var colorFunctions = []string{"rgb(", "rgba(", "hsl(", "hsla("}
+
+// HasFunction reports whether a value calls a CSS function other than a
+// color (calc, url, linear-gradient...)
+func HasFunction(raw string) bool {
+	if !strings.Contains(raw, "(") {
+		return false
+	}
+	for _, fn := range colorFunctions {
+		if strings.HasPrefix(raw, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitValues splits a space separated CSS value, keeping function calls
+// such as rgba(0, 0, 0, 0.1) whole
+func SplitValues(raw string) []string {
+	return splitTopLevel(raw, func(r rune) bool { return r == ' ' || r == '\t' })
+}
+
+// SplitArgs splits the comma separated arguments of a CSS function
+func SplitArgs(raw string) []string {
+	return splitTopLevel(raw, func(r rune) bool { return r == ',' })
+}
+
+func splitTopLevel(raw string, separator func(rune) bool) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i, r := range raw {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0 && separator(r):
+			if part := strings.TrimSpace(raw[start:i]); part != "" {
+				parts = append(parts, part)
+			}
+			start = i + 1
+		}
+	}
+	if part := strings.TrimSpace(raw[start:]); part != "" {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// FontFamily returns the first family of a font-family list, unquoted:
+// apps take a single family, without fallbacks
+func FontFamily(raw string) string {
+	family := strings.TrimSpace(strings.Split(raw, ",")[0])
+	return strings.Trim(family, `'"`)
+}
+
+// FormatNumber prints a number with at most two decimals
+func FormatNumber(n float64) string {
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64)
+}
+
+func formatAny(value interface{}) string {
+	if n, ok := value.(float64); ok {
+		return FormatNumber(n)
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// KebabCase converts camelCase and snake_case token names to kebab-case
+func KebabCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '_':
+			b.WriteRune('-')
+		case r >= 'A' && r <= 'Z':
+			if i > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r + 32)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// CamelCase converts kebab-case CSS properties to camelCase
+func CamelCase(prop string) string {
+	parts := strings.Split(prop, "-")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = renderers.Capitalize(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+// PhoneBreakpoint returns the breakpoint phones fall in, whose responsive
+// overrides and hidden elements apply: the narrowest one
+func PhoneBreakpoint(breakpoints parser.Breakpoints) string {
+	if names := breakpoints.Names(); len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+// Hidden reports whether an element is hidden on phones
+func Hidden(style *renderers.ElementStyle, phone string) bool {
+	return style != nil && slices.Contains(style.HiddenAt, phone)
+}
+
+// PhoneStyles returns the styles an element has on a phone, by camelCase
+// property: the inherited ones, its own, the overrides of the phone
+// breakpoint and, when disabled, its disabled state
+func PhoneStyles(style *renderers.ElementStyle, phone string, inherited map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	apply := func(styles map[string]interface{}) {
+		for prop, value := range styles {
+			merged[CamelCase(prop)] = value
+		}
+	}
+	apply(inherited)
+	if style == nil {
+		return merged
+	}
+	apply(style.Styles)
+	apply(style.Responsive[phone])
+	if style.Disabled {
+		apply(style.States["disabled"])
+	}
+	return merged
+}
+
+// InheritedProperties are the text properties CSS inherits from parents.
+// Native containers don't pass them down, so every text applies them
+// itself.
+var InheritedProperties = map[string]bool{
+	"color":         true,
+	"fontFamily":    true,
+	"fontSize":      true,
+	"fontStyle":     true,
+	"fontWeight":    true,
+	"letterSpacing": true,
+	"lineHeight":    true,
+	"textAlign":     true,
+	"textTransform": true,
+}
+
+// Inherit returns the text styles the children of an element inherit
+func Inherit(inherited, styles map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(inherited))
+	for prop, value := range inherited {
+		merged[prop] = value
+	}
+	for prop, value := range styles {
+		if InheritedProperties[prop] {
+			merged[prop] = value
+		}
+	}
+	return merged
+}
+
+// FontOnly keeps the inherited font family: like buttons and inputs on
+// the web, native buttons and text fields don't inherit the other text
+// styles
+func FontOnly(inherited map[string]interface{}) map[string]interface{} {
+	if family, ok := inherited["fontFamily"]; ok {
+		return map[string]interface{}{"fontFamily": family}
+	}
+	return nil
+}
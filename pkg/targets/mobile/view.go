@@ -0,0 +1,595 @@
+package mobile
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+)
+
+// Kind is the native view an element maps to
+type Kind int
+
+const (
+	// Column stacks its children vertically: blocks and flex columns
+	Column Kind = iota
+	// Row lays its children out horizontally: flex rows
+	Row
+	// Stack overlaps its children, for containers with absolutely
+	// positioned children
+	Stack
+	// Pager scrolls horizontally through its children, one per page
+	Pager
+	Text
+	Image
+	Button
+	Input
+	Link
+	// Component references another generated component, e.g. the
+	// organisms of a page
+	Component
+)
+
+// View is an element resolved for a phone, ready for a declarative UI
+// toolkit (Compose, SwiftUI) to print
+type View struct {
+	Kind Kind
+	// Name is the element's stylesheet class, or the referenced component
+	Name string
+	// Text is the view's text, with its text-transform applied
+	Text    string
+	Heading bool
+	// TextProp, SrcProp and HrefProp name the component props bound to the
+	// text, source and destination, which then hold their defaults
+	TextProp string
+	SrcProp  string
+	HrefProp string
+	// Props are the prop values passed to a Component
+	Props []renderers.Attr
+	// Block views fill the width of their container, like block-level
+	// elements outside flex rows
+	Block bool
+
+	Src         string
+	Alt         string
+	Href        string
+	Placeholder string
+	InputType   string
+	Label       string
+	Disabled    bool
+
+	// Interval is the autoplay delay of a Pager in milliseconds, 0 when it
+	// doesn't autoplay
+	Interval int
+
+	Style    Style
+	Children []*View
+}
+
+// Container reports whether the view lays out children
+func (v *View) Container() bool {
+	return v.Kind == Column || v.Kind == Row || v.Kind == Stack || v.Kind == Pager
+}
+
+// Length is a resolved CSS length: pixels, or a percentage of the parent
+type Length struct {
+	Value   float64
+	Percent bool
+}
+
+// Full reports whether the length is 100%
+func (l *Length) Full() bool {
+	return l != nil && l.Percent && l.Value == 100
+}
+
+// Edges are the four sides of a padding or margin, in pixels
+type Edges struct {
+	Top, Right, Bottom, Left float64
+}
+
+func (e Edges) IsZero() bool {
+	return e == Edges{}
+}
+
+// Style is what native toolkits can apply of an element's styles on a
+// phone. Text properties only apply to texts, links, buttons and inputs.
+type Style struct {
+	Width, Height       *Length
+	MinWidth, MinHeight *Length
+	MaxWidth, MaxHeight *Length
+	Padding, Margin     Edges
+
+	Background  *Color
+	BorderWidth float64
+	BorderColor *Color
+	Radius      *Length
+	Opacity     *float64
+	ZIndex      *float64
+	Clip        bool
+
+	// Absolute views are offset by Top and Left from their Stack
+	Absolute  bool
+	Top, Left float64
+
+	// Justify and Align are the CSS justify-content and align-items
+	// keywords of a container
+	Justify string
+	Align   string
+	Gap     float64
+	// Grow is the flex grow factor of a view in a Row or Column
+	Grow float64
+
+	Color *Color
+	// FontFamily is the first family of the font-family list, and
+	// FontToken the brand font family it came from, if any
+	FontFamily    string
+	FontToken     string
+	FontSize      float64
+	FontWeight    int
+	Italic        bool
+	TextAlign     string
+	LetterSpacing float64
+	LineHeight    float64
+	Underline     bool
+	Strikethrough bool
+
+	// Fit is the object-fit of an image
+	Fit string
+
+	// Unsupported lists the source properties the style couldn't apply
+	Unsupported []string
+}
+
+// Builder converts element trees to views
+type Builder struct {
+	tokens *Tokens
+	phone  string
+}
+
+func NewBuilder(ctx *renderers.Context) *Builder {
+	return &Builder{
+		tokens: NewTokens(ctx.Registry.Structure().Project.Brand),
+		phone:  PhoneBreakpoint(ctx.Registry.Breakpoints()),
+	}
+}
+
+// Tokens returns the brand tokens the builder resolves styles against
+func (b *Builder) Tokens() *Tokens {
+	return b.tokens
+}
+
+// View converts a component's element tree, returning nil when it is
+// hidden on phones
+func (b *Builder) View(el *renderers.Element) *View {
+	return b.view(el, nil, nil)
+}
+
+// Carousel turns a carousel organism's root into a pager of its slides
+func Carousel(root *View, behavior *models.Behavior) {
+	root.Kind = Pager
+	if behavior.Autoplay && len(root.Children) > 1 {
+		root.Interval = behavior.Interval
+	}
+}
+
+var headingTags = map[string]bool{"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true}
+
+// inlineTags are the elements that don't fill the width of their container
+var inlineTags = map[string]bool{
+	"a": true, "b": true, "button": true, "code": true, "em": true, "i": true,
+	"img": true, "input": true, "label": true, "small": true, "span": true, "strong": true,
+}
+
+func attr(el *renderers.Element, name string) (string, bool) {
+	for _, a := range el.Attrs {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// attrProp returns the component prop an attribute is bound to, or ""
+func attrProp(el *renderers.Element, name string) string {
+	for _, a := range el.Attrs {
+		if a.Name == name {
+			return a.Prop
+		}
+	}
+	return ""
+}
+
+// view converts an element. inherited holds the text styles of its
+// ancestors, which native containers don't pass down like CSS does.
+func (b *Builder) view(el *renderers.Element, parent *View, inherited map[string]interface{}) *View {
+	switch {
+	case el.Tag == "":
+		v := &View{Kind: Column, Block: true}
+		v.Children = b.views(el.Children, v, inherited)
+		return v
+	case el.Tag[0] >= 'A' && el.Tag[0] <= 'Z':
+		return &View{Kind: Component, Name: el.Tag, Block: true, Props: el.Attrs}
+	case Hidden(el.Style, b.phone):
+		return nil
+	}
+
+	v := &View{Heading: headingTags[el.Tag], Text: el.Text, TextProp: el.TextProp}
+	if el.Style != nil {
+		v.Name = el.Style.Class
+	}
+	v.Block = !inlineTags[el.Tag] && (parent == nil || parent.Kind != Row && !shrinks(parent.Style.Align))
+	_, v.Disabled = attr(el, "disabled")
+
+	var styles map[string]interface{}
+	switch el.Tag {
+	case "img":
+		v.Kind = Image
+		v.Src, _ = attr(el, "src")
+		v.SrcProp = attrProp(el, "src")
+		v.Alt, _ = attr(el, "alt")
+		styles = PhoneStyles(el.Style, b.phone, nil)
+	case "input":
+		v.Kind = Input
+		v.Placeholder, _ = attr(el, "placeholder")
+		v.InputType, _ = attr(el, "type")
+		v.Label, _ = attr(el, "aria-label")
+		styles = PhoneStyles(el.Style, b.phone, FontOnly(inherited))
+	case "button":
+		v.Kind = Button
+		styles = PhoneStyles(el.Style, b.phone, FontOnly(inherited))
+	case "a":
+		v.Kind = Link
+		v.Href, _ = attr(el, "href")
+		v.HrefProp = attrProp(el, "href")
+		v.Label, _ = attr(el, "aria-label")
+		styles = PhoneStyles(el.Style, b.phone, inherited)
+	default:
+		if len(el.Children) == 0 && (el.Text != "" || el.TextProp != "" || v.Heading) {
+			v.Kind = Text
+			styles = PhoneStyles(el.Style, b.phone, inherited)
+			break
+		}
+		own := PhoneStyles(el.Style, b.phone, nil)
+		v.Style = b.Style(own)
+		if display, _ := own["display"].(string); strings.HasSuffix(display, "flex") {
+			if direction, _ := own["flexDirection"].(string); !strings.HasPrefix(direction, "column") {
+				v.Kind = Row
+			}
+		}
+		v.Children = b.views(el.Children, v, Inherit(inherited, own))
+		for _, child := range v.Children {
+			if child.Style.Absolute {
+				v.Kind = Stack
+			}
+		}
+		return v
+	}
+
+	v.Style = b.Style(styles)
+	if transform, ok := styles["textTransform"].(string); ok {
+		v.Text = textTransform(v.Text, transform)
+		// The text of a prop is only known at run time
+		if v.TextProp != "" && transform != "none" {
+			v.Style.Unsupported = append(v.Style.Unsupported, "textTransform")
+			sort.Strings(v.Style.Unsupported)
+		}
+	}
+	return v
+}
+
+// views converts elements, leaving out those hidden on phones
+func (b *Builder) views(elements []*renderers.Element, parent *View, inherited map[string]interface{}) []*View {
+	var views []*View
+	for _, el := range elements {
+		if v := b.view(el, parent, inherited); v != nil {
+			views = append(views, v)
+		}
+	}
+	return views
+}
+
+// shrinks reports whether the children of a column with the given
+// align-items keep their own width instead of stretching
+func shrinks(align string) bool {
+	return align != "" && align != "stretch" && align != "normal"
+}
+
+func textTransform(text, transform string) string {
+	switch transform {
+	case "uppercase":
+		return strings.ToUpper(text)
+	case "lowercase":
+		return strings.ToLower(text)
+	case "capitalize":
+		words := strings.Fields(text)
+		for i, word := range words {
+			words[i] = renderers.Capitalize(word)
+		}
+		return strings.Join(words, " ")
+	}
+	return text
+}
+
+// fontWeights maps the keywords of font-weight to numbers
+var fontWeights = map[string]int{"normal": 400, "bold": 700, "lighter": 300, "bolder": 700}
+
+// Style resolves camelCase CSS styles
+func (b *Builder) Style(styles map[string]interface{}) Style {
+	var s Style
+
+	// Unitless line heights multiply the font size
+	if size, ok := b.tokens.Pixels(styles["fontSize"]); ok {
+		s.FontSize = size
+	}
+
+	for _, prop := range renderers.SortedKeys(styles) {
+		if !b.property(&s, prop, styles[prop]) {
+			s.Unsupported = append(s.Unsupported, prop)
+		}
+	}
+	return s
+}
+
+// property applies one CSS property, reporting false when it has no native
+// equivalent
+func (b *Builder) property(s *Style, prop string, value interface{}) bool {
+	raw, ok := value.(string)
+	if !ok {
+		if n, isNumber := b.tokens.Pixels(value); isNumber {
+			raw = strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	}
+	raw, ok = b.tokens.Resolve(raw)
+	raw = strings.TrimSpace(raw)
+	if !ok || raw == "" {
+		return false
+	}
+
+	length := func(target **Length) bool {
+		if raw == "auto" || raw == "none" {
+			return true
+		}
+		l, ok := parseLength(raw)
+		*target = l
+		return ok
+	}
+	color := func(target **Color) bool {
+		c, ok := ParseColor(raw)
+		if !ok {
+			return false
+		}
+		if token, isToken := b.tokens.Token(value); isToken && token.Category == "color" {
+			c.Token = token.Key
+		}
+		*target = &c
+		return true
+	}
+
+	switch prop {
+	case "width":
+		return length(&s.Width)
+	case "height":
+		return length(&s.Height)
+	case "minWidth":
+		return length(&s.MinWidth)
+	case "minHeight":
+		return length(&s.MinHeight)
+	case "maxWidth":
+		return length(&s.MaxWidth)
+	case "maxHeight":
+		return length(&s.MaxHeight)
+	case "padding":
+		return edges(raw, &s.Padding)
+	case "margin":
+		return edges(raw, &s.Margin)
+	case "paddingTop", "paddingRight", "paddingBottom", "paddingLeft":
+		return side(raw, &s.Padding, strings.TrimPrefix(prop, "padding"))
+	case "marginTop", "marginRight", "marginBottom", "marginLeft":
+		return side(raw, &s.Margin, strings.TrimPrefix(prop, "margin"))
+	case "background", "backgroundColor":
+		return color(&s.Background)
+	case "color":
+		return color(&s.Color)
+	case "border":
+		if raw == "none" || raw == "0" {
+			return true
+		}
+		for _, part := range SplitValues(raw) {
+			if n, ok := Pixels(part); ok {
+				s.BorderWidth = n
+			} else if c, ok := ParseColor(part); ok {
+				s.BorderColor = &c
+			} else if part != "solid" {
+				return false
+			}
+		}
+		return true
+	case "borderWidth":
+		n, ok := Pixels(raw)
+		s.BorderWidth = n
+		return ok
+	case "borderColor":
+		return color(&s.BorderColor)
+	case "borderRadius":
+		return length(&s.Radius)
+	case "opacity":
+		n, err := strconv.ParseFloat(raw, 64)
+		s.Opacity = &n
+		return err == nil
+	case "zIndex":
+		n, err := strconv.ParseFloat(raw, 64)
+		s.ZIndex = &n
+		return err == nil
+	case "overflow":
+		s.Clip = raw == "hidden"
+		return raw == "hidden" || raw == "visible"
+	case "position":
+		s.Absolute = raw == "absolute"
+		return raw == "absolute" || raw == "relative" || raw == "static"
+	case "top":
+		n, ok := Pixels(raw)
+		s.Top = n
+		return ok
+	case "left":
+		n, ok := Pixels(raw)
+		s.Left = n
+		return ok
+	case "display":
+		return raw == "flex" || raw == "inline-flex" || raw == "block" || raw == "inline-block" || raw == "inline"
+	case "flexDirection":
+		return raw == "row" || raw == "column"
+	case "justifyContent":
+		s.Justify = raw
+		return true
+	case "alignItems":
+		s.Align = raw
+		return true
+	case "gap":
+		n, ok := Pixels(SplitValues(raw)[0])
+		s.Gap = n
+		return ok
+	case "flex", "flexGrow":
+		n, err := strconv.ParseFloat(SplitValues(raw)[0], 64)
+		s.Grow = n
+		return err == nil
+	case "fontFamily":
+		s.FontFamily = FontFamily(raw)
+		if token, ok := b.tokens.Token(value); ok && token.Category == "font-family" {
+			s.FontToken = token.Key
+		}
+		return s.FontFamily != ""
+	case "fontSize":
+		return s.FontSize > 0
+	case "fontWeight":
+		if weight, ok := fontWeights[raw]; ok {
+			s.FontWeight = weight
+			return true
+		}
+		n, err := strconv.Atoi(raw)
+		s.FontWeight = n
+		return err == nil
+	case "fontStyle":
+		s.Italic = raw == "italic"
+		return raw == "italic" || raw == "normal"
+	case "textAlign":
+		s.TextAlign = raw
+		return raw == "left" || raw == "center" || raw == "right" || raw == "justify"
+	case "letterSpacing":
+		n, ok := Pixels(raw)
+		s.LetterSpacing = n
+		return ok
+	case "lineHeight":
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			s.LineHeight = n * s.fontSize()
+			return true
+		}
+		n, ok := Pixels(raw)
+		s.LineHeight = n
+		return ok
+	case "textDecoration", "textDecorationLine":
+		s.Underline = strings.Contains(raw, "underline")
+		s.Strikethrough = strings.Contains(raw, "line-through")
+		return s.Underline || s.Strikethrough || raw == "none"
+	case "textTransform":
+		// Applied to the text itself
+		return true
+	case "objectFit":
+		s.Fit = raw
+		return raw == "cover" || raw == "contain" || raw == "fill"
+	}
+	return false
+}
+
+// fontSize returns the view's font size, or the root one
+func (s *Style) fontSize() float64 {
+	if s.FontSize > 0 {
+		return s.FontSize
+	}
+	return RootFontSize
+}
+
+func parseLength(raw string) (*Length, bool) {
+	if strings.HasSuffix(raw, "%") {
+		n, err := strconv.ParseFloat(strings.TrimSuffix(raw, "%"), 64)
+		return &Length{Value: n, Percent: true}, err == nil
+	}
+	if HasFunction(raw) && !strings.HasPrefix(raw, "clamp(") && !strings.HasPrefix(raw, "min(") && !strings.HasPrefix(raw, "max(") {
+		return nil, false
+	}
+	n, ok := Pixels(raw)
+	return &Length{Value: n}, ok
+}
+
+// edges parses a 1 to 4 values padding or margin
+func edges(raw string, e *Edges) bool {
+	var values []float64
+	for _, part := range SplitValues(raw) {
+		if part == "auto" {
+			part = "0"
+		}
+		n, ok := Pixels(part)
+		if !ok {
+			return false
+		}
+		values = append(values, n)
+	}
+	switch len(values) {
+	case 1:
+		*e = Edges{values[0], values[0], values[0], values[0]}
+	case 2:
+		*e = Edges{values[0], values[1], values[0], values[1]}
+	case 3:
+		*e = Edges{values[0], values[1], values[2], values[1]}
+	case 4:
+		*e = Edges{values[0], values[1], values[2], values[3]}
+	default:
+		return false
+	}
+	return true
+}
+
+func side(raw string, e *Edges, name string) bool {
+	if raw == "auto" {
+		raw = "0"
+	}
+	n, ok := Pixels(raw)
+	switch name {
+	case "Top":
+		e.Top = n
+	case "Right":
+		e.Right = n
+	case "Bottom":
+		e.Bottom = n
+	case "Left":
+		e.Left = n
+	}
+	return ok
+}
+
+// TypeName returns the name of a component's generated function or type,
+// suffixed with its kind when the toolkit already uses the name: an atom
+// "text" can't be called Text
+func TypeName(id, kind string, reserved map[string]bool) string {
+	name := renderers.ToPascalCase(id)
+	if reserved[name] {
+		name += renderers.Capitalize(kind)
+	}
+	return name
+}
+
+// DefaultLast returns the values of a variant prop with its default last,
+// for toolkits branching on the prop with a fallback branch
+func DefaultLast(variants []string, defaultValue string) []string {
+	var ordered []string
+	for _, variant := range variants {
+		if variant != defaultValue {
+			ordered = append(ordered, variant)
+		}
+	}
+	if len(ordered) < len(variants) {
+		ordered = append(ordered, defaultValue)
+	}
+	return ordered
+}
@@ -0,0 +1,665 @@
+// Package native is the React Native target: an Expo app whose components
+// use React Native primitives and StyleSheet.create, with every page as a
+// screen of a React Navigation stack.
+package native
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+func init() {
+	targets.Register("react-native", New)
+}
+
+// Target emits a React Native app built with Expo
+type Target struct {
+	ctx    *renderers.Context
+	styles *styleCompiler
+	// phone is the breakpoint phones fall in, whose responsive overrides
+	// and hidden elements apply: the narrowest one
+	phone string
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	if ctx.Options.Lang != renderers.LangJS {
+		return nil, fmt.Errorf("the react-native target only emits JavaScript (got -lang=%s)", ctx.Options.Lang)
+	}
+	if ctx.Options.Composition == renderers.CompositionImport {
+		return nil, fmt.Errorf("the react-native target always inlines children (got -composition=import)")
+	}
+
+	t := &Target{
+		ctx:    ctx,
+		styles: newStyleCompiler(ctx.Registry.Structure().Project.Brand),
+		phone:  mobile.PhoneBreakpoint(ctx.Registry.Breakpoints()),
+	}
+	return t, nil
+}
+
+func (t *Target) Name() string {
+	return "react-native"
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		"src",
+		"src/components",
+		"src/components/atoms",
+		"src/components/molecules",
+		"src/components/organisms",
+		"src/screens",
+		"assets",
+	}
+}
+
+func (t *Target) NextSteps() []string {
+	return []string{
+		"npm install",
+		"npx expo start",
+		"Scan the QR code with Expo Go, or press a / i to open an Android or iOS emulator",
+	}
+}
+
+// screenName returns the name of a page's screen in the stack
+func screenName(page *models.Page) string {
+	return renderers.ToPascalCase(page.ID)
+}
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	el, err := builder.Atom(atom)
+	if err != nil {
+		return nil, err
+	}
+	c := t.newComponent("../..")
+	return c.file("src/components/atoms", renderers.ToPascalCase(atom.ID), c.node(el, nil)), nil
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	c := t.newComponent("../..")
+	root, err := c.branches(renderers.MoleculeProps(t.ctx.Registry, molecule), nil, nil, func(variants map[string]string) (*renderers.JSXElement, error) {
+		el, err := renderers.NewMarkupBuilder(t.ctx).MoleculeVariant(molecule, variants)
+		if err != nil {
+			return nil, err
+		}
+		return c.node(el, nil), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.file("src/components/molecules", renderers.ToPascalCase(molecule.ID), root), nil
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	c := t.newComponent("../..")
+	root, err := c.branches(renderers.OrganismProps(t.ctx.Registry, organism), nil, nil, func(variants map[string]string) (*renderers.JSXElement, error) {
+		el, err := renderers.NewMarkupBuilder(t.ctx).OrganismVariant(organism, variants)
+		if err != nil {
+			return nil, err
+		}
+		root := c.node(el, nil)
+		if root != nil && renderers.IsCarousel(organism) {
+			c.carousel(root, organism.Behavior)
+		}
+		return root, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.file("src/components/organisms", renderers.ToPascalCase(organism.ID), root), nil
+}
+
+// Page writes the page's screen, which scrolls through the organisms of its
+// layout
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	c := t.newComponent("..")
+
+	// Screens reference organism components, imported once each
+	imported := make(map[string]bool)
+	body, err := builder.Page(layout, func(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+		name := renderers.ToPascalCase(organism.ID)
+		if !imported[name] {
+			imported[name] = true
+			c.imports = append(c.imports, fmt.Sprintf("import %s from '../components/organisms/%s';", name, name))
+		}
+		return &renderers.Element{Tag: name, Attrs: renderers.PropAttrs(props), Void: true}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.use("ScrollView")
+	root := &renderers.JSXElement{Tag: "ScrollView", Children: c.nodes(body.Children, nil)}
+	return c.file("src/screens", screenName(page)+"Screen", root), nil
+}
+
+// textElement returns a JSX element holding text
+func textElement(tag, text string, attrs ...renderers.JSXAttr) *renderers.JSXElement {
+	n := &renderers.JSXElement{Tag: tag, Attrs: attrs}
+	if text != "" {
+		n.Children = []renderers.JSXNode{renderers.JSXText(text)}
+	}
+	return n
+}
+
+// elementText returns a JSX element holding the text of an element, or the
+// prop it is bound to
+func elementText(tag string, el *renderers.Element, attrs ...renderers.JSXAttr) *renderers.JSXElement {
+	if el.TextProp != "" {
+		return &renderers.JSXElement{Tag: tag, Attrs: attrs, Children: []renderers.JSXNode{renderers.JSXExpr(el.TextProp)}}
+	}
+	return textElement(tag, el.Text, attrs...)
+}
+
+// component collects what one component file needs while its element
+// tree is converted
+type component struct {
+	t *Target
+	// root is the relative path from the component to src/
+	root       string
+	primitives map[string]bool
+	reactHooks map[string]bool
+	hooks      []string
+	imports    []string
+	navigation bool
+	assets     bool
+	sheet      *styleSheet
+	// params are the props of the component, and early the roots it
+	// returns for some values of its variant props
+	params []renderers.TemplateProp
+	early  []branch
+}
+
+// branch is a root a component returns while condition holds
+type branch struct {
+	condition string
+	root      *renderers.JSXElement
+}
+
+func (t *Target) newComponent(root string) *component {
+	return &component{
+		t:          t,
+		root:       root,
+		primitives: make(map[string]bool),
+		reactHooks: make(map[string]bool),
+		sheet:      &styleSheet{objects: make(map[string]styleObject)},
+	}
+}
+
+// use imports a React Native primitive or API
+func (c *component) use(name string) {
+	c.primitives[name] = true
+}
+
+var headingTags = map[string]bool{"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true}
+
+// isComponentTag reports whether a tag references a component, like the
+// organisms of a page
+func isComponentTag(tag string) bool {
+	return tag != "" && tag[0] >= 'A' && tag[0] <= 'Z'
+}
+
+func attr(el *renderers.Element, name string) (string, bool) {
+	for _, a := range el.Attrs {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// attrProp returns the component prop an attribute is bound to, or ""
+func attrProp(el *renderers.Element, name string) string {
+	for _, a := range el.Attrs {
+		if a.Name == name {
+			return a.Prop
+		}
+	}
+	return ""
+}
+
+// nodes converts elements, leaving out those hidden on phones
+func (c *component) nodes(elements []*renderers.Element, inherited map[string]interface{}) []renderers.JSXNode {
+	var nodes []renderers.JSXNode
+	for _, el := range elements {
+		if n := c.node(el, inherited); n != nil {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// node converts an element to the React Native primitive standing for it,
+// or returns nil when the element is hidden on phones. inherited holds the
+// text styles of its ancestors: Views can't pass them down like CSS does,
+// so every Text applies them itself.
+func (c *component) node(el *renderers.Element, inherited map[string]interface{}) *renderers.JSXElement {
+	switch {
+	case el.Tag == "":
+		return &renderers.JSXElement{Children: c.nodes(el.Children, inherited)}
+	case isComponentTag(el.Tag):
+		n := &renderers.JSXElement{Tag: el.Tag}
+		for _, prop := range el.Attrs {
+			n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: prop.Name, Value: prop.Value})
+		}
+		return n
+	case mobile.Hidden(el.Style, c.t.phone):
+		return nil
+	}
+
+	switch el.Tag {
+	case "img":
+		return c.image(el)
+	case "input":
+		return c.textInput(el, inherited)
+	case "button":
+		return c.pressable(el, inherited)
+	case "a":
+		return c.link(el, inherited)
+	}
+	if len(el.Children) == 0 && (el.Text != "" || el.TextProp != "" || headingTags[el.Tag]) {
+		return c.text(el, inherited)
+	}
+
+	c.use("View")
+	own := mobile.PhoneStyles(el.Style, c.t.phone, nil)
+	n := &renderers.JSXElement{Tag: "View", Children: c.nodes(el.Children, mobile.Inherit(inherited, own))}
+	if el.Style != nil {
+		style(n, c.sheet.add(el.Style.Class, c.t.styles.compile(own).withoutText()))
+	}
+	return n
+}
+
+// styleName returns the StyleSheet key of an element, or fallback when it
+// has no class (and only inherits styles)
+func styleName(el *renderers.Element, fallback string) string {
+	if el.Style != nil {
+		return el.Style.Class
+	}
+	return fallback
+}
+
+// style references a StyleSheet entry
+func style(n *renderers.JSXElement, key string) {
+	if key != "" {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "style", Expr: "styles." + key})
+	}
+}
+
+func (c *component) text(el *renderers.Element, inherited map[string]interface{}) *renderers.JSXElement {
+	c.use("Text")
+	n := elementText("Text", el)
+	if headingTags[el.Tag] {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "accessibilityRole", Value: "header"})
+	}
+	style(n, c.sheet.add(styleName(el, "text"), c.t.styles.compile(mobile.PhoneStyles(el.Style, c.t.phone, inherited))))
+	return n
+}
+
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+func (c *component) image(el *renderers.Element) *renderers.JSXElement {
+	c.use("Image")
+	n := &renderers.JSXElement{Tag: "Image"}
+
+	if src, ok := attr(el, "src"); ok {
+		source := renderers.JSString(src)
+		if prop := attrProp(el, "src"); prop != "" {
+			source = prop
+		}
+		if !schemePattern.MatchString(src) && !strings.HasPrefix(src, "//") {
+			// Paths of the website, see src/assets.js
+			c.assets = true
+			source = fmt.Sprintf("asset(%s)", source)
+		}
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "source", Expr: fmt.Sprintf("{ uri: %s }", source)})
+	}
+	if alt, ok := attr(el, "alt"); ok {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "accessibilityLabel", Value: alt})
+	}
+
+	// Remote images have no intrinsic size: keep the configured one
+	styles := mobile.PhoneStyles(el.Style, c.t.phone, nil)
+	for _, dimension := range []string{"width", "height"} {
+		value, ok := attr(el, dimension)
+		if _, styled := styles[dimension]; ok && !styled {
+			if size, err := strconv.ParseFloat(value, 64); err == nil {
+				styles[dimension] = size
+			} else {
+				styles[dimension] = value
+			}
+		}
+	}
+	style(n, c.sheet.add(styleName(el, "image"), c.t.styles.compile(styles)))
+	return n
+}
+
+// inputTypes maps HTML input types to the TextInput props giving them the
+// right keyboard
+var inputTypes = map[string][]renderers.JSXAttr{
+	"email":    {{Name: "keyboardType", Value: "email-address"}, {Name: "autoCapitalize", Value: "none"}},
+	"number":   {{Name: "keyboardType", Value: "numeric"}},
+	"password": {{Name: "secureTextEntry", Boolean: true}},
+	"search":   {{Name: "returnKeyType", Value: "search"}},
+	"tel":      {{Name: "keyboardType", Value: "phone-pad"}},
+	"url":      {{Name: "keyboardType", Value: "url"}, {Name: "autoCapitalize", Value: "none"}},
+}
+
+func (c *component) textInput(el *renderers.Element, inherited map[string]interface{}) *renderers.JSXElement {
+	c.use("TextInput")
+	n := &renderers.JSXElement{Tag: "TextInput"}
+
+	if placeholder, ok := attr(el, "placeholder"); ok {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "placeholder", Value: placeholder})
+	}
+	if label, ok := attr(el, "aria-label"); ok {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "accessibilityLabel", Value: label})
+	}
+	if inputType, ok := attr(el, "type"); ok {
+		n.Attrs = append(n.Attrs, inputTypes[inputType]...)
+	}
+	if _, disabled := attr(el, "disabled"); disabled {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "editable", Expr: "false"})
+	}
+	style(n, c.sheet.add(styleName(el, "input"), c.t.styles.compile(mobile.PhoneStyles(el.Style, c.t.phone, mobile.FontOnly(inherited)))))
+	return n
+}
+
+// pressable converts a button. Pressables can't hold text styles, so its
+// label is a Text with them. The box styles of its active state apply
+// while it is pressed.
+func (c *component) pressable(el *renderers.Element, inherited map[string]interface{}) *renderers.JSXElement {
+	c.use("Pressable")
+	c.use("Text")
+	n := &renderers.JSXElement{Tag: "Pressable", Attrs: []renderers.JSXAttr{{Name: "accessibilityRole", Value: "button"}}}
+	if _, disabled := attr(el, "disabled"); disabled {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "disabled", Boolean: true})
+	}
+	label := elementText("Text", el)
+	n.Children = []renderers.JSXNode{label}
+
+	name := styleName(el, "button")
+	box, text := c.t.styles.compile(mobile.PhoneStyles(el.Style, c.t.phone, mobile.FontOnly(inherited))).split()
+	boxKey := c.sheet.add(name, box)
+	style(label, c.sheet.add(name+"Text", text))
+
+	pressedKey := ""
+	if el.Style != nil && len(el.Style.States["active"]) > 0 && !el.Style.Disabled {
+		pressed, _ := c.t.styles.compile(el.Style.States["active"]).split()
+		pressedKey = c.sheet.add(name+"Pressed", pressed)
+	}
+	if pressedKey == "" {
+		style(n, boxKey)
+		return n
+	}
+
+	var layers []string
+	if boxKey != "" {
+		layers = append(layers, "styles."+boxKey)
+	}
+	layers = append(layers, "pressed && styles."+pressedKey)
+	n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "style", Expr: fmt.Sprintf("({ pressed }) => [%s]", strings.Join(layers, ", "))})
+	return n
+}
+
+// link converts an anchor to a pressable Text. Links to a page of the app
+// navigate to its screen and URLs open with Linking; other paths of the
+// website can't open in the app.
+func (c *component) link(el *renderers.Element, inherited map[string]interface{}) *renderers.JSXElement {
+	c.use("Text")
+	n := elementText("Text", el, renderers.JSXAttr{Name: "accessibilityRole", Value: "link"})
+	if label, ok := attr(el, "aria-label"); ok {
+		n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "accessibilityLabel", Value: label})
+	}
+
+	if href, ok := attr(el, "href"); ok {
+		if prop := attrProp(el, "href"); prop != "" {
+			// Only URLs can open when the destination is set at run time
+			if schemePattern.MatchString(href) {
+				c.use("Linking")
+				n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "onPress", Expr: fmt.Sprintf("() => Linking.openURL(%s)", prop)})
+			}
+		} else if screen := c.t.screenFor(href); screen != "" {
+			c.navigation = true
+			n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "onPress", Expr: fmt.Sprintf("() => navigation.navigate(%s)", renderers.JSString(screen))})
+		} else if schemePattern.MatchString(href) {
+			c.use("Linking")
+			n.Attrs = append(n.Attrs, renderers.JSXAttr{Name: "onPress", Expr: fmt.Sprintf("() => Linking.openURL(%s)", renderers.JSString(href))})
+		}
+	}
+
+	style(n, c.sheet.add(styleName(el, "link"), c.t.styles.compile(mobile.PhoneStyles(el.Style, c.t.phone, inherited))))
+	return n
+}
+
+// screenFor returns the screen of the page served at href, or ""
+func (t *Target) screenFor(href string) string {
+	if !strings.HasPrefix(href, "/") {
+		return ""
+	}
+	if len(href) > 1 {
+		href = strings.TrimRight(href, "/")
+	}
+	structure := t.ctx.Registry.Structure()
+	for i := range structure.Pages {
+		page := &structure.Pages[i]
+		if renderers.PageRoute(t.ctx.Registry, page) == href {
+			return screenName(page)
+		}
+	}
+	return ""
+}
+
+// carousel turns an organism's root into a horizontal pager with one
+// screen-wide page per slide, scrolled automatically with autoplay
+func (c *component) carousel(root *renderers.JSXElement, behavior *models.Behavior) {
+	c.use("ScrollView")
+	c.use("View")
+	c.use("useWindowDimensions")
+	c.hooks = append(c.hooks, "const { width } = useWindowDimensions();")
+
+	root.Tag = "ScrollView"
+	root.Attrs = append(root.Attrs,
+		renderers.JSXAttr{Name: "horizontal", Boolean: true},
+		renderers.JSXAttr{Name: "pagingEnabled", Boolean: true},
+		renderers.JSXAttr{Name: "showsHorizontalScrollIndicator", Expr: "false"},
+	)
+	for i, slide := range root.Children {
+		root.Children[i] = &renderers.JSXElement{
+			Tag:      "View",
+			Attrs:    []renderers.JSXAttr{{Name: "style", Expr: "{ width }"}},
+			Children: []renderers.JSXNode{slide},
+		}
+	}
+
+	if !behavior.Autoplay || len(root.Children) < 2 {
+		return
+	}
+	c.reactHooks["useEffect"] = true
+	c.reactHooks["useRef"] = true
+	root.Attrs = append([]renderers.JSXAttr{{Name: "ref", Expr: "carousel"}}, root.Attrs...)
+	c.hooks = append(c.hooks, fmt.Sprintf(`const carousel = useRef(null);
+
+useEffect(() => {
+  let current = 0;
+  const interval = setInterval(() => {
+    current = (current + 1) %% %d;
+    carousel.current?.scrollTo({ x: current * width, animated: true });
+  }, %d);
+  return () => clearInterval(interval);
+}, [width]);`, len(root.Children), behavior.Interval))
+}
+
+// branches declares the props of a molecule or organism as the
+// component's props and returns the root it returns last. Variant props
+// can't drive states: the component returns early with a root per value,
+// built by build with the variants fixed so far and the default value
+// last, needing no condition. conditions are those of the enclosing
+// values.
+func (c *component) branches(props []renderers.TemplateProp, fixed map[string]string, conditions []string, build func(variants map[string]string) (*renderers.JSXElement, error)) (*renderers.JSXElement, error) {
+	if fixed == nil {
+		c.params = props
+	}
+	for _, prop := range props {
+		if _, isFixed := fixed[prop.Name]; isFixed || len(prop.Variants) == 0 {
+			continue
+		}
+
+		variants := mobile.DefaultLast(prop.Variants, prop.Default)
+		var last *renderers.JSXElement
+		for i, variant := range variants {
+			variantsFixed := map[string]string{prop.Name: variant}
+			for name, value := range fixed {
+				variantsFixed[name] = value
+			}
+			branchConditions := conditions
+			if i < len(variants)-1 {
+				branchConditions = append(slices.Clip(conditions), fmt.Sprintf("%s === %s", prop.Name, renderers.JSString(variant)))
+			}
+			root, err := c.branches(props, variantsFixed, branchConditions, build)
+			if err != nil {
+				return nil, err
+			}
+			if i < len(variants)-1 {
+				c.early = append(c.early, branch{condition: strings.Join(branchConditions, " && "), root: root})
+			} else {
+				last = root
+			}
+		}
+		return last, nil
+	}
+	return build(fixed)
+}
+
+// file returns the component's source file
+func (c *component) file(dir, name string, root *renderers.JSXElement) []targets.File {
+	imports := []string{"import React from 'react';"}
+	if len(c.reactHooks) > 0 {
+		imports[0] = fmt.Sprintf("import React, { %s } from 'react';", strings.Join(renderers.SortedKeys(c.reactHooks), ", "))
+	}
+	if !c.sheet.empty() {
+		c.use("StyleSheet")
+	}
+	if len(c.primitives) > 0 {
+		imports = append(imports, fmt.Sprintf("import { %s } from 'react-native';", strings.Join(renderers.SortedKeys(c.primitives), ", ")))
+	}
+	hooks := c.hooks
+	if c.navigation {
+		imports = append(imports, "import { useNavigation } from '@react-navigation/native';")
+		hooks = append([]string{"const navigation = useNavigation();"}, hooks...)
+	}
+	if c.assets {
+		imports = append(imports, fmt.Sprintf("import { asset } from '%s/assets';", c.root))
+	}
+	imports = append(imports, c.imports...)
+
+	declarations := ""
+	if len(hooks) > 0 {
+		declarations = renderers.IndentCode(strings.Join(hooks, "\n"), 1) + "\n\n"
+	}
+
+	jsx := "    null"
+	if root != nil {
+		jsx = renderers.PrintJSX(root, 2)
+	}
+	for _, b := range c.early {
+		early := "      null"
+		if b.root != nil {
+			early = renderers.PrintJSX(b.root, 3)
+		}
+		declarations += fmt.Sprintf("  if (%s) {\n    return (\n%s\n    );\n  }\n\n", b.condition, early)
+	}
+
+	var params []string
+	for _, prop := range c.params {
+		if prop.Default != "" {
+			params = append(params, fmt.Sprintf("%s = %s", prop.Name, renderers.JSString(prop.Default)))
+		} else {
+			params = append(params, prop.Name)
+		}
+	}
+	signature := "()"
+	if len(params) > 0 {
+		signature = "({ " + strings.Join(params, ", ") + " })"
+	}
+
+	styles := ""
+	if !c.sheet.empty() {
+		styles = "\n" + c.sheet.String() + "\n"
+	}
+
+	content := fmt.Sprintf(`%s
+
+const %s = %s => {
+%s  return (
+%s
+  );
+};
+%s
+export default %s;
+`, strings.Join(imports, "\n"), name, signature, declarations, jsx, styles, name)
+
+	return []targets.File{{Path: fmt.Sprintf("%s/%s.js", dir, name), Content: content}}
+}
+
+// styleSheet collects the entries of a component's StyleSheet.create call
+type styleSheet struct {
+	names   []string
+	objects map[string]styleObject
+}
+
+// add registers a style object and returns the key referencing it, or ""
+// when it is empty. An element rendered twice reuses its entry; a
+// different object claiming the name gets a suffix.
+func (ss *styleSheet) add(name string, obj styleObject) string {
+	if obj.empty() {
+		return ""
+	}
+	key := name
+	for i := 2; ; i++ {
+		existing, taken := ss.objects[key]
+		if !taken {
+			break
+		}
+		if reflect.DeepEqual(existing, obj) {
+			return key
+		}
+		key = fmt.Sprintf("%s%d", name, i)
+	}
+	ss.names = append(ss.names, key)
+	ss.objects[key] = obj
+	return key
+}
+
+func (ss *styleSheet) empty() bool {
+	return len(ss.names) == 0
+}
+
+// String returns the StyleSheet.create call, noting the properties React
+// Native can't apply above the entry they were dropped from
+func (ss *styleSheet) String() string {
+	var entries []string
+	for _, name := range ss.names {
+		obj := ss.objects[name]
+		var lines []string
+		if len(obj.unsupported) > 0 {
+			lines = append(lines, "// Not supported by React Native: "+strings.Join(obj.unsupported, ", "))
+		}
+		for _, prop := range renderers.SortedKeys(obj.values) {
+			lines = append(lines, fmt.Sprintf("%s: %s,", prop, obj.values[prop]))
+		}
+		entries = append(entries, fmt.Sprintf("  %s: {\n    %s\n  },", name, strings.Join(lines, "\n    ")))
+	}
+	return fmt.Sprintf("const styles = StyleSheet.create({\n%s\n});", strings.Join(entries, "\n"))
+}
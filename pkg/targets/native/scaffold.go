@@ -0,0 +1,150 @@
+package native
+
+import (
+	"fmt"
+	"strings"
+
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+// Scaffold returns the Expo project around the components: config files,
+// the asset helper and the navigation stack
+func (t *Target) Scaffold() ([]targets.File, error) {
+	return []targets.File{
+		{Path: "package.json", Content: t.packageJSON()},
+		{Path: "app.json", Content: t.appJSON()},
+		{Path: "babel.config.js", Content: babelConfig},
+		{Path: ".gitignore", Content: `node_modules
+.expo
+dist
+.DS_Store
+*.log
+.env
+.env.local
+`},
+		{Path: "README.md", Content: t.readme()},
+		{Path: "src/assets.js", Content: assetsJS},
+		{Path: "App.js", Content: t.app()},
+	}, nil
+}
+
+func (t *Target) packageJSON() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "version": "%s",
+  "private": true,
+  "main": "node_modules/expo/AppEntry.js",
+  "scripts": {
+    "start": "expo start",
+    "android": "expo start --android",
+    "ios": "expo start --ios"
+  },
+  "dependencies": {
+    "@react-navigation/native": "^6.1.9",
+    "@react-navigation/native-stack": "^6.9.17",
+    "expo": "~50.0.0",
+    "expo-linking": "~6.2.2",
+    "react": "18.2.0",
+    "react-native": "0.73.2",
+    "react-native-safe-area-context": "4.8.2",
+    "react-native-screens": "~3.29.0"
+  },
+  "devDependencies": {
+    "@babel/core": "^7.20.0"
+  }
+}
+`, project.ID, project.Version)
+}
+
+// appJSON returns the Expo config. The scheme is what deep links to the
+// pages' routes open the app with.
+func (t *Target) appJSON() string {
+	project := t.ctx.Registry.Structure().Project
+	scheme := strings.ReplaceAll(strings.ToLower(project.ID), "_", "-")
+	return fmt.Sprintf(`{
+  "expo": {
+    "name": "%s",
+    "slug": "%s",
+    "version": "%s",
+    "scheme": "%s",
+    "orientation": "portrait"
+  }
+}
+`, project.Name, scheme, project.Version, scheme)
+}
+
+const babelConfig = `module.exports = function (api) {
+  api.cache(true);
+  return {
+    presets: ['babel-preset-expo'],
+  };
+};
+`
+
+// assetsJS resolves the image paths of the structure, which are paths of
+// the website
+const assetsJS = `// Image paths such as /images/logo.png are served by the website: set the
+// URL it is served from so the app can load them
+export const assetBaseURL = '';
+
+export const asset = (path) => assetBaseURL + path;
+`
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nThis React Native (Expo) app was automatically generated from an atomic design structure.\n\n## Getting Started\n\n```bash\n# Install dependencies\nnpm install\n\n# Start Expo\nnpx expo start\n```\n\n## Notes\n\n- Styles are resolved for a phone: brand variables, `rem`, viewport units and `clamp()` become numbers, and the narrowest breakpoint's overrides apply. Properties React Native can't apply are listed in a comment above their `StyleSheet` entry.\n- Image paths are relative to the website: set `assetBaseURL` in `src/assets.js`.\n- Brand fonts must be loaded (e.g. with `expo-font`) before they render.\n\n## Project Structure\n\n- `App.js` - Navigation stack with one screen per page\n- `src/components/atoms` - Basic UI elements\n- `src/components/molecules` - Combinations of atoms\n- `src/components/organisms` - Complex UI sections\n- `src/screens` - Page screens\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
+
+// app returns the root component: a stack navigator with a screen per page,
+// starting at the / page. Deep links to a page's route open its screen.
+func (t *Target) app() string {
+	structure := t.ctx.Registry.Structure()
+
+	var imports, screens, paths []string
+	initial := ""
+	for i := range structure.Pages {
+		page := &structure.Pages[i]
+		name := screenName(page)
+		route := renderers.PageRoute(t.ctx.Registry, page)
+		if route == "/" {
+			initial = name
+		}
+
+		imports = append(imports, fmt.Sprintf("import %sScreen from './src/screens/%sScreen';", name, name))
+		screens = append(screens, fmt.Sprintf("<Stack.Screen name=\"%s\" component={%sScreen} options={{ title: %s }} />", name, name, renderers.JSString(page.Title)))
+		paths = append(paths, fmt.Sprintf("%s: %s,", name, renderers.JSString(strings.TrimPrefix(route, "/"))))
+	}
+	if initial == "" && len(structure.Pages) > 0 {
+		initial = screenName(&structure.Pages[0])
+	}
+
+	return fmt.Sprintf(`import React from 'react';
+import { NavigationContainer } from '@react-navigation/native';
+import { createNativeStackNavigator } from '@react-navigation/native-stack';
+import * as Linking from 'expo-linking';
+%s
+
+const Stack = createNativeStackNavigator();
+
+const linking = {
+  prefixes: [Linking.createURL('/')],
+  config: {
+    screens: {
+      %s
+    },
+  },
+};
+
+export default function App() {
+  return (
+    <NavigationContainer linking={linking}>
+      <Stack.Navigator initialRouteName="%s">
+        %s
+      </Stack.Navigator>
+    </NavigationContainer>
+  );
+}
+`, strings.Join(imports, "\n"), strings.Join(paths, "\n      "), initial, strings.Join(screens, "\n        "))
+}
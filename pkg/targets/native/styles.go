@@ -0,0 +1,387 @@
+package native
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+// webOnlyProperties have no React Native equivalent and are dropped
+var webOnlyProperties = map[string]bool{
+	"animation":           true,
+	"backdropFilter":      true,
+	"backgroundImage":     true,
+	"backgroundPosition":  true,
+	"backgroundRepeat":    true,
+	"backgroundSize":      true,
+	"boxShadow":           true,
+	"boxSizing":           true,
+	"content":             true,
+	"cursor":              true,
+	"filter":              true,
+	"gridArea":            true,
+	"gridColumn":          true,
+	"gridRow":             true,
+	"gridTemplateAreas":   true,
+	"gridTemplateColumns": true,
+	"gridTemplateRows":    true,
+	"listStyle":           true,
+	"objectPosition":      true,
+	"order":               true,
+	"outline":             true,
+	"outlineOffset":       true,
+	"scrollBehavior":      true,
+	"textOverflow":        true,
+	"textShadow":          true,
+	"transition":          true,
+	"userSelect":          true,
+	"whiteSpace":          true,
+	"willChange":          true,
+}
+
+// textProperties only apply to Text (and TextInput): Views neither use
+// them nor pass them down to their children
+var textProperties = map[string]bool{
+	"color":              true,
+	"fontFamily":         true,
+	"fontSize":           true,
+	"fontStyle":          true,
+	"fontWeight":         true,
+	"letterSpacing":      true,
+	"lineHeight":         true,
+	"textAlign":          true,
+	"textDecorationLine": true,
+	"textTransform":      true,
+}
+
+// unitlessProperties take plain numbers, not lengths
+var unitlessProperties = map[string]bool{
+	"aspectRatio": true,
+	"flex":        true,
+	"flexGrow":    true,
+	"flexShrink":  true,
+	"opacity":     true,
+	"zIndex":      true,
+}
+
+// styleObject is one entry of a StyleSheet.create call
+type styleObject struct {
+	// values maps React Native properties to JS values
+	values map[string]string
+	// unsupported lists the source properties that were dropped
+	unsupported []string
+}
+
+func (obj styleObject) empty() bool {
+	return len(obj.values) == 0 && len(obj.unsupported) == 0
+}
+
+// split moves the text properties to a second object, for elements that
+// wrap their text in a Text (Pressable)
+func (obj styleObject) split() (styleObject, styleObject) {
+	box := styleObject{values: make(map[string]string), unsupported: obj.unsupported}
+	text := styleObject{values: make(map[string]string)}
+	for prop, value := range obj.values {
+		if textProperties[prop] {
+			text.values[prop] = value
+		} else {
+			box.values[prop] = value
+		}
+	}
+	return box, text
+}
+
+// withoutText drops the text properties of a View's styles, which its
+// Text descendants inherit instead
+func (obj styleObject) withoutText() styleObject {
+	box, _ := obj.split()
+	return box
+}
+
+// styleCompiler converts CSS style maps to React Native styles, resolving
+// var() references against the brand tokens
+type styleCompiler struct {
+	tokens *mobile.Tokens
+}
+
+func newStyleCompiler(brand models.Brand) *styleCompiler {
+	return &styleCompiler{tokens: mobile.NewTokens(brand)}
+}
+
+// compile converts a style map to a StyleSheet entry
+func (sc *styleCompiler) compile(styles map[string]interface{}) styleObject {
+	obj := styleObject{values: make(map[string]string)}
+
+	// Unitless line heights multiply the font size
+	fontSize := float64(mobile.RootFontSize)
+	for key, value := range styles {
+		if mobile.CamelCase(key) == "fontSize" {
+			if size, ok := sc.tokens.Pixels(value); ok {
+				fontSize = size
+			}
+		}
+	}
+
+	for _, key := range renderers.SortedKeys(styles) {
+		prop := mobile.CamelCase(key)
+		// Shorthands set several properties: keep none if one fails
+		values := make(map[string]string)
+		if webOnlyProperties[prop] || !sc.property(prop, styles[key], fontSize, values) {
+			obj.unsupported = append(obj.unsupported, prop)
+			continue
+		}
+		for p, value := range values {
+			obj.values[p] = value
+		}
+	}
+	return obj
+}
+
+// property converts one CSS property to the React Native properties it
+// maps to, reporting false when it has no equivalent
+func (sc *styleCompiler) property(prop string, value interface{}, fontSize float64, out map[string]string) bool {
+	var raw string
+	switch v := value.(type) {
+	case float64, int:
+		n, _ := sc.tokens.Pixels(v)
+		switch {
+		case prop == "fontWeight":
+			out[prop] = renderers.JSString(mobile.FormatNumber(n))
+		case prop == "lineHeight":
+			out[prop] = mobile.FormatNumber(n * fontSize)
+		default:
+			out[prop] = mobile.FormatNumber(n)
+		}
+		return true
+	case string:
+		resolved, ok := sc.tokens.Resolve(v)
+		if !ok {
+			return false
+		}
+		raw = strings.TrimSpace(resolved)
+	default:
+		return false
+	}
+
+	switch prop {
+	case "fontFamily":
+		// React Native takes a single family, without fallbacks
+		family := strings.TrimSpace(strings.Split(raw, ",")[0])
+		out[prop] = renderers.JSString(strings.Trim(family, `'"`))
+	case "fontWeight":
+		out[prop] = renderers.JSString(raw)
+	case "lineHeight":
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			out[prop] = mobile.FormatNumber(n * fontSize)
+			return true
+		}
+		return sc.length(prop, raw, out)
+	case "padding", "margin":
+		return sc.box(prop, raw, out)
+	case "gap":
+		values := mobile.SplitValues(raw)
+		if len(values) == 2 {
+			return sc.length("rowGap", values[0], out) && sc.length("columnGap", values[1], out)
+		}
+		return sc.length(prop, raw, out)
+	case "border", "borderTop", "borderRight", "borderBottom", "borderLeft":
+		return sc.border(prop, raw, out)
+	case "flex":
+		values := mobile.SplitValues(raw)
+		if len(values) == 1 {
+			return sc.number(prop, raw, out)
+		}
+		ok := sc.number("flexGrow", values[0], out) && sc.number("flexShrink", values[1], out)
+		if len(values) > 2 {
+			ok = ok && sc.length("flexBasis", values[2], out)
+		}
+		return ok
+	case "background":
+		// Only a plain color maps to React Native; gradients, images and
+		// layered backgrounds are reported as unsupported
+		values := mobile.SplitValues(raw)
+		if len(values) != 1 || mobile.HasFunction(raw) {
+			return false
+		}
+		if raw == "none" {
+			raw = "transparent"
+		}
+		out["backgroundColor"] = renderers.JSString(raw)
+	case "transform":
+		return sc.transform(raw, out)
+	case "textDecoration":
+		out["textDecorationLine"] = renderers.JSString(mobile.SplitValues(raw)[0])
+	case "display":
+		switch raw {
+		case "none":
+			out[prop] = renderers.JSString(raw)
+		case "flex", "inline-flex":
+			// Every View is a flex container, but CSS rows by default; an
+			// explicit flexDirection sorts after display and wins
+			out["flexDirection"] = renderers.JSString("row")
+		case "block", "inline", "inline-block":
+			// Views stack like blocks already
+		default:
+			return false
+		}
+	case "position":
+		if raw != "absolute" && raw != "relative" {
+			return false
+		}
+		out[prop] = renderers.JSString(raw)
+	default:
+		if unitlessProperties[prop] {
+			if n, err := strconv.ParseFloat(raw, 64); err == nil {
+				out[prop] = mobile.FormatNumber(n)
+				return true
+			}
+			out[prop] = renderers.JSString(raw)
+			return true
+		}
+		if _, isLength := lengthValue(raw); isLength {
+			return sc.length(prop, raw, out)
+		}
+		if mobile.HasFunction(raw) {
+			return false
+		}
+		out[prop] = renderers.JSString(raw)
+	}
+	return true
+}
+
+// length sets prop to a length, a percentage or a keyword such as auto
+func (sc *styleCompiler) length(prop, raw string, out map[string]string) bool {
+	if value, ok := lengthValue(raw); ok {
+		out[prop] = value
+		return true
+	}
+	if mobile.HasFunction(raw) || strings.Contains(raw, " ") {
+		return false
+	}
+	out[prop] = renderers.JSString(raw)
+	return true
+}
+
+// number sets prop to a plain number
+func (sc *styleCompiler) number(prop, raw string, out map[string]string) bool {
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return false
+	}
+	out[prop] = mobile.FormatNumber(n)
+	return true
+}
+
+// box expands the padding and margin shorthands, which React Native only
+// accepts with a single value
+func (sc *styleCompiler) box(prop, raw string, out map[string]string) bool {
+	values := mobile.SplitValues(raw)
+	var props []string
+	switch len(values) {
+	case 1:
+		props = []string{prop}
+	case 2:
+		props = []string{prop + "Vertical", prop + "Horizontal"}
+	case 3:
+		props = []string{prop + "Top", prop + "Horizontal", prop + "Bottom"}
+	case 4:
+		props = []string{prop + "Top", prop + "Right", prop + "Bottom", prop + "Left"}
+	default:
+		return false
+	}
+	for i, p := range props {
+		if !sc.length(p, values[i], out) {
+			return false
+		}
+	}
+	return true
+}
+
+var borderStyles = map[string]bool{"solid": true, "dashed": true, "dotted": true}
+
+// border expands border shorthands ("1px solid #ccc") to width, style and
+// color. React Native has a single borderStyle for all sides.
+func (sc *styleCompiler) border(prop, raw string, out map[string]string) bool {
+	if raw == "none" || raw == "0" {
+		out[prop+"Width"] = "0"
+		return true
+	}
+	for _, part := range mobile.SplitValues(raw) {
+		switch {
+		case borderStyles[part]:
+			out["borderStyle"] = renderers.JSString(part)
+		case part == "none":
+			out[prop+"Width"] = "0"
+		default:
+			if n, ok := mobile.Pixels(part); ok {
+				out[prop+"Width"] = mobile.FormatNumber(n)
+			} else {
+				out[prop+"Color"] = renderers.JSString(part)
+			}
+		}
+	}
+	return true
+}
+
+var transformPattern = regexp.MustCompile(`(\w+)\(([^()]*)\)`)
+
+// transform converts CSS transform functions to React Native's array of
+// single-property objects
+func (sc *styleCompiler) transform(raw string, out map[string]string) bool {
+	var steps []string
+	add := func(name, value string) {
+		steps = append(steps, "{ "+name+": "+value+" }")
+	}
+	for _, match := range transformPattern.FindAllStringSubmatch(raw, -1) {
+		name := match[1]
+		args := mobile.SplitArgs(match[2])
+		switch name {
+		case "translate", "translateX", "translateY":
+			axes := []string{"translateX", "translateY"}
+			if name != "translate" {
+				axes = []string{name}
+			}
+			for i, arg := range args {
+				value, ok := lengthValue(arg)
+				if !ok || i >= len(axes) {
+					return false
+				}
+				add(axes[i], value)
+			}
+		case "scale", "scaleX", "scaleY":
+			n, err := strconv.ParseFloat(args[0], 64)
+			if err != nil {
+				return false
+			}
+			add(name, mobile.FormatNumber(n))
+		case "rotate", "rotateX", "rotateY", "rotateZ", "skewX", "skewY":
+			add(name, renderers.JSString(args[0]))
+		default:
+			return false
+		}
+	}
+	if len(steps) == 0 {
+		return false
+	}
+	out["transform"] = "[" + strings.Join(steps, ", ") + "]"
+	return true
+}
+
+// lengthValue converts a CSS length to a JS value: a number of pixels, or a
+// quoted percentage
+func lengthValue(raw string) (string, bool) {
+	if strings.HasSuffix(raw, "%") {
+		if _, err := strconv.ParseFloat(strings.TrimSuffix(raw, "%"), 64); err == nil {
+			return renderers.JSString(raw), true
+		}
+		return "", false
+	}
+	if n, ok := mobile.Pixels(raw); ok {
+		return mobile.FormatNumber(n), true
+	}
+	return "", false
+}
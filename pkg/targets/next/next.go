@@ -0,0 +1,206 @@
+// Package next is the Next.js App Router target: the React components of
+// the react target, served by app/<route>/page files with metadata
+// exports.
+package next
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+func init() {
+	targets.Register("next", New)
+}
+
+// Target emits a Next.js project using the App Router
+type Target struct {
+	ctx *renderers.Context
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	return &Target{ctx: ctx}, nil
+}
+
+func (t *Target) Name() string {
+	return "next"
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		"app",
+		"components",
+		"components/atoms",
+		"components/molecules",
+		"components/organisms",
+		"styles",
+		"public",
+	}
+}
+
+// lang returns the language of the generated sources
+func (t *Target) lang() renderers.Lang {
+	return t.ctx.Options.Lang
+}
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	return t.component("components/atoms", atom.ID, renderers.NewAtomRenderer(atom, t.ctx))
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	return t.component("components/molecules", molecule.ID, renderers.NewMoleculeRenderer(molecule, t.ctx))
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	return t.component("components/organisms", organism.ID, renderers.NewOrganismRenderer(organism, t.ctx))
+}
+
+// component renders a component into dir, next to its CSS Module when it
+// has one. Components are server components unless they hold state.
+func (t *Target) component(dir, id string, renderer renderers.ComponentRenderer) ([]targets.File, error) {
+	component, err := renderer.RenderAsComponent()
+	if err != nil {
+		return nil, err
+	}
+	if renderer.Interactive() {
+		component = "'use client';\n\n" + component
+	}
+
+	componentName := renderers.ToPascalCase(id)
+	files := []targets.File{{
+		Path:    fmt.Sprintf("%s/%s%s", dir, componentName, t.lang().ComponentExt()),
+		Content: component,
+	}}
+
+	if css := renderer.StyleSheet(); css != "" {
+		files = append(files, targets.File{
+			Path:    fmt.Sprintf("%s/%s.module.css", dir, componentName),
+			Content: css,
+		})
+	}
+
+	return files, nil
+}
+
+// organismImports references organisms from pages and layouts, importing
+// each component once
+type organismImports struct {
+	lines    []string
+	imported map[string]bool
+}
+
+func newOrganismImports() *organismImports {
+	return &organismImports{imported: make(map[string]bool)}
+}
+
+// embed is the MarkupBuilder.Page callback: the element standing for an
+// organism is its component, given the section's prop values
+func (oi *organismImports) embed(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+	name := renderers.ToPascalCase(organism.ID)
+	if !oi.imported[name] {
+		oi.imported[name] = true
+		oi.lines = append(oi.lines, fmt.Sprintf("import %s from '@/components/organisms/%s';", name, name))
+	}
+	el := &renderers.Element{Tag: name, Void: true}
+	for _, prop := range renderers.SortedKeys(props) {
+		el.Attrs = append(el.Attrs, renderers.Attr{Name: prop, Value: props[prop]})
+	}
+	return el, nil
+}
+
+// splitLayout splits a layout around its content, the first section listing
+// several organisms. The sections before and after it (header, footer) are
+// shared by the pages using the layout and go to its route group layout;
+// a layout without such a section shares nothing.
+func splitLayout(layout *models.Layout) (before, content, after []models.LayoutSection) {
+	for i, section := range layout.Structure {
+		if len(section.Organisms) > 0 {
+			return layout.Structure[:i], layout.Structure[i : i+1], layout.Structure[i+1:]
+		}
+	}
+	return nil, layout.Structure, nil
+}
+
+// routeGroup returns the directory of the route group holding the pages of
+// a layout with shared sections, or "app"
+func routeGroup(layout *models.Layout) string {
+	before, _, after := splitLayout(layout)
+	if len(before) == 0 && len(after) == 0 {
+		return "app"
+	}
+	return path.Join("app", "("+layout.ID+")")
+}
+
+// Page writes the page as app/<route>/page, rendering the organisms of its
+// layout that its route group layout doesn't. Pages stay server components
+// so they can export metadata.
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+
+	_, sections, _ := splitLayout(layout)
+	organisms := newOrganismImports()
+	body, err := builder.Page(&models.Layout{ID: layout.ID, Structure: sections}, organisms.embed)
+	if err != nil {
+		return nil, err
+	}
+
+	// A single section needs no fragment
+	if len(body.Children) == 1 {
+		body = body.Children[0]
+	}
+
+	imports := organisms.lines
+	if t.lang() == renderers.LangTS {
+		imports = append([]string{"import type { Metadata } from 'next';"}, imports...)
+	}
+
+	componentName := renderers.ToPascalCase(page.ID)
+	content := fmt.Sprintf(`%s
+
+%s
+
+export default function %s() {
+  return (
+%s
+  );
+}
+`, strings.Join(imports, "\n"), t.metadata(page), componentName, renderers.PrintJSX(renderers.ElementJSX(body), 2))
+
+	dir := path.Join(routeGroup(layout), targets.RouteDir(renderers.PageRoute(t.ctx.Registry, page)))
+	return []targets.File{{
+		Path:    path.Join(dir, "page"+t.lang().ComponentExt()),
+		Content: content,
+	}}, nil
+}
+
+// metadata returns the page's metadata export, which Next.js renders into
+// the <head>
+func (t *Target) metadata(page *models.Page) string {
+	entries := []string{fmt.Sprintf("title: %s,", renderers.JSString(page.Title))}
+	var other []string
+	for _, tag := range renderers.PageMetaTags(page) {
+		value := renderers.JSString(tag.Content)
+		switch tag.Key {
+		case "description", "keywords":
+			entries = append(entries, fmt.Sprintf("%s: %s,", tag.Key, value))
+		case "ogImage":
+			entries = append(entries, fmt.Sprintf("openGraph: {\n    images: [%s],\n  },", value))
+		default:
+			// Tags without a dedicated field, e.g. content-language
+			other = append(other, fmt.Sprintf("%s: %s,", renderers.JSString(tag.Name), value))
+		}
+	}
+	if len(other) > 0 {
+		entries = append(entries, fmt.Sprintf("other: {\n    %s\n  },", strings.Join(other, "\n    ")))
+	}
+
+	declaration := "export const metadata = {"
+	if t.lang() == renderers.LangTS {
+		declaration = "export const metadata: Metadata = {"
+	}
+	return fmt.Sprintf("%s\n  %s\n};", declaration, strings.Join(entries, "\n  "))
+}
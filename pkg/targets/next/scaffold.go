@@ -0,0 +1,225 @@
+package next
+
+import (
+	"fmt"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+// Scaffold returns the Next.js project around the components: config
+// files, global styles, the root layout and the route group layouts
+func (t *Target) Scaffold() ([]targets.File, error) {
+	structure := t.ctx.Registry.Structure()
+
+	files := []targets.File{
+		{Path: "package.json", Content: t.packageJSON()},
+		{Path: "next.config.mjs", Content: nextConfig},
+		{Path: ".gitignore", Content: `node_modules
+.next
+out
+.DS_Store
+*.log
+.env
+.env.local
+`},
+		{Path: "README.md", Content: t.readme()},
+	}
+
+	if t.lang() == renderers.LangTS {
+		files = append(files,
+			targets.File{Path: "tsconfig.json", Content: tsconfig},
+			targets.File{Path: "next-env.d.ts", Content: "/// <reference types=\"next\" />\n/// <reference types=\"next/image-types/global\" />\n"},
+		)
+	} else {
+		files = append(files, targets.File{Path: "jsconfig.json", Content: jsconfig})
+	}
+
+	files = append(files,
+		targets.File{Path: "styles/global.css", Content: targets.GlobalStylesheet(structure.Project.Brand)},
+		targets.File{Path: "app/layout" + t.lang().ComponentExt(), Content: t.rootLayout()},
+	)
+
+	// Only the layouts pages use, each once
+	used := make(map[string]bool)
+	for _, page := range structure.Pages {
+		layout := t.ctx.Registry.Layout(page.Layout)
+		if layout == nil || used[layout.ID] || routeGroup(layout) == "app" {
+			continue
+		}
+		used[layout.ID] = true
+		content, err := t.groupLayout(layout)
+		if err != nil {
+			return nil, fmt.Errorf("error generating layout %s: %w", layout.ID, err)
+		}
+		files = append(files, targets.File{Path: routeGroup(layout) + "/layout" + t.lang().ComponentExt(), Content: content})
+	}
+
+	return files, nil
+}
+
+func (t *Target) packageJSON() string {
+	project := t.ctx.Registry.Structure().Project
+
+	typeScriptDeps := ""
+	if t.lang() == renderers.LangTS {
+		typeScriptDeps = `,
+  "devDependencies": {
+    "@types/node": "^20.10.5",
+    "@types/react": "^18.2.43",
+    "@types/react-dom": "^18.2.17",
+    "typescript": "^5.3.3"
+  }`
+	}
+
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "version": "%s",
+  "private": true,
+  "scripts": {
+    "dev": "next dev",
+    "build": "next build",
+    "start": "next start"
+  },
+  "dependencies": {
+    "next": "^14.0.4",
+    "react": "^18.2.0",
+    "react-dom": "^18.2.0"
+  }%s
+}
+`, project.ID, project.Version, typeScriptDeps)
+}
+
+const nextConfig = `/** @type {import('next').NextConfig} */
+const nextConfig = {};
+
+export default nextConfig;
+`
+
+// Both configs map @/ to the project root, which pages use to import
+// components
+const tsconfig = `{
+  "compilerOptions": {
+    "target": "ES2017",
+    "lib": ["dom", "dom.iterable", "esnext"],
+    "allowJs": true,
+    "skipLibCheck": true,
+    "strict": true,
+    "noEmit": true,
+    "esModuleInterop": true,
+    "module": "esnext",
+    "moduleResolution": "bundler",
+    "resolveJsonModule": true,
+    "isolatedModules": true,
+    "jsx": "preserve",
+    "incremental": true,
+    "plugins": [{ "name": "next" }],
+    "paths": {
+      "@/*": ["./*"]
+    }
+  },
+  "include": ["next-env.d.ts", "**/*.ts", "**/*.tsx", ".next/types/**/*.ts"],
+  "exclude": ["node_modules"]
+}
+`
+
+const jsconfig = `{
+  "compilerOptions": {
+    "paths": {
+      "@/*": ["./*"]
+    }
+  }
+}
+`
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nThis Next.js project was automatically generated from an atomic design structure.\n\n## Getting Started\n\n```bash\n# Install dependencies\nnpm install\n\n# Run development server\nnpm run dev\n\n# Build for production\nnpm run build\n```\n\n## Project Structure\n\n- `app` - Root layout and one `page` per route\n- `components/atoms` - Basic UI elements\n- `components/molecules` - Combinations of atoms\n- `components/organisms` - Complex UI sections\n- `styles` - Global styles and CSS\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
+
+// groupLayout returns the layout of a route group: the sections a layout
+// shares around the content of its pages
+func (t *Target) groupLayout(layout *models.Layout) (string, error) {
+	before, _, after := splitLayout(layout)
+	organisms := newOrganismImports()
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	header, err := builder.Page(&models.Layout{ID: layout.ID, Structure: before}, organisms.embed)
+	if err != nil {
+		return "", err
+	}
+	footer, err := builder.Page(&models.Layout{ID: layout.ID, Structure: after}, organisms.embed)
+	if err != nil {
+		return "", err
+	}
+
+	shell := renderers.ElementJSX(header)
+	shell.Children = append(shell.Children, renderers.JSXExpr("children"))
+	shell.Children = append(shell.Children, renderers.ElementJSX(footer).Children...)
+
+	imports := organisms.lines
+	props := "{ children }"
+	if t.lang() == renderers.LangTS {
+		imports = append([]string{"import type { ReactNode } from 'react';"}, imports...)
+		props = "{ children }: { children: ReactNode }"
+	}
+
+	name := renderers.ToPascalCase(layout.ID)
+	if !strings.HasSuffix(name, "Layout") {
+		name += "Layout"
+	}
+	return fmt.Sprintf(`%s
+
+export default function %s(%s) {
+  return (
+%s
+  );
+}
+`, strings.Join(imports, "\n"), name, props, renderers.PrintJSX(shell, 2)), nil
+}
+
+// rootLayout returns app/layout: the <html> shell every page renders in,
+// with the global styles, the brand fonts and the default title
+func (t *Target) rootLayout() string {
+	project := t.ctx.Registry.Structure().Project
+
+	imports := "import '../styles/global.css';"
+	declaration := "export const metadata = {"
+	props := "{ children }"
+	if t.lang() == renderers.LangTS {
+		imports = "import type { Metadata } from 'next';\nimport type { ReactNode } from 'react';\n" + imports
+		declaration = "export const metadata: Metadata = {"
+		props = "{ children }: { children: ReactNode }"
+	}
+
+	head := ""
+	if project.ThirdParty.Fonts != nil && len(project.ThirdParty.Fonts.Google) > 0 {
+		links := []string{
+			`<link rel="preconnect" href="https://fonts.googleapis.com" />`,
+			`<link rel="preconnect" href="https://fonts.gstatic.com" crossOrigin="" />`,
+		}
+		for _, fontURL := range project.ThirdParty.Fonts.Google {
+			links = append(links, fmt.Sprintf(`<link href="%s" rel="stylesheet" />`, fontURL))
+		}
+		head = fmt.Sprintf(`
+      <head>
+        %s
+      </head>`, strings.Join(links, "\n        "))
+	}
+
+	return fmt.Sprintf(`%s
+
+%s
+  title: %s,
+};
+
+export default function RootLayout(%s) {
+  return (
+    <html lang="es">%s
+      <body>{children}</body>
+    </html>
+  );
+}
+`, imports, declaration, renderers.JSString(project.Name), props, head)
+}
@@ -0,0 +1,96 @@
+// Package react is the React + Vite target: JSX (or TSX) components, React
+// Router pages and react-helmet-async metadata.
+package react
+
+import (
+	"fmt"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+func init() {
+	targets.Register("react", New)
+}
+
+// Target emits a React project built with Vite
+type Target struct {
+	ctx *renderers.Context
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	return &Target{ctx: ctx}, nil
+}
+
+func (t *Target) Name() string {
+	return "react"
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		"src",
+		"src/components",
+		"src/components/atoms",
+		"src/components/molecules",
+		"src/components/organisms",
+		"src/pages",
+		"src/styles",
+		"src/assets",
+		"public",
+	}
+}
+
+// lang returns the language of the generated sources
+func (t *Target) lang() renderers.Lang {
+	return t.ctx.Options.Lang
+}
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	return t.component("src/components/atoms", atom.ID, renderers.NewAtomRenderer(atom, t.ctx))
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	return t.component("src/components/molecules", molecule.ID, renderers.NewMoleculeRenderer(molecule, t.ctx))
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	return t.component("src/components/organisms", organism.ID, renderers.NewOrganismRenderer(organism, t.ctx))
+}
+
+// component renders a component into dir and, when the renderer collected a
+// CSS Module, adds it next to the component file
+func (t *Target) component(dir, id string, renderer renderers.ComponentRenderer) ([]targets.File, error) {
+	component, err := renderer.RenderAsComponent()
+	if err != nil {
+		return nil, err
+	}
+
+	componentName := renderers.ToPascalCase(id)
+	files := []targets.File{{
+		Path:    fmt.Sprintf("%s/%s%s", dir, componentName, t.lang().ComponentExt()),
+		Content: component,
+	}}
+
+	if css := renderer.StyleSheet(); css != "" {
+		files = append(files, targets.File{
+			Path:    fmt.Sprintf("%s/%s.module.css", dir, componentName),
+			Content: css,
+		})
+	}
+
+	return files, nil
+}
+
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	renderer := renderers.NewPageRenderer(page, layout, t.ctx)
+	component, err := renderer.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	return []targets.File{{
+		Path:    fmt.Sprintf("src/pages/%s%s", renderers.ToPascalCase(page.ID), t.lang().ComponentExt()),
+		Content: component,
+	}}, nil
+}
@@ -0,0 +1,224 @@
+package react
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+// Scaffold returns the Vite project around the components: config files,
+// global styles, the router and the entry points
+func (t *Target) Scaffold() ([]targets.File, error) {
+	structure := t.ctx.Registry.Structure()
+
+	files := []targets.File{
+		{Path: "package.json", Content: t.packageJSON()},
+		{Path: "vite.config" + t.lang().ScriptExt(), Content: t.viteConfig()},
+		{Path: ".gitignore", Content: `node_modules
+dist
+.DS_Store
+*.log
+.env
+.env.local
+`},
+		{Path: "README.md", Content: t.readme()},
+	}
+
+	if t.lang() == renderers.LangTS {
+		files = append(files, t.typeScriptFiles()...)
+	}
+
+	files = append(files,
+		targets.File{Path: "src/styles/global.css", Content: targets.GlobalStylesheet(structure.Project.Brand)},
+		targets.File{Path: "src/App" + t.lang().ComponentExt(), Content: t.app()},
+		targets.File{Path: "index.html", Content: t.indexHTML()},
+		targets.File{Path: "src/main" + t.lang().ComponentExt(), Content: t.main()},
+	)
+
+	return files, nil
+}
+
+func (t *Target) packageJSON() string {
+	project := t.ctx.Registry.Structure().Project
+
+	build := "vite build"
+	typeScriptScripts := ""
+	typeScriptDeps := ""
+	if t.lang() == renderers.LangTS {
+		build = "tsc --noEmit && vite build"
+		typeScriptScripts = `,
+    "typecheck": "tsc --noEmit"`
+		typeScriptDeps = `
+    "@types/react": "^18.2.43",
+    "@types/react-dom": "^18.2.17",
+    "typescript": "^5.3.3",`
+	}
+
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "version": "%s",
+  "type": "module",
+  "scripts": {
+    "dev": "vite",
+    "build": "%s",
+    "preview": "vite preview",
+    "lint": "eslint src"%s
+  },
+  "dependencies": {
+    "react": "^18.2.0",
+    "react-dom": "^18.2.0",
+    "react-router-dom": "^6.20.0",
+    "react-helmet-async": "^2.0.4"
+  },
+  "devDependencies": {%s
+    "@vitejs/plugin-react": "^4.2.1",
+    "vite": "^5.0.8",
+    "eslint": "^8.55.0",
+    "eslint-plugin-react": "^7.33.2"
+  }
+}
+`, project.ID, project.Version, build, typeScriptScripts, typeScriptDeps)
+}
+
+// typeScriptFiles returns the compiler config and the type declarations the
+// generated sources rely on
+func (t *Target) typeScriptFiles() []targets.File {
+	tsconfig := `{
+  "compilerOptions": {
+    "target": "ES2020",
+    "useDefineForClassFields": true,
+    "lib": ["ES2020", "DOM", "DOM.Iterable"],
+    "module": "ESNext",
+    "skipLibCheck": true,
+    "moduleResolution": "bundler",
+    "resolveJsonModule": true,
+    "isolatedModules": true,
+    "esModuleInterop": true,
+    "noEmit": true,
+    "jsx": "react-jsx",
+    "strict": true,
+    "noFallthroughCasesInSwitch": true
+  },
+  "include": ["src"]
+}
+`
+
+	routing := `import type { ComponentType } from 'react';
+
+// Metadata rendered into the <head> of a page
+export interface PageMeta {
+  title: string;
+  description?: string;
+  keywords?: string;
+  ogImage?: string;
+  language?: string;
+}
+
+// A page and the route it is served at
+export interface AppRoute {
+  path: string;
+  component: ComponentType;
+  title: string;
+}
+`
+
+	return []targets.File{
+		{Path: "tsconfig.json", Content: tsconfig},
+		// Vite's client types declare *.module.css and asset imports
+		{Path: "src/vite-env.d.ts", Content: "/// <reference types=\"vite/client\" />\n"},
+		{Path: "src/types/routing.ts", Content: routing},
+	}
+}
+
+func (t *Target) viteConfig() string {
+	return `import { defineConfig } from 'vite'
+import react from '@vitejs/plugin-react'
+
+export default defineConfig({
+  plugins: [react()],
+  server: {
+    port: 3000
+  }
+})
+`
+}
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nThis project was automatically generated from an atomic design structure.\n\n## Getting Started\n\n```bash\n# Install dependencies\nnpm install\n\n# Run development server\nnpm run dev\n\n# Build for production\nnpm run build\n```\n\n## Project Structure\n\n- `src/components/atoms` - Basic UI elements\n- `src/components/molecules` - Combinations of atoms\n- `src/components/organisms` - Complex UI sections\n- `src/pages` - Page components\n- `src/styles` - Global styles and CSS\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
+
+// app returns the root component registering a route for every page
+func (t *Target) app() string {
+	var imports []string
+	var routes []string
+
+	structure := t.ctx.Registry.Structure()
+	for i := range structure.Pages {
+		page := &structure.Pages[i]
+		pageName := renderers.ToPascalCase(page.ID)
+		imports = append(imports, fmt.Sprintf("import %s from './pages/%s';", pageName, pageName))
+		routes = append(routes, fmt.Sprintf(`<Route path="%s" element={<%s />} />`, renderers.PageRoute(t.ctx.Registry, page), pageName))
+	}
+
+	return fmt.Sprintf(`import React from 'react';
+import { BrowserRouter, Routes, Route } from 'react-router-dom';
+import { HelmetProvider } from 'react-helmet-async';
+%s
+import './styles/global.css';
+
+function App() {
+  return (
+    <HelmetProvider>
+      <BrowserRouter>
+        <Routes>
+          %s
+        </Routes>
+      </BrowserRouter>
+    </HelmetProvider>
+  );
+}
+
+export default App;
+`, strings.Join(imports, "\n"), strings.Join(routes, "\n          "))
+}
+
+func (t *Target) indexHTML() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+  <head>
+    <meta charset="UTF-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>%s</title>
+%s
+  </head>
+  <body>
+    <div id="root"></div>
+    <script type="module" src="/src/main%s"></script>
+  </body>
+</html>
+`, html.EscapeString(project.Name), targets.FontLinks(project), t.lang().ComponentExt())
+}
+
+func (t *Target) main() string {
+	rootElement := "document.getElementById('root')"
+	if t.lang() == renderers.LangTS {
+		// The root element is declared in index.html
+		rootElement += " as HTMLElement"
+	}
+
+	return fmt.Sprintf(`import React from 'react'
+import ReactDOM from 'react-dom/client'
+import App from './App'
+
+ReactDOM.createRoot(%s).render(
+  <React.StrictMode>
+    <App />
+  </React.StrictMode>
+)
+`, rootElement)
+}
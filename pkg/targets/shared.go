@@ -0,0 +1,146 @@
+package targets
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/parser"
+	"atomic-generator/pkg/renderers"
+)
+
+// RouteDir converts a route to the directory of a file-system router
+// (SvelteKit, Next.js): "/" is "", "/blog/:slug" is "blog/[slug]" and a
+// trailing "*" is a rest parameter
+func RouteDir(route string) string {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(route, "/"), "/") {
+		switch {
+		case segment == "":
+			continue
+		case segment == "*":
+			segment = "[...rest]"
+		case strings.HasPrefix(segment, ":"):
+			segment = "[" + strings.TrimPrefix(segment, ":") + "]"
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// GlobalStylesheet returns the brand tokens as CSS variables plus the base
+// reset every target ships
+func GlobalStylesheet(brand models.Brand) string {
+	return fmt.Sprintf(`/* Global Styles */
+:root {
+%s
+}
+
+/* Reset */
+* {
+  margin: 0;
+  padding: 0;
+  box-sizing: border-box;
+}
+
+body {
+  font-family: %s;
+  font-size: %s;
+  color: %s;
+  background-color: %s;
+  line-height: 1.6;
+}
+
+/* Normalize */
+img {
+  max-width: 100%%;
+  height: auto;
+}
+
+a {
+  color: inherit;
+  text-decoration: none;
+}
+
+button {
+  font-family: inherit;
+  cursor: pointer;
+}
+`, BrandVariables(brand),
+		cssVar("font-family-primary"),
+		cssVar("font-size-body"),
+		cssVar("color-text"),
+		cssVar("color-background"))
+}
+
+// BrandVariables emits brand tokens category by category, each sorted by
+// key so the output is stable between runs
+func BrandVariables(brand models.Brand) string {
+	var vars []string
+
+	// Colors
+	for _, key := range renderers.SortedKeys(brand.Colors) {
+		value := brand.Colors[key]
+		varName := parser.TokenName(key)
+		vars = append(vars, fmt.Sprintf("  --color-%s: %s;", varName, value))
+	}
+
+	// Typography - Font Families
+	for _, key := range renderers.SortedKeys(brand.Typography.FontFamily) {
+		value := brand.Typography.FontFamily[key]
+		varName := parser.TokenName(key)
+		vars = append(vars, fmt.Sprintf("  --font-family-%s: %s;", varName, value))
+	}
+
+	// Typography - Font Sizes
+	for _, key := range renderers.SortedKeys(brand.Typography.FontSizes) {
+		value := brand.Typography.FontSizes[key]
+		varName := parser.TokenName(key)
+		vars = append(vars, fmt.Sprintf("  --font-size-%s: %s;", varName, value))
+	}
+
+	// Typography - Font Weights
+	for _, key := range renderers.SortedKeys(brand.Typography.FontWeights) {
+		value := brand.Typography.FontWeights[key]
+		varName := parser.TokenName(key)
+		vars = append(vars, fmt.Sprintf("  --font-weight-%s: %v;", varName, value))
+	}
+
+	// Spacing
+	for _, key := range renderers.SortedKeys(brand.Spacing) {
+		value := brand.Spacing[key]
+		varName := parser.TokenName(key)
+		vars = append(vars, fmt.Sprintf("  --spacing-%s: %s;", varName, value))
+	}
+
+	// Breakpoints
+	for _, key := range renderers.SortedKeys(brand.Breakpoints) {
+		value := brand.Breakpoints[key]
+		varName := parser.TokenName(key)
+		vars = append(vars, fmt.Sprintf("  --breakpoint-%s: %s;", varName, value))
+	}
+
+	return strings.Join(vars, "\n")
+}
+
+func cssVar(name string) string {
+	return fmt.Sprintf("var(--%s)", name)
+}
+
+// FontLinks returns the <link> tags loading the project's Google Fonts,
+// indented for an HTML <head>
+func FontLinks(project models.Project) string {
+	if project.ThirdParty.Fonts == nil {
+		return ""
+	}
+
+	var links []string
+	for _, fontURL := range project.ThirdParty.Fonts.Google {
+		links = append(links, fmt.Sprintf(`    <link rel="preconnect" href="https://fonts.googleapis.com" />
+    <link rel="preconnect" href="https://fonts.gstatic.com" crossorigin />
+    <link href="%s" rel="stylesheet" />`, html.EscapeString(fontURL)))
+	}
+
+	return strings.Join(links, "\n")
+}
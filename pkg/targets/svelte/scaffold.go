@@ -0,0 +1,101 @@
+package svelte
+
+import (
+	"fmt"
+
+	"atomic-generator/pkg/targets"
+)
+
+// Scaffold returns the SvelteKit project around the components: config
+// files, the app shell and the root layout loading the global styles
+func (t *Target) Scaffold() ([]targets.File, error) {
+	structure := t.ctx.Registry.Structure()
+
+	return []targets.File{
+		{Path: "package.json", Content: t.packageJSON()},
+		{Path: "svelte.config.js", Content: svelteConfig},
+		{Path: "vite.config.js", Content: viteConfig},
+		{Path: ".gitignore", Content: `node_modules
+.svelte-kit
+build
+.DS_Store
+*.log
+.env
+.env.local
+`},
+		{Path: "README.md", Content: t.readme()},
+		{Path: "src/lib/styles/global.css", Content: targets.GlobalStylesheet(structure.Project.Brand)},
+		{Path: "src/app.html", Content: t.appHTML()},
+		{Path: "src/routes/+layout.svelte", Content: file("import '$lib/styles/global.css';", "<slot />", "")},
+	}, nil
+}
+
+func (t *Target) packageJSON() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "version": "%s",
+  "private": true,
+  "type": "module",
+  "scripts": {
+    "dev": "vite dev",
+    "build": "vite build",
+    "preview": "vite preview"
+  },
+  "devDependencies": {
+    "@sveltejs/adapter-auto": "^3.0.0",
+    "@sveltejs/kit": "^2.0.0",
+    "@sveltejs/vite-plugin-svelte": "^3.0.0",
+    "svelte": "^4.2.7",
+    "vite": "^5.0.8"
+  }
+}
+`, project.ID, project.Version)
+}
+
+const svelteConfig = `import adapter from '@sveltejs/adapter-auto';
+import { vitePreprocess } from '@sveltejs/vite-plugin-svelte';
+
+/** @type {import('@sveltejs/kit').Config} */
+const config = {
+  preprocess: vitePreprocess(),
+  kit: {
+    adapter: adapter()
+  }
+};
+
+export default config;
+`
+
+const viteConfig = `import { sveltekit } from '@sveltejs/kit/vite'
+import { defineConfig } from 'vite'
+
+export default defineConfig({
+  plugins: [sveltekit()],
+  server: {
+    port: 3000
+  }
+})
+`
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nThis SvelteKit project was automatically generated from an atomic design structure.\n\n## Getting Started\n\n```bash\n# Install dependencies\nnpm install\n\n# Run development server\nnpm run dev\n\n# Build for production\nnpm run build\n```\n\n## Project Structure\n\n- `src/lib/components/atoms` - Basic UI elements\n- `src/lib/components/molecules` - Combinations of atoms\n- `src/lib/components/organisms` - Complex UI sections\n- `src/routes` - One `+page.svelte` per page route\n- `src/lib/styles` - Global styles and CSS\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
+
+func (t *Target) appHTML() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+  <head>
+    <meta charset="UTF-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+%s
+    %%sveltekit.head%%
+  </head>
+  <body data-sveltekit-preload-data="hover">
+    <div style="display: contents">%%sveltekit.body%%</div>
+  </body>
+</html>
+`, targets.FontLinks(project))
+}
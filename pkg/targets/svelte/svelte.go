@@ -0,0 +1,226 @@
+// Package svelte is the SvelteKit target: Svelte components with scoped
+// styles and file-system routed pages.
+package svelte
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+func init() {
+	targets.Register("svelte", New)
+}
+
+// Target emits a SvelteKit project
+type Target struct {
+	ctx *renderers.Context
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	if ctx.Options.Lang != renderers.LangJS {
+		return nil, fmt.Errorf("the svelte target only emits JavaScript (got -lang=%s)", ctx.Options.Lang)
+	}
+	if ctx.Options.Composition == renderers.CompositionImport {
+		return nil, fmt.Errorf("the svelte target always inlines children (got -composition=import)")
+	}
+	return &Target{ctx: ctx}, nil
+}
+
+func (t *Target) Name() string {
+	return "svelte"
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		"src",
+		"src/lib",
+		"src/lib/components",
+		"src/lib/components/atoms",
+		"src/lib/components/molecules",
+		"src/lib/components/organisms",
+		"src/lib/styles",
+		"src/routes",
+		"static",
+	}
+}
+
+// braces escapes the characters opening and closing Svelte expressions
+var braces = strings.NewReplacer("{", "&#123;", "}", "&#125;")
+
+func escape(s string) string {
+	return braces.Replace(html.EscapeString(s))
+}
+
+// dialect prints Svelte markup: text and attributes can't open an
+// expression, state classes are toggled with class: directives and props
+// are bound with expressions
+var dialect = renderers.MarkupDialect{
+	EscapeText: escape,
+	EscapeAttr: escape,
+	BindClasses: func(bindings []renderers.ClassBinding) string {
+		var directives []string
+		for _, binding := range bindings {
+			directives = append(directives, fmt.Sprintf("class:%s={%s}", binding.Class, binding.Condition))
+		}
+		return strings.Join(directives, " ")
+	},
+	BindText: func(prop string) string {
+		return "{" + prop + "}"
+	},
+	BindAttr: func(name, prop string) string {
+		return fmt.Sprintf("%s={%s}", name, prop)
+	},
+}
+
+// exportProps returns the export let statements declaring a component's
+// props with their defaults, or "" when it has none
+func exportProps(props []renderers.TemplateProp) string {
+	var exports []string
+	for _, prop := range props {
+		if prop.Default != "" {
+			exports = append(exports, fmt.Sprintf("export let %s = %s;", prop.Name, renderers.JSString(prop.Default)))
+		} else {
+			exports = append(exports, fmt.Sprintf("export let %s;", prop.Name))
+		}
+	}
+	return strings.Join(exports, "\n")
+}
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	el, err := builder.Atom(atom)
+	if err != nil {
+		return nil, err
+	}
+	return t.component("src/lib/components/atoms", atom.ID, "", "", el, builder), nil
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	el, err := builder.Molecule(molecule)
+	if err != nil {
+		return nil, err
+	}
+	script := exportProps(renderers.MoleculeProps(t.ctx.Registry, molecule))
+	return t.component("src/lib/components/molecules", molecule.ID, script, "", el, builder), nil
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+
+	var imports, script []string
+	var special string
+	if props := exportProps(renderers.OrganismProps(t.ctx.Registry, organism)); props != "" {
+		script = append(script, props)
+	}
+
+	// Carousel state, advanced by an interval while the component is
+	// mounted when autoplay is on
+	if renderers.IsCarousel(organism) {
+		slides := renderers.CarouselSlides(organism)
+		carousel := `let currentSlide = 0;`
+		if organism.Behavior.Autoplay && slides > 0 {
+			imports = append(imports, "import { onMount } from 'svelte';")
+			carousel = fmt.Sprintf(`let currentSlide = 0;
+
+const nextSlide = () => (currentSlide = (currentSlide + 1) %% %d);
+const prevSlide = () => (currentSlide = (currentSlide - 1 + %d) %% %d);
+
+onMount(() => {
+  const interval = setInterval(nextSlide, %d);
+  return () => clearInterval(interval);
+});`, slides, slides, slides, organism.Behavior.Interval)
+		}
+		script = append(script, carousel)
+	}
+
+	// Header scroll behavior: the window's scroll position drives the
+	// "scrolled" state
+	if renderers.IsScrollAware(organism) {
+		builder.Drive("scrolled", "scrolled")
+		script = append(script, `let scrollY = 0;
+
+$: scrolled = scrollY > 50;`)
+		special = "<svelte:window bind:scrollY />"
+	}
+
+	el, err := builder.Organism(organism)
+	if err != nil {
+		return nil, err
+	}
+	if len(imports) > 0 {
+		script = append([]string{strings.Join(imports, "\n")}, script...)
+	}
+	return t.component("src/lib/components/organisms", organism.ID, strings.Join(script, "\n\n"), special, el, builder), nil
+}
+
+// component returns the .svelte file of an atom, molecule or organism.
+// special holds <svelte:...> elements, which must sit at the top level.
+func (t *Target) component(dir, id, script, special string, el *renderers.Element, builder *renderers.MarkupBuilder) []targets.File {
+	markup := renderers.PrintMarkup(el, 0, dialect)
+	if special != "" {
+		markup = special + "\n\n" + markup
+	}
+
+	return []targets.File{{
+		Path:    fmt.Sprintf("%s/%s.svelte", dir, renderers.ToPascalCase(id)),
+		Content: file(script, markup, builder.StyleSheet()),
+	}}
+}
+
+// file assembles a Svelte component, leaving out empty blocks. Svelte
+// scopes <style> to the component.
+func file(script, markup, style string) string {
+	var blocks []string
+	if script != "" {
+		blocks = append(blocks, fmt.Sprintf("<script>\n%s\n</script>", script))
+	}
+	blocks = append(blocks, markup)
+	if style != "" {
+		blocks = append(blocks, fmt.Sprintf("<style>\n%s</style>", style))
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// Page writes the page as src/routes/<route>/+page.svelte
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+
+	// Pages reference organism components, imported once each
+	var imports []string
+	imported := make(map[string]bool)
+	body, err := builder.Page(layout, func(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+		name := renderers.ToPascalCase(organism.ID)
+		if !imported[name] {
+			imported[name] = true
+			imports = append(imports, fmt.Sprintf("import %s from '$lib/components/organisms/%s.svelte';", name, name))
+		}
+		return &renderers.Element{Tag: name, Attrs: renderers.PropAttrs(props), Void: true}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	markup := head(page) + "\n\n" + renderers.PrintMarkup(body, 0, dialect)
+	dir := path.Join("src/routes", targets.RouteDir(renderers.PageRoute(t.ctx.Registry, page)))
+
+	return []targets.File{{
+		Path:    path.Join(dir, "+page.svelte"),
+		Content: file(strings.Join(imports, "\n"), markup, ""),
+	}}, nil
+}
+
+// head returns the <svelte:head> block with the page's title and meta tags
+func head(page *models.Page) string {
+	tags := []string{fmt.Sprintf("<title>%s</title>", escape(page.Title))}
+	for _, tag := range renderers.PageMetaTags(page) {
+		tags = append(tags, fmt.Sprintf(`<meta %s="%s" content="%s" />`, tag.Attr, tag.Name, escape(tag.Content)))
+	}
+	return fmt.Sprintf("<svelte:head>\n  %s\n</svelte:head>", strings.Join(tags, "\n  "))
+}
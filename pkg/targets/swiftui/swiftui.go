@@ -0,0 +1,252 @@
+// Package swiftui is the SwiftUI target: Swift sources with one View struct
+// per component and screen, and the brand as theme enums. Only the sources
+// are generated, to add to an iOS app target.
+package swiftui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+func init() {
+	targets.Register("swiftui", New)
+}
+
+// Target emits SwiftUI sources
+type Target struct {
+	ctx   *renderers.Context
+	views *mobile.Builder
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	if ctx.Options.Lang != renderers.LangJS {
+		return nil, fmt.Errorf("the swiftui target emits Swift; -lang=%s doesn't apply", ctx.Options.Lang)
+	}
+	if ctx.Options.Composition == renderers.CompositionImport {
+		return nil, fmt.Errorf("the swiftui target always inlines children (got -composition=import)")
+	}
+	return &Target{ctx: ctx, views: mobile.NewBuilder(ctx)}, nil
+}
+
+func (t *Target) Name() string {
+	return "swiftui"
+}
+
+// dir returns a source directory of the module
+func (t *Target) dir(sub string) string {
+	return "Sources/" + renderers.ToPascalCase(t.ctx.Registry.Structure().Project.ID) + "/" + sub
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		t.dir("Theme"),
+		t.dir("Atoms"),
+		t.dir("Molecules"),
+		t.dir("Organisms"),
+		t.dir("Screens"),
+	}
+}
+
+func (t *Target) NextSteps() []string {
+	return []string{
+		"Add the Sources folder to an iOS app target in Xcode (iOS 17 or later)",
+		"Show a screen from your App's WindowGroup, e.g. HomepageScreen()",
+		"The screens render with the brand theme",
+	}
+}
+
+// reserved are the SwiftUI types and theme enums components can't be named
+// after
+var reserved = map[string]bool{
+	"Assets": true, "BrandColors": true, "BrandFonts": true, "BrandSpacing": true,
+	"BrandTypography": true, "Button": true, "Color": true, "Font": true, "HStack": true,
+	"Image": true, "Link": true, "Spacer": true, "TabView": true, "Text": true,
+	"TextField": true, "VStack": true, "View": true, "ZStack": true,
+}
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	el, err := renderers.NewMarkupBuilder(t.ctx).Atom(atom)
+	if err != nil {
+		return nil, err
+	}
+	s := &view{family: t.defaultFamily()}
+	return s.file(t.dir("Atoms"), mobile.TypeName(atom.ID, "atom", reserved), s.root(t.views.View(el))), nil
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	s := &view{family: t.defaultFamily()}
+	body, err := s.branches(renderers.MoleculeProps(t.ctx.Registry, molecule), nil, func(variants map[string]string) (*mobile.View, error) {
+		el, err := renderers.NewMarkupBuilder(t.ctx).MoleculeVariant(molecule, variants)
+		if err != nil {
+			return nil, err
+		}
+		return t.views.View(el), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.file(t.dir("Molecules"), mobile.TypeName(molecule.ID, "molecule", reserved), body), nil
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	s := &view{family: t.defaultFamily()}
+	body, err := s.branches(renderers.OrganismProps(t.ctx.Registry, organism), nil, func(variants map[string]string) (*mobile.View, error) {
+		el, err := renderers.NewMarkupBuilder(t.ctx).OrganismVariant(organism, variants)
+		if err != nil {
+			return nil, err
+		}
+		root := t.views.View(el)
+		if root != nil && renderers.IsCarousel(organism) {
+			mobile.Carousel(root, organism.Behavior)
+		}
+		return root, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.file(t.dir("Organisms"), mobile.TypeName(organism.ID, "organism", reserved), body), nil
+}
+
+// Page writes the page's screen, which scrolls through the organisms of its
+// layout with the body font of the website
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	body, err := renderers.NewMarkupBuilder(t.ctx).Page(layout, func(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+		return &renderers.Element{Tag: mobile.TypeName(organism.ID, "organism", reserved), Attrs: renderers.PropAttrs(props), Void: true}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &view{family: t.defaultFamily()}
+	stack := &expr{head: "VStack(spacing: 0)", children: s.views(t.views.View(body).Children, mobile.Column)}
+	screen := &expr{head: "ScrollView", children: []*expr{stack}}
+	brand := t.ctx.Registry.Structure().Project.Brand
+	if _, ok := brand.Typography.FontSizes["body"]; ok {
+		screen.modifiers = append(screen.modifiers, ".font(BrandTypography.body)")
+	}
+	if _, ok := brand.Colors["text"]; ok {
+		screen.modifiers = append(screen.modifiers, ".foregroundColor(BrandColors.text)")
+	}
+	return s.file(t.dir("Screens"), renderers.ToPascalCase(page.ID)+"Screen", screen), nil
+}
+
+// view collects what one View struct needs while its views are converted
+type view struct {
+	// props are the struct's constants, set by its initializer
+	props []renderers.TemplateProp
+	// properties are the struct's state and timers
+	properties []string
+	states     map[string]int
+	// family is the font of texts without a font family of their own
+	family string
+}
+
+// root converts the root view of a component, an EmptyView when it is
+// hidden
+func (s *view) root(v *mobile.View) *expr {
+	if v == nil {
+		return &expr{head: "EmptyView()"}
+	}
+	return s.view(v, mobile.Column)
+}
+
+// branches declares the props of a molecule or organism as constants of
+// its struct and returns its body. Variant props can't drive states: the
+// body switches over them, each case built by build with the variants
+// fixed so far, the default variant last as default.
+func (s *view) branches(props []renderers.TemplateProp, fixed map[string]string, build func(variants map[string]string) (*mobile.View, error)) (*expr, error) {
+	if fixed == nil {
+		s.props = props
+	}
+	for _, prop := range props {
+		if _, isFixed := fixed[prop.Name]; isFixed || len(prop.Variants) == 0 {
+			continue
+		}
+
+		e := &expr{head: swiftName(prop.Name)}
+		variants := mobile.DefaultLast(prop.Variants, prop.Default)
+		for i, variant := range variants {
+			variantsFixed := map[string]string{prop.Name: variant}
+			for name, value := range fixed {
+				variantsFixed[name] = value
+			}
+			branch, err := s.branches(props, variantsFixed, build)
+			if err != nil {
+				return nil, err
+			}
+			label := "case " + swiftString(variant)
+			if i == len(variants)-1 {
+				label = "default"
+			}
+			e.cases = append(e.cases, block{label: label, children: []*expr{branch}})
+		}
+		return e, nil
+	}
+
+	root, err := build(fixed)
+	if err != nil {
+		return nil, err
+	}
+	// Only one branch runs: they share their state
+	s.states = nil
+	return s.root(root), nil
+}
+
+// declarations returns the struct's props and the initializer setting
+// them, or ""
+func (s *view) declarations() string {
+	if len(s.props) == 0 {
+		return ""
+	}
+	var constants, params, assignments []string
+	for _, prop := range s.props {
+		name := swiftName(prop.Name)
+		constants = append(constants, fmt.Sprintf("let %s: String", name))
+		param := name + ": String"
+		if prop.Default != "" {
+			param += " = " + swiftString(prop.Default)
+		}
+		params = append(params, param)
+		assignments = append(assignments, fmt.Sprintf("self.%s = %s", name, name))
+	}
+	return fmt.Sprintf("%s\n\ninit(%s) {\n%s\n}", strings.Join(constants, "\n"), strings.Join(params, ", "), indent(strings.Join(assignments, "\n"), 1))
+}
+
+// file returns the Swift file of a View struct
+func (s *view) file(dir, name string, body *expr) []targets.File {
+	var b strings.Builder
+	body.write(&b, 2)
+
+	// Branches declare the same state
+	var members []string
+	if declarations := s.declarations(); declarations != "" {
+		members = append(members, declarations)
+	}
+	var properties []string
+	for _, property := range s.properties {
+		if !slices.Contains(properties, property) {
+			properties = append(properties, property)
+		}
+	}
+	if len(properties) > 0 {
+		members = append(members, strings.Join(properties, "\n"))
+	}
+	head := ""
+	if len(members) > 0 {
+		head = indent(strings.Join(members, "\n\n"), 1) + "\n\n"
+	}
+	content := fmt.Sprintf(`import SwiftUI
+
+struct %s: View {
+%s    var body: some View {
+%s    }
+}
+`, name, head, b.String())
+	return []targets.File{{Path: fmt.Sprintf("%s/%s.swift", dir, name), Content: content}}
+}
@@ -0,0 +1,134 @@
+package swiftui
+
+import (
+	"fmt"
+	"strings"
+
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+// Scaffold returns the theme, with the brand as Swift enums and the asset
+// helper, and the README
+func (t *Target) Scaffold() ([]targets.File, error) {
+	return []targets.File{
+		{Path: "README.md", Content: t.readme()},
+		{Path: t.dir("Theme") + "/Theme.swift", Content: t.themeSwift()},
+		{Path: t.dir("Theme") + "/Assets.swift", Content: assetsSwift},
+	}, nil
+}
+
+// assetsSwift resolves the image paths of the structure, which are paths
+// of the website
+const assetsSwift = `import Foundation
+
+// Image paths such as /images/logo.png are served by the website: set the
+// URL it is served from so the app can load them
+enum Assets {
+    static var baseURL = ""
+
+    static func url(_ path: String) -> URL? {
+        URL(string: baseURL + path)
+    }
+}
+`
+
+// themeSwift writes the brand tokens as enums, resolved like component
+// styles
+func (t *Target) themeSwift() string {
+	brand := t.ctx.Registry.Structure().Project.Brand
+	tokens := t.views.Tokens()
+
+	var b strings.Builder
+	enum := func(name string, lines []string) {
+		fmt.Fprintf(&b, "\nenum %s {\n", name)
+		for _, line := range lines {
+			b.WriteString(indent(line, 1) + "\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	var colors []string
+	for _, key := range renderers.SortedKeys(brand.Colors) {
+		c, ok := mobile.ParseColor(brand.Colors[key])
+		if !ok {
+			colors = append(colors, fmt.Sprintf("// %s: %s isn't a color SwiftUI can use", lowerCamel(key), brand.Colors[key]))
+			continue
+		}
+		colors = append(colors, fmt.Sprintf("static let %s = %s", lowerCamel(key), color(c)))
+	}
+	enum("BrandColors", colors)
+
+	var families []string
+	for _, key := range renderers.SortedKeys(brand.Typography.FontFamily) {
+		value := brand.Typography.FontFamily[key]
+		families = append(families, "// "+value, fmt.Sprintf("static let %s = %s", lowerCamel(key), swiftString(mobile.FontFamily(value))))
+	}
+	if len(families) > 0 {
+		b.WriteString("\n// Brand fonts render with the system font until their files are added to\n// the app and listed under UIAppFonts in Info.plist")
+	}
+	enum("BrandFonts", families)
+
+	var weights []string
+	for _, key := range renderers.SortedKeys(brand.Typography.FontWeights) {
+		if n, ok := tokens.Pixels(brand.Typography.FontWeights[key]); ok {
+			weights = append(weights, fmt.Sprintf("static let %s = Font.Weight%s", lowerCamel(key), fontWeight(int(n))))
+		}
+	}
+	enum("BrandFontWeights", weights)
+
+	var fonts []string
+	for _, key := range renderers.SortedKeys(brand.Typography.FontSizes) {
+		size, ok := tokens.Pixels(brand.Typography.FontSizes[key])
+		if !ok {
+			continue
+		}
+		if family := t.defaultFamily(); family != "" {
+			fonts = append(fonts, fmt.Sprintf("static let %s = Font.custom(%s, size: %s)", lowerCamel(key), family, number(size)))
+		} else {
+			fonts = append(fonts, fmt.Sprintf("static let %s = Font.system(size: %s)", lowerCamel(key), number(size)))
+		}
+	}
+	enum("BrandTypography", fonts)
+
+	var spacing []string
+	for _, key := range renderers.SortedKeys(brand.Spacing) {
+		if n, ok := tokens.Pixels(brand.Spacing[key]); ok {
+			spacing = append(spacing, fmt.Sprintf("static let %s: CGFloat = %s", lowerCamel(key), number(n)))
+		}
+	}
+	enum("BrandSpacing", spacing)
+
+	return fmt.Sprintf(`import SwiftUI
+%s
+extension Color {
+    init(hex: UInt32, opacity: Double = 1) {
+        self.init(
+            red: Double((hex >> 16) & 0xFF) / 255,
+            green: Double((hex >> 8) & 0xFF) / 255,
+            blue: Double(hex & 0xFF) / 255,
+            opacity: opacity
+        )
+    }
+}
+`, b.String())
+}
+
+// defaultFamily returns the brand font texts use unless styled, like the
+// body of the website: the primary family, or the first one
+func (t *Target) defaultFamily() string {
+	families := t.ctx.Registry.Structure().Project.Brand.Typography.FontFamily
+	if _, ok := families["primary"]; ok {
+		return "BrandFonts.primary"
+	}
+	for _, key := range renderers.SortedKeys(families) {
+		return "BrandFonts." + lowerCamel(key)
+	}
+	return ""
+}
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nThese SwiftUI sources were automatically generated from an atomic design structure. Only the sources are generated: add the `Sources` folder to an iOS app target (iOS 17 or later).\n\n## Usage\n\n```swift\n@main\nstruct MyApp: App {\n    var body: some Scene {\n        WindowGroup {\n            HomeScreen()\n        }\n    }\n}\n```\n\n## Notes\n\n- Styles are resolved for a phone: brand variables, `rem`, viewport units and `clamp()` become points, and the narrowest breakpoint's overrides apply. Properties SwiftUI can't apply are listed in a comment above their view.\n- Brand colors, fonts, font sizes and spacing are enums of `Theme/Theme.swift`. Brand fonts render with the system font until their files are added to the app.\n- Image paths are relative to the website: set `Assets.baseURL` in `Theme/Assets.swift`.\n- Links to URLs open in the browser; links to paths of the website are plain text.\n\n## Project Structure\n\n- `Theme` - Brand theme and asset helper\n- `Atoms` - Basic UI elements\n- `Molecules` - Combinations of atoms\n- `Organisms` - Complex UI sections\n- `Screens` - Page screens\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
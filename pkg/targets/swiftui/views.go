@@ -0,0 +1,656 @@
+package swiftui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets/mobile"
+)
+
+// expr is a SwiftUI view expression: its first line, trailing closures
+// holding child views, and modifiers
+type expr struct {
+	// comment is printed above the expression
+	comment string
+	head    string
+	// blocks are trailing closures; the first opens after head, the
+	// others are labeled (} label: {)
+	blocks    []block
+	modifiers []string
+	// children is shorthand for a single unlabeled block
+	children []*expr
+	// cases make the expression a switch over head: each block's label is
+	// a case ("dark" or default) showing its children
+	cases []block
+}
+
+type block struct {
+	label string
+	// param names the closure's parameter (image in)
+	param    string
+	children []*expr
+	// code is printed instead of children
+	code string
+}
+
+// indent prefixes every non-empty line of code with four spaces per level
+func indent(code string, level int) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = strings.Repeat("    ", level) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *expr) write(b *strings.Builder, level int) {
+	pad := strings.Repeat("    ", level)
+	if e.comment != "" {
+		fmt.Fprintf(b, "%s// %s\n", pad, e.comment)
+	}
+	if e.cases != nil {
+		fmt.Fprintf(b, "%sswitch %s {\n", pad, e.head)
+		for _, c := range e.cases {
+			fmt.Fprintf(b, "%s%s:\n", pad, c.label)
+			for _, child := range c.children {
+				child.write(b, level+1)
+			}
+		}
+		b.WriteString(pad + "}\n")
+		return
+	}
+
+	blocks := e.blocks
+	if e.children != nil {
+		blocks = append([]block{{children: e.children}}, blocks...)
+	}
+	b.WriteString(pad + e.head)
+	for i, bl := range blocks {
+		if i == 0 {
+			b.WriteString(" {")
+		} else {
+			fmt.Fprintf(b, "%s} %s: {", pad, bl.label)
+		}
+		if bl.param != "" {
+			fmt.Fprintf(b, " %s in", bl.param)
+		}
+		b.WriteString("\n")
+		if bl.code != "" {
+			b.WriteString(indent(bl.code, level+1) + "\n")
+		}
+		for _, child := range bl.children {
+			child.write(b, level+1)
+		}
+	}
+	// Modifiers line up with a closing brace, or are indented under a
+	// single line view
+	modifierLevel := level + 1
+	if len(blocks) > 0 {
+		b.WriteString(pad + "}")
+		modifierLevel = level
+	}
+	b.WriteString("\n")
+	for _, modifier := range e.modifiers {
+		b.WriteString(indent(modifier, modifierLevel) + "\n")
+	}
+}
+
+// swiftString quotes a Swift string literal
+func swiftString(s string) string {
+	return `"` + swiftEscaper.Replace(s) + `"`
+}
+
+var swiftEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// memberNames are the members of View structs props can't be named after
+var memberNames = map[string]bool{"body": true}
+
+// swiftName returns the property of a component prop, which is also the
+// label of its initializer's parameter
+func swiftName(prop string) string {
+	if memberNames[prop] {
+		return prop + "Value"
+	}
+	return prop
+}
+
+// markdownPattern matches the text Text(_:) would read as markdown or a
+// format specifier
+var markdownPattern = regexp.MustCompile("[*_`~\\[%]")
+
+// boundText returns a Text showing the text of a view, or the prop it is
+// bound to
+func boundText(v *mobile.View) string {
+	if v.TextProp != "" {
+		return "Text(" + swiftName(v.TextProp) + ")"
+	}
+	return textView(v.Text)
+}
+
+// textView returns a Text showing s as is
+func textView(s string) string {
+	if markdownPattern.MatchString(s) {
+		return "Text(verbatim: " + swiftString(s) + ")"
+	}
+	return "Text(" + swiftString(s) + ")"
+}
+
+// lowerCamel names a theme member after a brand token key
+func lowerCamel(key string) string {
+	name := renderers.ToPascalCase(key)
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func number(n float64) string {
+	return mobile.FormatNumber(n)
+}
+
+// views converts the children of a container
+func (s *view) views(views []*mobile.View, parent mobile.Kind) []*expr {
+	var exprs []*expr
+	for _, v := range views {
+		exprs = append(exprs, s.view(v, parent))
+	}
+	return exprs
+}
+
+// view converts a view to its SwiftUI expression
+func (s *view) view(v *mobile.View, parent mobile.Kind) *expr {
+	var e *expr
+	switch v.Kind {
+	case mobile.Component:
+		var args []string
+		for _, prop := range v.Props {
+			args = append(args, swiftName(prop.Name)+": "+swiftString(prop.Value))
+		}
+		return &expr{head: v.Name + "(" + strings.Join(args, ", ") + ")"}
+	case mobile.Text:
+		e = &expr{head: boundText(v), modifiers: s.textModifiers(v)}
+		e.modifiers = append(e.modifiers, s.box(v, parent)...)
+	case mobile.Link:
+		e = s.link(v, parent)
+	case mobile.Image:
+		e = s.image(v, parent)
+	case mobile.Button:
+		e = s.button(v, parent)
+	case mobile.Input:
+		e = s.input(v, parent)
+	case mobile.Pager:
+		e = s.pager(v, parent)
+	default:
+		e = s.container(v, parent)
+	}
+	if len(v.Style.Unsupported) > 0 {
+		e.comment = "Not supported in SwiftUI: " + strings.Join(v.Style.Unsupported, ", ")
+	}
+	return e
+}
+
+// box returns the modifiers sizing, drawing and placing a view, in the
+// order of the CSS box model: padding, size, background, border, opacity,
+// margin
+func (s *view) box(v *mobile.View, parent mobile.Kind) []string {
+	return append(s.inner(v, parent), s.outer(v)...)
+}
+
+// inner returns the modifiers of a view's box up to its border
+func (s *view) inner(v *mobile.View, parent mobile.Kind) []string {
+	st := v.Style
+	var m []string
+	if !st.Padding.IsZero() {
+		m = append(m, padding(st.Padding)...)
+	}
+	m = append(m, frames(v, parent)...)
+
+	shape := shape(st.Radius)
+	if st.Background != nil {
+		if shape != "" {
+			m = append(m, fmt.Sprintf(".background(%s, in: %s)", color(*st.Background), shape))
+		} else {
+			m = append(m, fmt.Sprintf(".background(%s)", color(*st.Background)))
+		}
+	}
+	switch {
+	case shape != "" && (st.Clip || v.Kind == mobile.Image):
+		m = append(m, ".clipShape("+shape+")")
+	case st.Clip:
+		m = append(m, ".clipped()")
+	}
+	if st.BorderWidth > 0 && st.BorderColor != nil {
+		if shape == "" {
+			shape = "Rectangle()"
+		}
+		m = append(m, fmt.Sprintf(".overlay(%s.stroke(%s, lineWidth: %s))", shape, color(*st.BorderColor), number(st.BorderWidth)))
+	}
+	return m
+}
+
+// outer returns the modifiers applying around a view's box
+func (s *view) outer(v *mobile.View) []string {
+	st := v.Style
+	var m []string
+	if st.Opacity != nil {
+		m = append(m, fmt.Sprintf(".opacity(%s)", number(*st.Opacity)))
+	}
+	if !st.Margin.IsZero() {
+		m = append(m, padding(st.Margin)...)
+	}
+	if st.Absolute && (st.Top != 0 || st.Left != 0) {
+		m = append(m, fmt.Sprintf(".offset(x: %s, y: %s)", number(st.Left), number(st.Top)))
+	}
+	if st.ZIndex != nil {
+		m = append(m, fmt.Sprintf(".zIndex(%s)", number(*st.ZIndex)))
+	}
+	if v.Heading {
+		m = append(m, ".accessibilityAddTraits(.isHeader)")
+	}
+	if v.Label != "" {
+		m = append(m, ".accessibilityLabel("+swiftString(v.Label)+")")
+	}
+	return m
+}
+
+func padding(e mobile.Edges) []string {
+	if e.Top == e.Right && e.Top == e.Bottom && e.Top == e.Left {
+		return []string{fmt.Sprintf(".padding(%s)", number(e.Top))}
+	}
+	if e.Top == e.Bottom && e.Left == e.Right {
+		var m []string
+		if e.Left != 0 {
+			m = append(m, fmt.Sprintf(".padding(.horizontal, %s)", number(e.Left)))
+		}
+		if e.Top != 0 {
+			m = append(m, fmt.Sprintf(".padding(.vertical, %s)", number(e.Top)))
+		}
+		return m
+	}
+	return []string{fmt.Sprintf(".padding(EdgeInsets(top: %s, leading: %s, bottom: %s, trailing: %s))",
+		number(e.Top), number(e.Left), number(e.Bottom), number(e.Right))}
+}
+
+// frames returns the frame modifiers of a view: a fixed frame for sizes in
+// pixels, then a flexible one for bounds, full sizes and blocks filling
+// their container
+func frames(v *mobile.View, parent mobile.Kind) []string {
+	st := v.Style
+	var fixed, flexible []string
+	if st.Width != nil && !st.Width.Percent {
+		fixed = append(fixed, "width: "+number(st.Width.Value))
+	}
+	if st.Height != nil && !st.Height.Percent {
+		fixed = append(fixed, "height: "+number(st.Height.Value))
+	}
+
+	bound := func(name string, l *mobile.Length) {
+		if l != nil && !l.Percent && l.Value > 0 {
+			flexible = append(flexible, name+": "+number(l.Value))
+		}
+	}
+	fillWidth := st.Width.Full() || st.Width == nil && (v.Block || st.Grow > 0 && parent == mobile.Row)
+	fillHeight := st.Height.Full() || st.Height == nil && st.Grow > 0 && parent == mobile.Column
+	bound("minWidth", st.MinWidth)
+	switch {
+	case st.MaxWidth != nil && !st.MaxWidth.Percent:
+		bound("maxWidth", st.MaxWidth)
+	case fillWidth:
+		flexible = append(flexible, "maxWidth: .infinity")
+	}
+	bound("minHeight", st.MinHeight)
+	switch {
+	case st.MaxHeight != nil && !st.MaxHeight.Percent:
+		bound("maxHeight", st.MaxHeight)
+	case fillHeight:
+		flexible = append(flexible, "maxHeight: .infinity")
+	}
+	if len(flexible) > 0 {
+		if alignment := frameAlignment(v); alignment != "" {
+			flexible = append(flexible, "alignment: "+alignment)
+		}
+	}
+
+	var m []string
+	if len(fixed) > 0 {
+		m = append(m, ".frame("+strings.Join(fixed, ", ")+")")
+	}
+	if len(flexible) > 0 {
+		m = append(m, ".frame("+strings.Join(flexible, ", ")+")")
+	}
+	// A block with a max width still fills its container up to it
+	if st.MaxWidth != nil && !st.MaxWidth.Percent && fillWidth {
+		m = append(m, ".frame(maxWidth: .infinity)")
+	}
+	return m
+}
+
+// frameAlignment places a view's content in a frame wider than it, like
+// text-align and justify-content do
+func frameAlignment(v *mobile.View) string {
+	if v.Kind == mobile.Row {
+		switch v.Style.Justify {
+		case "center":
+			return ".center"
+		case "flex-end", "end":
+			return ".trailing"
+		}
+		return ".leading"
+	}
+	switch v.Style.TextAlign {
+	case "center":
+		return ".center"
+	case "right":
+		return ".trailing"
+	}
+	if v.Container() && v.Style.Align == "center" {
+		return ".center"
+	}
+	return ".leading"
+}
+
+// shape returns the shape of a border radius, or "" for square corners
+func shape(radius *mobile.Length) string {
+	switch {
+	case radius == nil || radius.Value == 0:
+		return ""
+	case radius.Percent && radius.Value >= 50:
+		return "Capsule()"
+	case radius.Percent:
+		// Only a capsule's radius depends on the size
+		return ""
+	}
+	return fmt.Sprintf("RoundedRectangle(cornerRadius: %s)", number(radius.Value))
+}
+
+// color references the brand color a value came from, or writes it
+func color(c mobile.Color) string {
+	if c.Token != "" {
+		return "BrandColors." + lowerCamel(c.Token)
+	}
+	if c.A < 1 {
+		return fmt.Sprintf("Color(hex: 0x%06X, opacity: %s)", c.RGB(), number(c.A))
+	}
+	return fmt.Sprintf("Color(hex: 0x%06X)", c.RGB())
+}
+
+// fontWeights names the Font.Weight constants by hundreds
+var fontWeights = []string{"ultraLight", "thin", "light", "regular", "medium", "semibold", "bold", "heavy", "black"}
+
+func fontWeight(weight int) string {
+	i := (weight+50)/100 - 1
+	i = max(0, min(i, len(fontWeights)-1))
+	return "." + fontWeights[i]
+}
+
+var genericDesigns = map[string]string{"serif": ".serif", "monospace": ".monospaced"}
+
+// font returns the Font of a text style, or "" to keep the inherited one
+func (s *view) font(st mobile.Style) string {
+	if st.FontToken == "" && st.FontSize == 0 {
+		return ""
+	}
+	size := st.FontSize
+	if size == 0 {
+		size = mobile.RootFontSize
+	}
+	switch {
+	case st.FontToken != "":
+		return fmt.Sprintf(".custom(BrandFonts.%s, size: %s)", lowerCamel(st.FontToken), number(size))
+	case genericDesigns[st.FontFamily] != "":
+		return fmt.Sprintf(".system(size: %s, design: %s)", number(size), genericDesigns[st.FontFamily])
+	case st.FontFamily != "" && st.FontFamily != "sans-serif":
+		return fmt.Sprintf(".custom(%s, size: %s)", swiftString(st.FontFamily), number(size))
+	case st.FontFamily == "" && s.family != "":
+		return fmt.Sprintf(".custom(%s, size: %s)", s.family, number(size))
+	}
+	return fmt.Sprintf(".system(size: %s)", number(size))
+}
+
+// textModifiers returns the modifiers styling a Text
+func (s *view) textModifiers(v *mobile.View) []string {
+	st := v.Style
+	var m []string
+	if f := s.font(st); f != "" {
+		m = append(m, ".font("+f+")")
+	}
+	if st.FontWeight > 0 {
+		m = append(m, ".fontWeight("+fontWeight(st.FontWeight)+")")
+	}
+	if st.Italic {
+		m = append(m, ".italic()")
+	}
+	if st.Color != nil {
+		m = append(m, ".foregroundColor("+color(*st.Color)+")")
+	}
+	if st.LetterSpacing != 0 {
+		m = append(m, fmt.Sprintf(".kerning(%s)", number(st.LetterSpacing)))
+	}
+	if st.Underline {
+		m = append(m, ".underline()")
+	}
+	if st.Strikethrough {
+		m = append(m, ".strikethrough()")
+	}
+	if st.LineHeight > 0 {
+		size := st.FontSize
+		if size == 0 {
+			size = mobile.RootFontSize
+		}
+		if spacing := st.LineHeight - size*1.2; spacing > 0 {
+			m = append(m, fmt.Sprintf(".lineSpacing(%s)", number(spacing)))
+		}
+	}
+	if alignment, ok := textAlignments[st.TextAlign]; ok {
+		m = append(m, ".multilineTextAlignment("+alignment+")")
+	}
+	return m
+}
+
+var textAlignments = map[string]string{"left": ".leading", "center": ".center", "right": ".trailing"}
+
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// link converts an anchor. Links to URLs open with Link; paths of the
+// website can't open in the app and stay texts.
+func (s *view) link(v *mobile.View, parent mobile.Kind) *expr {
+	text := &expr{head: boundText(v), modifiers: s.textModifiers(v)}
+	if !schemePattern.MatchString(v.Href) {
+		text.modifiers = append(text.modifiers, s.box(v, parent)...)
+		return text
+	}
+	href := swiftString(v.Href)
+	if v.HrefProp != "" {
+		href = swiftName(v.HrefProp)
+	}
+	return &expr{
+		head:      fmt.Sprintf("Link(destination: URL(string: %s)!)", href),
+		children:  []*expr{text},
+		modifiers: s.box(v, parent),
+	}
+}
+
+var contentModes = map[string]string{"cover": ".scaledToFill()", "contain": ".scaledToFit()", "fill": ""}
+
+// image converts an image, loaded asynchronously from the website
+func (s *view) image(v *mobile.View, parent mobile.Kind) *expr {
+	src := swiftString(v.Src)
+	if v.SrcProp != "" {
+		src = swiftName(v.SrcProp)
+	}
+	url := fmt.Sprintf("Assets.url(%s)", src)
+	if schemePattern.MatchString(v.Src) {
+		url = fmt.Sprintf("URL(string: %s)", src)
+	}
+	mode, ok := contentModes[v.Style.Fit]
+	if !ok {
+		mode = ".scaledToFit()"
+	}
+	image := "image\n    .resizable()"
+	if mode != "" {
+		image += "\n    " + mode
+	}
+
+	// Images keep their own width unless told otherwise
+	wasBlock := v.Block
+	v.Block = false
+	modifiers := s.box(v, parent)
+	v.Block = wasBlock
+	if v.Alt != "" {
+		modifiers = append(modifiers, ".accessibilityLabel("+swiftString(v.Alt)+")")
+	}
+	return &expr{
+		head: fmt.Sprintf("AsyncImage(url: %s)", url),
+		blocks: []block{
+			{param: "image", code: image},
+			{label: "placeholder", code: "Color.clear"},
+		},
+		modifiers: modifiers,
+	}
+}
+
+// button converts a button. Its label carries the box styles, so the
+// whole box is tappable.
+func (s *view) button(v *mobile.View, parent mobile.Kind) *expr {
+	label := &expr{head: boundText(v), modifiers: append(s.textModifiers(v), s.inner(v, parent)...)}
+	modifiers := []string{".buttonStyle(.plain)"}
+	if v.Disabled {
+		modifiers = append(modifiers, ".disabled(true)")
+	}
+	return &expr{head: "Button(action: {})", children: []*expr{label}, modifiers: append(modifiers, s.outer(v)...)}
+}
+
+// keyboardTypes gives HTML input types the right keyboard
+var keyboardTypes = map[string][]string{
+	"email":  {".keyboardType(.emailAddress)", ".textInputAutocapitalization(.never)"},
+	"number": {".keyboardType(.numberPad)"},
+	"search": {".submitLabel(.search)"},
+	"tel":    {".keyboardType(.phonePad)"},
+	"url":    {".keyboardType(.URL)", ".textInputAutocapitalization(.never)"},
+}
+
+// input converts an input to a text field holding its value in state
+func (s *view) input(v *mobile.View, parent mobile.Kind) *expr {
+	state := s.state(v.Name)
+	s.properties = append(s.properties, fmt.Sprintf(`@State private var %s = ""`, state))
+
+	field := "TextField"
+	if v.InputType == "password" {
+		field = "SecureField"
+	}
+	e := &expr{head: fmt.Sprintf("%s(%s, text: $%s)", field, swiftString(v.Placeholder), state)}
+	e.modifiers = append(e.modifiers, keyboardTypes[v.InputType]...)
+	if v.Disabled {
+		e.modifiers = append(e.modifiers, ".disabled(true)")
+	}
+	e.modifiers = append(e.modifiers, s.textModifiers(v)...)
+	e.modifiers = append(e.modifiers, s.box(v, parent)...)
+	return e
+}
+
+// state returns a unique name for a state property of the struct
+func (s *view) state(name string) string {
+	if name == "" {
+		name = "value"
+	}
+	name = lowerCamel(name)
+	if s.states == nil {
+		s.states = make(map[string]int)
+	}
+	s.states[name]++
+	if n := s.states[name]; n > 1 {
+		return fmt.Sprintf("%s%d", name, n)
+	}
+	return name
+}
+
+// container converts a Column, Row or Stack to a VStack, HStack or ZStack
+// laid out like the flexbox or block layout of the element
+func (s *view) container(v *mobile.View, parent mobile.Kind) *expr {
+	st := v.Style
+	children := s.views(v.Children, v.Kind)
+	e := &expr{modifiers: s.box(v, parent)}
+	if len(children) == 0 {
+		// An empty box only draws its background
+		e.head = "Color.clear"
+		return e
+	}
+	e.children = children
+
+	switch v.Kind {
+	case mobile.Row:
+		alignment := map[string]string{"center": ".center", "flex-end": ".bottom", "end": ".bottom", "baseline": ".firstTextBaseline"}[st.Align]
+		if alignment == "" {
+			alignment = ".top"
+		}
+		e.head = fmt.Sprintf("HStack(alignment: %s, spacing: %s)", alignment, number(st.Gap))
+		e.children = spread(children, st.Justify)
+	case mobile.Stack:
+		alignment := ".topLeading"
+		if st.Align == "center" || st.Justify == "center" {
+			alignment = ".center"
+		}
+		e.head = fmt.Sprintf("ZStack(alignment: %s)", alignment)
+	default:
+		alignment := map[string]string{"center": ".center", "flex-end": ".trailing", "end": ".trailing"}[st.Align]
+		if alignment == "" {
+			alignment = ".leading"
+		}
+		e.head = fmt.Sprintf("VStack(alignment: %s, spacing: %s)", alignment, number(st.Gap))
+	}
+	return e
+}
+
+// spread puts Spacers between the children of a row for the space-*
+// values of justify-content, and before them for flex-end
+func spread(children []*expr, justify string) []*expr {
+	spacer := func() *expr { return &expr{head: "Spacer(minLength: 0)"} }
+	var spread []*expr
+	switch justify {
+	case "space-between", "space-around", "space-evenly":
+		if justify != "space-between" {
+			spread = append(spread, spacer())
+		}
+		for i, child := range children {
+			if i > 0 {
+				spread = append(spread, spacer())
+			}
+			spread = append(spread, child)
+		}
+		if justify != "space-between" {
+			spread = append(spread, spacer())
+		}
+		return spread
+	case "flex-end", "end":
+		return append([]*expr{spacer()}, children...)
+	}
+	return children
+}
+
+// pager converts a carousel to a horizontal paging ScrollView with one
+// screen-wide page per slide, scrolled automatically with autoplay
+func (s *view) pager(v *mobile.View, parent mobile.Kind) *expr {
+	s.properties = append(s.properties, "@State private var currentSlide: Int? = 0")
+	slides := s.views(v.Children, mobile.Row)
+	for i, slide := range slides {
+		slide.modifiers = append(slide.modifiers, ".containerRelativeFrame(.horizontal)", fmt.Sprintf(".id(%d)", i))
+	}
+	stack := &expr{head: "HStack(spacing: 0)", children: slides, modifiers: []string{".scrollTargetLayout()"}}
+
+	modifiers := []string{".scrollTargetBehavior(.paging)", ".scrollPosition(id: $currentSlide)"}
+	if v.Interval > 0 {
+		modifiers = append(modifiers, fmt.Sprintf(`.onReceive(Timer.publish(every: %s, on: .main, in: .common).autoconnect()) { _ in
+    withAnimation {
+        currentSlide = ((currentSlide ?? 0) + 1) %% %d
+    }
+}`, number(float64(v.Interval)/1000), len(slides)))
+	}
+	return &expr{
+		head:      "ScrollView(.horizontal, showsIndicators: false)",
+		children:  []*expr{stack},
+		modifiers: append(modifiers, s.box(v, parent)...),
+	}
+}
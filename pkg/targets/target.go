@@ -0,0 +1,79 @@
+package targets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+)
+
+// File is a generated file, with its path relative to the project root
+type File struct {
+	Path    string
+	Content string
+}
+
+// Target emits a project for one framework. It owns how components are
+// rendered, how their files are named and laid out, and the scaffolding
+// around them; ProjectGenerator only walks the structure and writes the
+// files a target returns.
+type Target interface {
+	// Name is the -target value selecting this target
+	Name() string
+	// Directories lists the directories the project always has, even when
+	// empty (e.g. public/)
+	Directories() []string
+	// Scaffold returns the files that don't belong to a single component:
+	// package manifest, build config, entry points, global styles, routing
+	Scaffold() ([]File, error)
+
+	Atom(atom *models.Atom) ([]File, error)
+	Molecule(molecule *models.Molecule) ([]File, error)
+	Organism(organism *models.Organism) ([]File, error)
+	// Page returns the files of a page rendered with its layout
+	Page(page *models.Page, layout *models.Layout) ([]File, error)
+}
+
+// NextSteps is implemented by targets whose projects aren't started with
+// npm install && npm run dev. It returns the steps printed after
+// generation, once the user is in the project directory; the last entry
+// says what to expect when they are done.
+type NextSteps interface {
+	NextSteps() []string
+}
+
+// Factory creates a target for one generation run. It returns an error when
+// the target doesn't support the requested options.
+type Factory func(ctx *renderers.Context) (Target, error)
+
+var factories = make(map[string]Factory)
+
+// Register makes a target available under name. Target packages call it
+// from init, so importing a target package is enough to enable it.
+func Register(name string, factory Factory) {
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("target %q registered twice", name))
+	}
+	factories[name] = factory
+}
+
+// New creates the target registered under name
+func New(name string, ctx *renderers.Context) (Target, error) {
+	factory, exists := factories[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown target %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return factory(ctx)
+}
+
+// Names returns the registered target names, sorted
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,72 @@
+package vue
+
+import (
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+// useCarousel ports the carousel state and autoplay effect of the React
+// organisms
+const useCarousel = `import { ref, onMounted, onUnmounted } from 'vue';
+
+// Tracks the current slide of a carousel, advancing it every interval
+// milliseconds when autoplay is on
+export function useCarousel(slideCount, { autoplay = false, interval = 5000 } = {}) {
+  const currentSlide = ref(0);
+
+  const nextSlide = () => {
+    currentSlide.value = (currentSlide.value + 1) % slideCount;
+  };
+  const prevSlide = () => {
+    currentSlide.value = (currentSlide.value - 1 + slideCount) % slideCount;
+  };
+
+  let timer;
+  onMounted(() => {
+    if (autoplay && slideCount > 0) {
+      timer = setInterval(nextSlide, interval);
+    }
+  });
+  onUnmounted(() => clearInterval(timer));
+
+  return { currentSlide, nextSlide, prevSlide };
+}
+`
+
+// useScrolled ports the header scroll listener of the React organisms
+const useScrolled = `import { ref, onMounted, onUnmounted } from 'vue';
+
+// Tracks whether the page is scrolled past threshold pixels
+export function useScrolled(threshold = 50) {
+  const scrolled = ref(false);
+
+  const handleScroll = () => {
+    scrolled.value = window.scrollY > threshold;
+  };
+
+  onMounted(() => window.addEventListener('scroll', handleScroll));
+  onUnmounted(() => window.removeEventListener('scroll', handleScroll));
+
+  return scrolled;
+}
+`
+
+// composables returns the composables used by at least one organism
+func (t *Target) composables() []targets.File {
+	var carousel, scrollAware bool
+	structure := t.ctx.Registry.Structure()
+	for i := range structure.Organisms {
+		organism := &structure.Organisms[i]
+		carousel = carousel || renderers.IsCarousel(organism)
+		scrollAware = scrollAware || renderers.IsScrollAware(organism)
+	}
+
+	var files []targets.File
+	if carousel {
+		files = append(files, targets.File{Path: "src/composables/useCarousel.js", Content: useCarousel})
+	}
+	if scrollAware {
+		files = append(files, targets.File{Path: "src/composables/useScrolled.js", Content: useScrolled})
+	}
+	return files
+}
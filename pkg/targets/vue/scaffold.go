@@ -0,0 +1,133 @@
+package vue
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+// Scaffold returns the Vite project around the components: config files,
+// global styles, composables, the router and the entry points
+func (t *Target) Scaffold() ([]targets.File, error) {
+	structure := t.ctx.Registry.Structure()
+
+	files := []targets.File{
+		{Path: "package.json", Content: t.packageJSON()},
+		{Path: "vite.config.js", Content: viteConfig},
+		{Path: ".gitignore", Content: `node_modules
+dist
+.DS_Store
+*.log
+.env
+.env.local
+`},
+		{Path: "README.md", Content: t.readme()},
+		{Path: "src/styles/global.css", Content: targets.GlobalStylesheet(structure.Project.Brand)},
+	}
+
+	files = append(files, t.composables()...)
+
+	files = append(files,
+		targets.File{Path: "src/router.js", Content: t.router()},
+		targets.File{Path: "src/App.vue", Content: sfc("", "  <RouterView />", "")},
+		targets.File{Path: "index.html", Content: t.indexHTML()},
+		targets.File{Path: "src/main.js", Content: mainJS},
+	)
+
+	return files, nil
+}
+
+func (t *Target) packageJSON() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "version": "%s",
+  "type": "module",
+  "scripts": {
+    "dev": "vite",
+    "build": "vite build",
+    "preview": "vite preview"
+  },
+  "dependencies": {
+    "vue": "^3.3.13",
+    "vue-router": "^4.2.5",
+    "@unhead/vue": "^1.8.9"
+  },
+  "devDependencies": {
+    "@vitejs/plugin-vue": "^4.5.2",
+    "vite": "^5.0.8"
+  }
+}
+`, project.ID, project.Version)
+}
+
+const viteConfig = `import { defineConfig } from 'vite'
+import vue from '@vitejs/plugin-vue'
+
+export default defineConfig({
+  plugins: [vue()],
+  server: {
+    port: 3000
+  }
+})
+`
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nThis Vue 3 project was automatically generated from an atomic design structure.\n\n## Getting Started\n\n```bash\n# Install dependencies\nnpm install\n\n# Run development server\nnpm run dev\n\n# Build for production\nnpm run build\n```\n\n## Project Structure\n\n- `src/components/atoms` - Basic UI elements\n- `src/components/molecules` - Combinations of atoms\n- `src/components/organisms` - Complex UI sections\n- `src/composables` - Carousel and scroll behaviors\n- `src/pages` - Page components\n- `src/router.js` - Routes of the pages\n- `src/styles` - Global styles and CSS\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
+
+// router returns the vue-router setup with a route for every page
+func (t *Target) router() string {
+	var imports []string
+	var routes []string
+
+	structure := t.ctx.Registry.Structure()
+	for i := range structure.Pages {
+		page := &structure.Pages[i]
+		pageName := renderers.ToPascalCase(page.ID)
+		imports = append(imports, fmt.Sprintf("import %s from './pages/%s.vue';", pageName, pageName))
+		routes = append(routes, fmt.Sprintf("{ path: %s, component: %s },", renderers.JSString(renderers.PageRoute(t.ctx.Registry, page)), pageName))
+	}
+
+	return fmt.Sprintf(`import { createRouter, createWebHistory } from 'vue-router';
+%s
+
+export default createRouter({
+  history: createWebHistory(),
+  routes: [
+    %s
+  ],
+});
+`, strings.Join(imports, "\n"), strings.Join(routes, "\n    "))
+}
+
+func (t *Target) indexHTML() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+  <head>
+    <meta charset="UTF-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>%s</title>
+%s
+  </head>
+  <body>
+    <div id="app"></div>
+    <script type="module" src="/src/main.js"></script>
+  </body>
+</html>
+`, html.EscapeString(project.Name), targets.FontLinks(project))
+}
+
+const mainJS = `import { createApp } from 'vue';
+import { createHead } from '@unhead/vue';
+import App from './App.vue';
+import router from './router';
+import './styles/global.css';
+
+createApp(App).use(router).use(createHead()).mount('#app');
+`
@@ -0,0 +1,253 @@
+// Package vue is the Vue 3 + Vite target: single-file components with
+// scoped styles, vue-router pages and @unhead/vue metadata.
+package vue
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+func init() {
+	targets.Register("vue", New)
+}
+
+// Target emits a Vue 3 project built with Vite
+type Target struct {
+	ctx *renderers.Context
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	if ctx.Options.Lang != renderers.LangJS {
+		return nil, fmt.Errorf("the vue target only emits JavaScript (got -lang=%s)", ctx.Options.Lang)
+	}
+	if ctx.Options.Composition == renderers.CompositionImport {
+		return nil, fmt.Errorf("the vue target always inlines children (got -composition=import)")
+	}
+	return &Target{ctx: ctx}, nil
+}
+
+func (t *Target) Name() string {
+	return "vue"
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		"src",
+		"src/components",
+		"src/components/atoms",
+		"src/components/molecules",
+		"src/components/organisms",
+		"src/composables",
+		"src/pages",
+		"src/styles",
+		"src/assets",
+		"public",
+	}
+}
+
+// mustache escapes the interpolation delimiters in template text
+var mustache = strings.NewReplacer("{{", "{{ '{{' }}")
+
+// dialect prints Vue templates: text can't open an interpolation, state
+// classes are bound with :class and props are interpolated or bound with :
+var dialect = renderers.MarkupDialect{
+	EscapeText: func(text string) string {
+		return mustache.Replace(html.EscapeString(text))
+	},
+	EscapeAttr: html.EscapeString,
+	BindClasses: func(bindings []renderers.ClassBinding) string {
+		var entries []string
+		for _, binding := range bindings {
+			entries = append(entries, fmt.Sprintf("%s: %s", binding.Class, binding.Condition))
+		}
+		return fmt.Sprintf(`:class="{ %s }"`, strings.Join(entries, ", "))
+	},
+	BindText: func(prop string) string {
+		return fmt.Sprintf("{{ %s }}", prop)
+	},
+	BindAttr: func(name, prop string) string {
+		return fmt.Sprintf(`:%s="%s"`, name, prop)
+	},
+}
+
+// defineProps returns the defineProps call declaring a component's props
+// with their defaults, or "" when it has none
+func defineProps(props []renderers.TemplateProp) string {
+	if len(props) == 0 {
+		return ""
+	}
+	var options []string
+	for _, prop := range props {
+		option := "type: String"
+		if prop.Default != "" {
+			option += ", default: " + renderers.JSString(prop.Default)
+		}
+		if len(prop.Variants) > 0 {
+			var variants []string
+			for _, variant := range prop.Variants {
+				variants = append(variants, renderers.JSString(variant))
+			}
+			option += fmt.Sprintf(", validator: (value) => [%s].includes(value)", strings.Join(variants, ", "))
+		}
+		options = append(options, fmt.Sprintf("  %s: { %s },", prop.Name, option))
+	}
+	return fmt.Sprintf("defineProps({\n%s\n});", strings.Join(options, "\n"))
+}
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	el, err := builder.Atom(atom)
+	if err != nil {
+		return nil, err
+	}
+	return t.component("src/components/atoms", atom.ID, "", el, builder), nil
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	el, err := builder.Molecule(molecule)
+	if err != nil {
+		return nil, err
+	}
+	script := defineProps(renderers.MoleculeProps(t.ctx.Registry, molecule))
+	return t.component("src/components/molecules", molecule.ID, script, el, builder), nil
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+
+	// Behaviors live in composables (see composables.go)
+	var imports, setup []string
+	carousel := renderers.IsCarousel(organism)
+	if carousel {
+		imports = append(imports, "import { useCarousel } from '../../composables/useCarousel';")
+		options := ""
+		if organism.Behavior.Autoplay {
+			options = fmt.Sprintf(", { autoplay: true, interval: %d }", organism.Behavior.Interval)
+		}
+		setup = append(setup, fmt.Sprintf("const { currentSlide, nextSlide, prevSlide } = useCarousel(%d%s);", renderers.CarouselSlides(organism), options))
+		builder.BindSlides(func(slide *renderers.Element, index int) {
+			slide.Attrs = append(slide.Attrs, renderers.Attr{Name: "v-show", Value: fmt.Sprintf("currentSlide === %d", index)})
+		})
+	}
+	if renderers.IsScrollAware(organism) {
+		builder.Drive("scrolled", "scrolled")
+		imports = append(imports, "import { useScrolled } from '../../composables/useScrolled';")
+		setup = append(setup, "const scrolled = useScrolled();")
+	}
+
+	el, err := builder.Organism(organism)
+	if err != nil {
+		return nil, err
+	}
+	if carousel && organism.Behavior.Controls && renderers.CarouselSlides(organism) > 1 {
+		el.Children = append(el.Children, carouselControl("prev", "Previous slide", "‹", "prevSlide"), carouselControl("next", "Next slide", "›", "nextSlide"))
+	}
+
+	var blocks []string
+	if len(imports) > 0 {
+		blocks = append(blocks, strings.Join(imports, "\n"))
+	}
+	if props := defineProps(renderers.OrganismProps(t.ctx.Registry, organism)); props != "" {
+		blocks = append(blocks, props)
+	}
+	if len(setup) > 0 {
+		blocks = append(blocks, strings.Join(setup, "\n"))
+	}
+	script := strings.Join(blocks, "\n\n")
+	return t.component("src/components/organisms", organism.ID, script, el, builder), nil
+}
+
+// carouselControl returns a button moving a carousel to another slide
+func carouselControl(direction, label, text, handler string) *renderers.Element {
+	return &renderers.Element{
+		Tag:     "button",
+		Classes: []string{"carousel-" + direction},
+		Attrs: []renderers.Attr{
+			{Name: "type", Value: "button"},
+			{Name: "aria-label", Value: label},
+			{Name: "@click", Value: handler},
+		},
+		Text: text,
+	}
+}
+
+// component returns the single-file component of an atom, molecule or
+// organism
+func (t *Target) component(dir, id, script string, el *renderers.Element, builder *renderers.MarkupBuilder) []targets.File {
+	return []targets.File{{
+		Path:    fmt.Sprintf("%s/%s.vue", dir, renderers.ToPascalCase(id)),
+		Content: sfc(script, renderers.PrintMarkup(el, 1, dialect), builder.StyleSheet()),
+	}}
+}
+
+// sfc assembles a single-file component, leaving out empty blocks
+func sfc(script, template, style string) string {
+	var blocks []string
+	if script != "" {
+		blocks = append(blocks, fmt.Sprintf("<script setup>\n%s\n</script>", script))
+	}
+	blocks = append(blocks, fmt.Sprintf("<template>\n%s\n</template>", template))
+	if style != "" {
+		blocks = append(blocks, fmt.Sprintf("<style scoped>\n%s</style>", style))
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+func (t *Target) Page(page *models.Page, layout *models.Layout) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+
+	// Pages reference organism components, imported once each
+	var imports []string
+	imported := make(map[string]bool)
+	body, err := builder.Page(layout, func(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+		name := renderers.ToPascalCase(organism.ID)
+		if !imported[name] {
+			imported[name] = true
+			imports = append(imports, fmt.Sprintf("import %s from '../components/organisms/%s.vue';", name, name))
+		}
+		return &renderers.Element{Tag: name, Attrs: renderers.PropAttrs(props), Void: true}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	script := fmt.Sprintf(`import { useHead } from '@unhead/vue';
+%s
+
+%s`, strings.Join(imports, "\n"), head(page))
+
+	return []targets.File{{
+		Path:    fmt.Sprintf("src/pages/%s.vue", renderers.ToPascalCase(page.ID)),
+		Content: sfc(script, renderers.PrintMarkup(body, 1, dialect), ""),
+	}}, nil
+}
+
+// head returns the useHead call setting the page's title and meta tags
+func head(page *models.Page) string {
+	tags := renderers.PageMetaTags(page)
+	if len(tags) == 0 {
+		return fmt.Sprintf("useHead({\n  title: %s,\n});", renderers.JSString(page.Title))
+	}
+
+	var meta []string
+	for _, tag := range tags {
+		attr := tag.Attr
+		if strings.Contains(attr, "-") {
+			attr = renderers.JSString(attr)
+		}
+		meta = append(meta, fmt.Sprintf("{ %s: %s, content: %s },", attr, renderers.JSString(tag.Name), renderers.JSString(tag.Content)))
+	}
+
+	return fmt.Sprintf(`useHead({
+  title: %s,
+  meta: [
+    %s
+  ],
+});`, renderers.JSString(page.Title), strings.Join(meta, "\n    "))
+}
@@ -0,0 +1,172 @@
+package webcomponents
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+// Scaffold returns the shared styles, the entry module defining every
+// custom element and the demo page
+func (t *Target) Scaffold() ([]targets.File, error) {
+	demo, err := t.demo()
+	if err != nil {
+		return nil, err
+	}
+
+	return []targets.File{
+		{Path: "package.json", Content: t.packageJSON()},
+		{Path: "README.md", Content: t.readme()},
+		{Path: "base.js", Content: t.base()},
+		{Path: "index.js", Content: t.entry()},
+		{Path: "index.html", Content: demo},
+	}, nil
+}
+
+func (t *Target) packageJSON() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf(`{
+  "name": "%s",
+  "version": "%s",
+  "type": "module",
+  "main": "index.js",
+  "exports": {
+    ".": "./index.js"
+  },
+  "scripts": {
+    "start": "npx serve ."
+  }
+}
+`, project.ID, project.Version)
+}
+
+func (t *Target) readme() string {
+	project := t.ctx.Registry.Structure().Project
+	return fmt.Sprintf("# %s\n\n%s\n\n## Generated with Atomic Generator\n\nFramework-free custom elements, automatically generated from an atomic design structure.\n\n## Usage\n\n```html\n<script type=\"module\" src=\"index.js\"></script>\n\n<atomic-main-header></atomic-main-header>\n```\n\n`index.js` defines every element and sets the brand tokens on the document. Each element keeps its styles in its Shadow DOM.\n\n## Demo\n\n```bash\nnpx serve .\n```\n\nThen open the printed URL: `index.html` renders the pages with the elements.\n\n## Project Structure\n\n- `components/atoms` - Basic UI elements\n- `components/molecules` - Combinations of atoms\n- `components/organisms` - Complex UI sections\n- `base.js` - Reset shared by every shadow root and brand tokens\n\n## Version\n\n%s\n", project.Name, project.Name, project.Version)
+}
+
+// base returns the module with the stylesheets every element shares
+func (t *Target) base() string {
+	brand := t.ctx.Registry.Structure().Project.Brand
+	return fmt.Sprintf(`// Reset adopted by every shadow root: global styles don't reach into
+// them. Hosts generate no box, so their content lays out (and sticks) as
+// if it were in the page.
+export const baseStyles = new CSSStyleSheet();
+baseStyles.replaceSync(`+"`"+`:host {
+  display: contents;
+}
+
+* {
+  margin: 0;
+  padding: 0;
+  box-sizing: border-box;
+}
+
+img {
+  max-width: 100%%;
+  height: auto;
+}
+
+a {
+  color: inherit;
+  text-decoration: none;
+}
+
+button {
+  font-family: inherit;
+  cursor: pointer;
+}`+"`"+`);
+
+// Brand tokens. Custom properties inherit into shadow roots, so they are
+// set once on the document, where a site can override them.
+export const tokens = new CSSStyleSheet();
+tokens.replaceSync(`+"`"+`:root {
+%s
+}`+"`"+`);
+`, templateLiteral.Replace(targets.BrandVariables(brand)))
+}
+
+// entry returns index.js, which sets the brand tokens and defines every
+// element by importing its module
+func (t *Target) entry() string {
+	structure := t.ctx.Registry.Structure()
+
+	var exports []string
+	export := func(kind, id string) {
+		exports = append(exports, fmt.Sprintf("export { %s } from './%s';", renderers.ToPascalCase(id), modulePath(kind, id)))
+	}
+	for _, atom := range t.ctx.Registry.AllAtoms() {
+		export("atoms", atom.ID)
+	}
+	for _, molecule := range structure.Molecules {
+		export("molecules", molecule.ID)
+	}
+	for _, organism := range structure.Organisms {
+		export("organisms", organism.ID)
+	}
+
+	return fmt.Sprintf(`import { tokens } from './base.js';
+
+document.adoptedStyleSheets = [...document.adoptedStyleSheets, tokens];
+
+%s
+`, strings.Join(exports, "\n"))
+}
+
+// demo returns index.html, rendering the layout of every page with the
+// custom elements
+func (t *Target) demo() (string, error) {
+	structure := t.ctx.Registry.Structure()
+	builder := renderers.NewMarkupBuilder(t.ctx)
+
+	var pages []string
+	for i := range structure.Pages {
+		page := &structure.Pages[i]
+		layout := t.ctx.Registry.Layout(page.Layout)
+		if layout == nil {
+			return "", fmt.Errorf("layout %s not found for page %s", page.Layout, page.ID)
+		}
+
+		body, err := builder.Page(layout, func(organism *models.Organism, props map[string]string) (*renderers.Element, error) {
+			return &renderers.Element{Tag: TagName(organism.ID), Attrs: renderers.PropAttrs(props)}, nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("error rendering page %s: %w", page.ID, err)
+		}
+
+		comment := fmt.Sprintf("    <!-- %s (%s) -->", html.EscapeString(page.Title), renderers.PageRoute(t.ctx.Registry, page))
+		pages = append(pages, comment+"\n"+renderers.PrintMarkup(body, 2, renderers.HTMLDialect))
+	}
+
+	project := structure.Project
+	fonts := targets.FontLinks(project)
+	if fonts != "" {
+		fonts += "\n"
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="es">
+  <head>
+    <meta charset="UTF-8" />
+    <meta name="viewport" content="width=device-width, initial-scale=1.0" />
+    <title>%s</title>
+%s    <style>
+      body {
+        margin: 0;
+        font-family: var(--font-family-primary);
+        color: var(--color-text);
+        background-color: var(--color-background);
+      }
+    </style>
+    <script type="module" src="./index.js"></script>
+  </head>
+  <body>
+%s
+  </body>
+</html>
+`, html.EscapeString(project.Name), fonts, strings.Join(pages, "\n\n")), nil
+}
@@ -0,0 +1,281 @@
+// Package webcomponents is the framework-free target: every atom, molecule
+// and organism is a custom element with its styles in its Shadow DOM.
+package webcomponents
+
+import (
+	"fmt"
+	"strings"
+
+	"atomic-generator/pkg/models"
+	"atomic-generator/pkg/renderers"
+	"atomic-generator/pkg/targets"
+)
+
+func init() {
+	targets.Register("webcomponents", New)
+}
+
+// Target emits standards-based custom elements (<atomic-value-card>), an
+// ES module entry defining all of them and an HTML demo page
+type Target struct {
+	ctx *renderers.Context
+}
+
+func New(ctx *renderers.Context) (targets.Target, error) {
+	if ctx.Options.Lang != renderers.LangJS {
+		return nil, fmt.Errorf("the webcomponents target only emits JavaScript (got -lang=%s)", ctx.Options.Lang)
+	}
+	if ctx.Options.Composition == renderers.CompositionImport {
+		return nil, fmt.Errorf("the webcomponents target always inlines children (got -composition=import)")
+	}
+	return &Target{ctx: ctx}, nil
+}
+
+func (t *Target) Name() string {
+	return "webcomponents"
+}
+
+func (t *Target) Directories() []string {
+	return []string{
+		"components",
+		"components/atoms",
+		"components/molecules",
+		"components/organisms",
+	}
+}
+
+func (t *Target) NextSteps() []string {
+	return []string{
+		"npx serve .",
+		"The demo page will be available at the URL serve prints (ES modules don't load from file://)",
+	}
+}
+
+// TagName returns the custom element name of an atom, molecule or organism
+func TagName(id string) string {
+	return "atomic-" + strings.ReplaceAll(strings.ToLower(id), "_", "-")
+}
+
+// modulePath returns the path of the module defining a custom element
+func modulePath(kind, id string) string {
+	return fmt.Sprintf("components/%s/%s.js", kind, strings.TrimPrefix(TagName(id), "atomic-"))
+}
+
+// templateLiteral escapes s for a JS template literal
+var templateLiteral = strings.NewReplacer("\\", "\\\\", "`", "\\`", "${", "\\${")
+
+func (t *Target) Atom(atom *models.Atom) ([]targets.File, error) {
+	builder := renderers.NewMarkupBuilder(t.ctx)
+	el, err := builder.Atom(atom)
+	if err != nil {
+		return nil, err
+	}
+	return t.element("atoms", atom.ID, el, builder, nil, ""), nil
+}
+
+func (t *Target) Molecule(molecule *models.Molecule) ([]targets.File, error) {
+	builder := newBuilder(t.ctx)
+	el, err := builder.Molecule(molecule)
+	if err != nil {
+		return nil, err
+	}
+	return t.element("molecules", molecule.ID, el, builder, renderers.MoleculeProps(t.ctx.Registry, molecule), ""), nil
+}
+
+func (t *Target) Organism(organism *models.Organism) ([]targets.File, error) {
+	builder := newBuilder(t.ctx)
+
+	var behaviors []string
+	if renderers.IsScrollAware(organism) {
+		builder.Drive("scrolled", "scrolled")
+		behaviors = append(behaviors, scrolledBehavior)
+	}
+
+	el, err := builder.Organism(organism)
+	if err != nil {
+		return nil, err
+	}
+	if renderers.IsCarousel(organism) && organism.Behavior.Autoplay && renderers.CarouselSlides(organism) > 1 {
+		el.Attrs = append(el.Attrs, renderers.Attr{Name: "data-carousel", Boolean: true})
+		behaviors = append(behaviors, fmt.Sprintf(carouselBehavior, organism.Behavior.Interval))
+	}
+
+	return t.element("organisms", organism.ID, el, builder, renderers.OrganismProps(t.ctx.Registry, organism), strings.Join(behaviors, "\n\n")), nil
+}
+
+// newBuilder returns a builder for components taking props: the states of
+// their variants are driven by name, like the scripts of ScriptDialect
+// expect (tone-dark)
+func newBuilder(ctx *renderers.Context) *renderers.MarkupBuilder {
+	builder := renderers.NewMarkupBuilder(ctx)
+	builder.DriveVariants(func(prop, variant string) string {
+		return prop + "-" + variant
+	})
+	return builder
+}
+
+// markProps marks the elements whose text or attributes are bound to a
+// prop with data-<text|attribute>-prop="<prop>", collecting in bound what
+// they bind
+func markProps(el *renderers.Element, bound map[string]bool) {
+	if el.TextProp != "" {
+		el.Attrs = append(el.Attrs, renderers.Attr{Name: "data-text-prop", Value: el.TextProp})
+		bound["text"] = true
+	}
+	for _, attr := range el.Attrs {
+		if attr.Prop != "" {
+			el.Attrs = append(el.Attrs, renderers.Attr{Name: "data-" + attr.Name + "-prop", Value: attr.Prop})
+			bound[attr.Name] = true
+		}
+	}
+	for _, child := range el.Children {
+		markProps(child, bound)
+	}
+}
+
+// render returns the render method showing the props of a component set as
+// attributes, or their defaults, in the elements markProps marked
+func render(className string, props []renderers.TemplateProp, bound map[string]bool) string {
+	lines := []string{fmt.Sprintf("const prop = (name) => this.getAttribute(name) ?? %s.defaults[name];", className)}
+	for _, name := range renderers.SortedKeys(bound) {
+		// Props bind text, href and src: their dataset keys need no casing
+		dataset := "element.dataset." + name + "Prop"
+		update := fmt.Sprintf("element.setAttribute('%s', prop(%s));", name, dataset)
+		if name == "text" {
+			update = fmt.Sprintf("element.textContent = prop(%s);", dataset)
+		}
+		lines = append(lines, fmt.Sprintf(`this.shadowRoot.querySelectorAll('[data-%s-prop]').forEach((element) => {
+  %s
+});`, name, update))
+	}
+	for _, prop := range props {
+		if len(prop.Variants) == 0 {
+			continue
+		}
+		var variants []string
+		for _, variant := range prop.Variants {
+			variants = append(variants, renderers.JSString(variant))
+		}
+		lines = append(lines, fmt.Sprintf(`[%s].forEach((variant) => {
+  this.shadowRoot.querySelectorAll(`+"`[data-state-%s-${variant}]`"+`).forEach((element) => {
+    element.getAttribute(`+"`data-state-%s-${variant}`"+`).split(' ').forEach((name) => {
+      element.classList.toggle(name, prop(%s) === variant);
+    });
+  });
+});`, strings.Join(variants, ", "), prop.Name, prop.Name, renderers.JSString(prop.Name)))
+	}
+	return fmt.Sprintf(`
+
+  // render shows the props set as attributes, or their defaults
+  render() {
+%s
+  }`, renderers.IndentCode(strings.Join(lines, "\n"), 2))
+}
+
+// element returns the module defining the custom element of an atom,
+// molecule or organism. Its props are observed attributes. behavior is the
+// body of connectedCallback, set up so that returned cleanups run on
+// disconnect.
+func (t *Target) element(kind, id string, el *renderers.Element, builder *renderers.MarkupBuilder, props []renderers.TemplateProp, behavior string) []targets.File {
+	className := renderers.ToPascalCase(id)
+	tagName := TagName(id)
+
+	fields, callbacks := "", ""
+	var connected []string
+	if len(props) > 0 {
+		bound := make(map[string]bool)
+		markProps(el, bound)
+
+		var names, defaults []string
+		for _, prop := range props {
+			names = append(names, renderers.JSString(prop.Name))
+			defaults = append(defaults, fmt.Sprintf("%s: %s", prop.Name, renderers.JSString(prop.Default)))
+		}
+		fields = fmt.Sprintf(`
+  static observedAttributes = [%s];
+
+  static defaults = { %s };
+`, strings.Join(names, ", "), strings.Join(defaults, ", "))
+		connected = append(connected, "this.render();")
+		callbacks = `
+
+  attributeChangedCallback() {
+    this.render();
+  }` + render(className, props, bound)
+	}
+	if behavior != "" {
+		connected = append(connected, "this.cleanups = [];", behavior)
+	}
+	if len(connected) > 0 {
+		disconnected := ""
+		if behavior != "" {
+			disconnected = `
+
+  disconnectedCallback() {
+    this.cleanups.forEach((cleanup) => cleanup());
+  }`
+		}
+		callbacks = fmt.Sprintf(`
+
+  connectedCallback() {
+%s
+  }%s`, renderers.IndentCode(strings.Join(connected, "\n"), 2), disconnected) + callbacks
+	}
+
+	content := fmt.Sprintf(`import { baseStyles } from '../../base.js';
+
+const styles = new CSSStyleSheet();
+styles.replaceSync(`+"`%s`"+`);
+
+const template = document.createElement('template');
+template.innerHTML = `+"`\n%s\n`"+`;
+
+export class %s extends HTMLElement {%s
+  constructor() {
+    super();
+    const root = this.attachShadow({ mode: 'open' });
+    root.adoptedStyleSheets = [baseStyles, styles];
+    root.appendChild(template.content.cloneNode(true));
+  }%s
+}
+
+customElements.define('%s', %s);
+`, templateLiteral.Replace(builder.StyleSheet()), templateLiteral.Replace(renderers.PrintMarkup(el, 0, renderers.ScriptDialect)), className, fields, callbacks, tagName, className)
+
+	return []targets.File{{Path: modulePath(kind, id), Content: content}}
+}
+
+// scrolledBehavior toggles the scrolled state classes of a scroll-aware
+// header (see renderers.ScriptDialect)
+const scrolledBehavior = `const scrolled = this.shadowRoot.querySelectorAll('[data-state-scrolled]');
+const update = () => {
+  scrolled.forEach((element) => {
+    element.dataset.stateScrolled.split(' ').forEach((name) => {
+      element.classList.toggle(name, window.scrollY > 50);
+    });
+  });
+};
+window.addEventListener('scroll', update);
+update();
+this.cleanups.push(() => window.removeEventListener('scroll', update));`
+
+// carouselBehavior shows one slide of an autoplay carousel at a time
+const carouselBehavior = `const slides = Array.from(this.shadowRoot.querySelector('[data-carousel]').children);
+let current = 0;
+const show = () => {
+  slides.forEach((slide, i) => {
+    slide.style.display = i === current ? '' : 'none';
+  });
+};
+show();
+const interval = setInterval(() => {
+  current = (current + 1) %% slides.length;
+  show();
+}, %d);
+this.cleanups.push(() => clearInterval(interval));`
+
+// Page adds nothing: the demo page, written by Scaffold, shows the pages'
+// layouts
+func (t *Target) Page(*models.Page, *models.Layout) ([]targets.File, error) {
+	return nil, nil
+}